@@ -35,6 +35,13 @@ func listenHTTPServer(r *gin.Engine, port int) *http.Server {
 func main() {
 	cfg := config.MustLoadConfig()
 
+	if len(os.Args) > 1 {
+		if err := app.RunCommand(cfg, os.Args[1:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	r := gin.Default()
 
 	close := app.MustConfigureApp(r, cfg)
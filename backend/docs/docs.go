@@ -67,22 +67,26 @@ const docTemplate = `{
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Number of page",
+                        "description": "Number of page, from 0",
                         "name": "pageNum",
-                        "in": "query",
-                        "required": true
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor from previous page, empty for the first page. Can not be used with pageNum",
+                        "name": "cursor",
+                        "in": "query"
                     },
                     {
                         "type": "integer",
-                        "description": "Size of page",
+                        "description": "Size of page, 20 by default, at most 100",
                         "name": "pageSize",
-                        "in": "query",
-                        "required": true
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Page of applications",
+                        "description": "Page of applications, newest first",
                         "schema": {
                             "$ref": "#/definitions/docs.GetApplicationsResponse"
                         }
@@ -99,9 +103,6 @@ const docTemplate = `{
                     "403": {
                         "description": "Only available for reviewer"
                     },
-                    "404": {
-                        "description": "Page with given number not found"
-                    },
                     "500": {
                         "description": "Internal server error"
                     }
@@ -113,7 +114,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Creates application for given offer",
+                "description": "Creates application for given offer. If there are no free places, application is waitlisted",
                 "consumes": [
                     "application/json"
                 ],
@@ -154,6 +155,12 @@ const docTemplate = `{
                     "403": {
                         "description": "Only available for reviewer"
                     },
+                    "409": {
+                        "description": "Offer is not accepting applications",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error"
                     }
@@ -245,17 +252,15 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Number of page",
+                        "description": "Number of page, from 0",
                         "name": "pageNum",
-                        "in": "query",
-                        "required": true
+                        "in": "query"
                     },
                     {
                         "type": "integer",
-                        "description": "Size of page",
+                        "description": "Size of page, 20 by default, at most 100",
                         "name": "pageSize",
-                        "in": "query",
-                        "required": true
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -277,9 +282,6 @@ const docTemplate = `{
                     "403": {
                         "description": "Only available for reviewer"
                     },
-                    "404": {
-                        "description": "Page with given number not found"
-                    },
                     "500": {
                         "description": "Internal server error"
                     }
@@ -338,30 +340,33 @@ const docTemplate = `{
                 }
             }
         },
-        "/hotel/": {
-            "get": {
+        "/application/{id}/withdraw": {
+            "patch": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "GetHotels all hotels",
-                "produces": [
-                    "application/json"
-                ],
+                "description": "Withdraws own application before the draw. The freed place goes to the first waitlisted application",
                 "tags": [
-                    "Hotel"
+                    "Application"
+                ],
+                "summary": "Withdraw application",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Id of application",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get hotels",
                 "responses": {
-                    "200": {
-                        "description": "Page of hotels",
-                        "schema": {
-                            "$ref": "#/definitions/docs.GetHotelsResponse"
-                        }
+                    "204": {
+                        "description": "Application withdrawn"
                     },
                     "400": {
-                        "description": "Invalid data for getting hotels",
+                        "description": "Invalid application id",
                         "schema": {
                             "type": "string"
                         }
@@ -370,53 +375,71 @@ const docTemplate = `{
                         "description": "Unauthorized"
                     },
                     "403": {
-                        "description": "Only available for admin"
+                        "description": "Application does not belong to user"
                     },
                     "404": {
-                        "description": "Page with given number not found"
+                        "description": "Application with given id not found"
+                    },
+                    "409": {
+                        "description": "Offer is not accepting applications or application can not be withdrawn",
+                        "schema": {
+                            "type": "string"
+                        }
                     },
                     "500": {
                         "description": "Internal server error"
                     }
                 }
-            },
+            }
+        },
+        "/catalog/import": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Creates offer with given info",
+                "description": "Upserts locations, hotels and room types from Ostrovok feed by external id",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Hotel"
+                    "Catalog"
                 ],
-                "summary": "Create hotel",
+                "summary": "Import catalog",
                 "parameters": [
                     {
-                        "description": "Data for creating hotel",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/docs.CreateHotelRequest"
-                        }
+                        "type": "file",
+                        "description": "Feed in JSON lines or CSV",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "jsonl or csv, by default taken from file extension",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only report what would be changed",
+                        "name": "dryRun",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created hotel data",
+                    "200": {
+                        "description": "Import report",
                         "schema": {
-                            "$ref": "#/definitions/docs.CreateHotelResponse"
+                            "$ref": "#/definitions/docs.CatalogImportResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid data for creating hotel",
+                        "description": "Invalid feed",
                         "schema": {
                             "type": "string"
                         }
@@ -425,7 +448,7 @@ const docTemplate = `{
                         "description": "Unauthorized"
                     },
                     "403": {
-                        "description": "Only available for admin"
+                        "description": "Only available with catalog:write permission"
                     },
                     "500": {
                         "description": "Internal server error"
@@ -433,30 +456,38 @@ const docTemplate = `{
                 }
             }
         },
-        "/location/": {
+        "/draw/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "GetLocations all locations",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Location"
+                    "Draw"
+                ],
+                "summary": "Get draw by id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Draw ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get locations",
                 "responses": {
                     "200": {
-                        "description": "Page of locations",
+                        "description": "Draw",
                         "schema": {
-                            "$ref": "#/definitions/docs.GetLocationsResponse"
+                            "$ref": "#/definitions/docs.DrawResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid data for getting locations",
+                        "description": "Invalid draw id",
                         "schema": {
                             "type": "string"
                         }
@@ -465,53 +496,53 @@ const docTemplate = `{
                         "description": "Unauthorized"
                     },
                     "403": {
-                        "description": "Only available for admin"
+                        "description": "Only available with draw:audit permission"
                     },
                     "404": {
-                        "description": "Page with given number not found"
+                        "description": "Draw not found",
+                        "schema": {
+                            "type": "string"
+                        }
                     },
                     "500": {
                         "description": "Internal server error"
                     }
                 }
-            },
+            }
+        },
+        "/draw/{id}/replay": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Creates location with given info",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Repeats draw with stored seed, candidates and algorithm version and checks that winners are the same",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Location"
+                    "Draw"
                 ],
-                "summary": "Create location",
+                "summary": "Replay draw",
                 "parameters": [
                     {
-                        "description": "Data for creating location",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/docs.CreateLocationRequest"
-                        }
+                        "type": "string",
+                        "description": "Draw ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created location data",
+                    "200": {
+                        "description": "Replay result",
                         "schema": {
-                            "$ref": "#/definitions/docs.CreateLocationResponse"
+                            "$ref": "#/definitions/docs.ReplayDrawResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid data for creating location",
+                        "description": "Invalid draw id",
                         "schema": {
                             "type": "string"
                         }
@@ -520,7 +551,19 @@ const docTemplate = `{
                         "description": "Unauthorized"
                     },
                     "403": {
-                        "description": "Only available for admin"
+                        "description": "Only available with draw:audit permission"
+                    },
+                    "404": {
+                        "description": "Draw not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "422": {
+                        "description": "Draw was made by unknown algorithm version",
+                        "schema": {
+                            "type": "string"
+                        }
                     },
                     "500": {
                         "description": "Internal server error"
@@ -528,46 +571,44 @@ const docTemplate = `{
                 }
             }
         },
-        "/offer/": {
+        "/hotel/": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "GetForPage all offers with pagination",
+                "description": "GetHotels all hotels ordered by name",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Offer"
+                    "Hotel"
                 ],
-                "summary": "GetForPage offers",
+                "summary": "Get hotels",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Number of page",
-                        "name": "pageNum",
-                        "in": "query",
-                        "required": true
+                        "type": "string",
+                        "description": "next_cursor from previous page, empty for the first page. Without cursor the whole list is returned",
+                        "name": "cursor",
+                        "in": "query"
                     },
                     {
                         "type": "integer",
-                        "description": "Size of page",
+                        "description": "Size of page, 20 by default, at most 100",
                         "name": "pageSize",
-                        "in": "query",
-                        "required": true
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Page of offers",
+                        "description": "Page of hotels",
                         "schema": {
-                            "$ref": "#/definitions/docs.GetOffersResponse"
+                            "$ref": "#/definitions/docs.GetHotelsResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid data for getting offers",
+                        "description": "Invalid data for getting hotels",
                         "schema": {
                             "type": "string"
                         }
@@ -578,9 +619,6 @@ const docTemplate = `{
                     "403": {
                         "description": "Only available for admin"
                     },
-                    "404": {
-                        "description": "Page with given number not found"
-                    },
                     "500": {
                         "description": "Internal server error"
                     }
@@ -600,29 +638,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Offer"
+                    "Hotel"
                 ],
-                "summary": "Create offer",
+                "summary": "Create hotel",
                 "parameters": [
                     {
-                        "description": "Data for creating offer",
+                        "description": "Data for creating hotel",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/docs.CreateOfferRequest"
+                            "$ref": "#/definitions/docs.CreateHotelRequest"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Created offer data",
+                        "description": "Created hotel data",
                         "schema": {
-                            "$ref": "#/definitions/docs.CreateOfferResponse"
+                            "$ref": "#/definitions/docs.CreateHotelResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid data for creating offer",
+                        "description": "Invalid data for creating hotel",
                         "schema": {
                             "type": "string"
                         }
@@ -633,111 +671,87 @@ const docTemplate = `{
                     "403": {
                         "description": "Only available for admin"
                     },
+                    "409": {
+                        "description": "Hotel with this name already exists",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error"
                     }
                 }
             }
         },
-        "/offer/search": {
+        "/hotel/{id}": {
             "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Find offers with given search params",
+                "description": "Returns hotel with contacts and cover images",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Offer"
+                    "Hotel"
                 ],
-                "summary": "Find offers",
+                "summary": "Get hotel by id",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Id of required city",
-                        "name": "cityId",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Number of page",
-                        "name": "pageNum",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Size of page",
-                        "name": "pageSize",
-                        "in": "query",
+                        "description": "Hotel id",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Page of offers found",
+                        "description": "Hotel",
                         "schema": {
-                            "$ref": "#/definitions/docs.GetOffersResponse"
+                            "$ref": "#/definitions/docs.HotelResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid data for finding offers",
+                        "description": "Invalid hotel id",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized"
-                    },
-                    "403": {
-                        "description": "Only available for reviewer"
-                    },
                     "404": {
-                        "description": "Page with given number not found"
+                        "description": "Hotel not found",
+                        "schema": {
+                            "type": "string"
+                        }
                     },
                     "500": {
                         "description": "Internal server error"
                     }
                 }
-            }
-        },
-        "/offer/{id}": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "GetForPage offer by id",
-                "produces": [
-                    "application/json"
-                ],
+                "description": "Marks hotel and its own rooms as deleted. Hotel with offers in progress or active templates can not be deleted",
                 "tags": [
-                    "Offer"
+                    "Hotel"
                 ],
-                "summary": "GetForPage by id",
+                "summary": "Delete hotel",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Id of requested offer",
+                        "description": "Hotel id",
                         "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Requested offer",
-                        "schema": {
-                            "$ref": "#/definitions/docs.OfferResponse"
-                        }
+                    "204": {
+                        "description": "Hotel deleted"
                     },
                     "400": {
-                        "description": "Invalid data for getting offer by id",
+                        "description": "Invalid hotel id",
                         "schema": {
                             "type": "string"
                         }
@@ -746,10 +760,19 @@ const docTemplate = `{
                         "description": "Unauthorized"
                     },
                     "403": {
-                        "description": "Only available for admin"
+                        "description": "Only available with catalog:write permission"
                     },
                     "404": {
-                        "description": "Offer with given id not found"
+                        "description": "Hotel not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Hotel has active offers",
+                        "schema": {
+                            "type": "string"
+                        }
                     },
                     "500": {
                         "description": "Internal server error"
@@ -762,7 +785,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update offer with given id and data",
+                "description": "Changes only given fields of hotel",
                 "consumes": [
                     "application/json"
                 ],
@@ -770,33 +793,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Offer"
+                    "Hotel"
                 ],
-                "summary": "Update offer",
+                "summary": "Update hotel",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Id of offer to update",
+                        "description": "Hotel id",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Data for updating offer",
+                        "description": "Fields to change",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/docs.UpdateOfferRequest"
+                            "$ref": "#/definitions/docs.UpdateHotelRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully updated offer"
+                        "description": "Updated hotel",
+                        "schema": {
+                            "$ref": "#/definitions/docs.HotelResponse"
+                        }
                     },
                     "400": {
-                        "description": "Invalid data for finding offers",
+                        "description": "Invalid data for updating hotel",
                         "schema": {
                             "type": "string"
                         }
@@ -805,10 +831,19 @@ const docTemplate = `{
                         "description": "Unauthorized"
                     },
                     "403": {
-                        "description": "Only available for admin"
+                        "description": "Only available with catalog:write permission"
                     },
                     "404": {
-                        "description": "Offer with given id not found"
+                        "description": "Hotel not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Hotel with this name already exists",
+                        "schema": {
+                            "type": "string"
+                        }
                     },
                     "500": {
                         "description": "Internal server error"
@@ -816,46 +851,49 @@ const docTemplate = `{
                 }
             }
         },
-        "/report/": {
-            "get": {
+        "/hotel/{id}/images": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "GetForPage all reports with pagination",
+                "description": "Appends images to the end of hotel cover list",
+                "consumes": [
+                    "multipart/form-data"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Report"
+                    "Hotel"
                 ],
-                "summary": "GetForPage reports",
+                "summary": "Upload hotel cover images",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Number of page",
-                        "name": "pageNum",
-                        "in": "query",
+                        "type": "string",
+                        "description": "Hotel id",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Size of page",
-                        "name": "pageSize",
-                        "in": "query",
+                        "type": "file",
+                        "description": "Images",
+                        "name": "images",
+                        "in": "formData",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Page of reports",
+                        "description": "Hotel with images",
                         "schema": {
-                            "$ref": "#/definitions/docs.GetReportsResponse"
+                            "$ref": "#/definitions/docs.HotelResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid data for getting reports",
+                        "description": "Invalid hotel id or no images",
                         "schema": {
                             "type": "string"
                         }
@@ -864,10 +902,13 @@ const docTemplate = `{
                         "description": "Unauthorized"
                     },
                     "403": {
-                        "description": "Only available for admin"
+                        "description": "Only available with catalog:write permission"
                     },
                     "404": {
-                        "description": "Page with given number not found"
+                        "description": "Hotel not found",
+                        "schema": {
+                            "type": "string"
+                        }
                     },
                     "500": {
                         "description": "Internal server error"
@@ -875,46 +916,39 @@ const docTemplate = `{
                 }
             }
         },
-        "/report/my": {
-            "get": {
+        "/hotel/{id}/images/{imageId}": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "GetForPage all reports of current user with pagination",
-                "produces": [
-                    "application/json"
-                ],
                 "tags": [
-                    "Report"
+                    "Hotel"
                 ],
-                "summary": "GetForPage my reports",
+                "summary": "Delete hotel cover image",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Number of page",
-                        "name": "pageNum",
-                        "in": "query",
+                        "type": "string",
+                        "description": "Hotel id",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Size of page",
-                        "name": "pageSize",
-                        "in": "query",
+                        "type": "string",
+                        "description": "Image id",
+                        "name": "imageId",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Page of reports",
-                        "schema": {
-                            "$ref": "#/definitions/docs.GetReportsResponse"
-                        }
+                    "204": {
+                        "description": "Image deleted"
                     },
                     "400": {
-                        "description": "Invalid data for getting reports",
+                        "description": "Invalid id",
                         "schema": {
                             "type": "string"
                         }
@@ -923,10 +957,13 @@ const docTemplate = `{
                         "description": "Unauthorized"
                     },
                     "403": {
-                        "description": "User is not reviewer"
+                        "description": "Only available with catalog:write permission"
                     },
                     "404": {
-                        "description": "Page with given number not found"
+                        "description": "Image not found",
+                        "schema": {
+                            "type": "string"
+                        }
                     },
                     "500": {
                         "description": "Internal server error"
@@ -934,39 +971,44 @@ const docTemplate = `{
                 }
             }
         },
-        "/report/my/application/{id}": {
+        "/location/": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get my report by application id",
+                "description": "GetLocations all locations ordered by name",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Report"
+                    "Location"
                 ],
-                "summary": "Get by application id",
+                "summary": "Get locations",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Id of corresponding application",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "next_cursor from previous page, empty for the first page. Without cursor the whole list is returned",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Size of page, 20 by default, at most 100",
+                        "name": "pageSize",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Requested report",
+                        "description": "Page of locations",
                         "schema": {
-                            "$ref": "#/definitions/docs.GetByApplicationIdResponse"
+                            "$ref": "#/definitions/docs.GetLocationsResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid data for getting report by id",
+                        "description": "Invalid data for getting locations",
                         "schema": {
                             "type": "string"
                         }
@@ -975,53 +1017,50 @@ const docTemplate = `{
                         "description": "Unauthorized"
                     },
                     "403": {
-                        "description": "User is not reviewer or this report does not belong to user",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "404": {
-                        "description": "Report with given id not found"
+                        "description": "Only available for admin"
                     },
                     "500": {
                         "description": "Internal server error"
                     }
                 }
-            }
-        },
-        "/report/my/{id}": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "GetForPage report of current user by id",
+                "description": "Creates location with given info",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Report"
+                    "Location"
                 ],
-                "summary": "GetForPage my by id",
+                "summary": "Create location",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Id of requested report",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Data for creating location",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.CreateLocationRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Requested report",
+                    "201": {
+                        "description": "Created location data",
                         "schema": {
-                            "$ref": "#/definitions/docs.ReportResponse"
+                            "$ref": "#/definitions/docs.CreateLocationResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid data for getting report by id",
+                        "description": "Invalid data for creating location",
                         "schema": {
                             "type": "string"
                         }
@@ -1030,130 +1069,88 @@ const docTemplate = `{
                         "description": "Unauthorized"
                     },
                     "403": {
-                        "description": "User is not reviewer or this report does not belong to user",
+                        "description": "Only available for admin"
+                    },
+                    "409": {
+                        "description": "Location with this name already exists",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "404": {
-                        "description": "Report with given id not found"
-                    },
                     "500": {
                         "description": "Internal server error"
                     }
                 }
             }
         },
-        "/report/search": {
+        "/location/{id}": {
             "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "GetReportsByFilter reports by filter with pagination",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Report"
+                    "Location"
                 ],
-                "summary": "GetReportsByFilter reports by filter",
+                "summary": "Get location by id",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "cityId if report",
-                        "name": "cityId",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "hotelId of report",
-                        "name": "hotelId",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Status of report",
-                        "name": "status",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Number of page",
-                        "name": "pageNum",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Size of page",
-                        "name": "pageSize",
-                        "in": "query",
+                        "description": "Location id",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Page of reports",
+                        "description": "Location",
                         "schema": {
-                            "$ref": "#/definitions/docs.GetReportsResponse"
+                            "$ref": "#/definitions/docs.LocationResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid data for getting reports",
+                        "description": "Invalid location id",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized"
-                    },
-                    "403": {
-                        "description": "Only available for admin"
-                    },
                     "404": {
-                        "description": "Page with given number not found"
+                        "description": "Location not found",
+                        "schema": {
+                            "type": "string"
+                        }
                     },
                     "500": {
                         "description": "Internal server error"
                     }
                 }
-            }
-        },
-        "/report/{id}": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "GetForPage report by id",
-                "produces": [
-                    "application/json"
-                ],
+                "description": "Marks location as deleted. Location with hotels can not be deleted",
                 "tags": [
-                    "Report"
+                    "Location"
                 ],
-                "summary": "GetForPage by id",
+                "summary": "Delete location",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Id of requested report",
+                        "description": "Location id",
                         "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Requested report",
-                        "schema": {
-                            "$ref": "#/definitions/docs.ReportResponse"
-                        }
+                    "204": {
+                        "description": "Location deleted"
                     },
                     "400": {
-                        "description": "Invalid data for getting report by id",
+                        "description": "Invalid location id",
                         "schema": {
                             "type": "string"
                         }
@@ -1162,10 +1159,19 @@ const docTemplate = `{
                         "description": "Unauthorized"
                     },
                     "403": {
-                        "description": "Only available for admin"
+                        "description": "Only available with catalog:write permission"
                     },
                     "404": {
-                        "description": "Report with given id not found"
+                        "description": "Location not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Location has hotels",
+                        "schema": {
+                            "type": "string"
+                        }
                     },
                     "500": {
                         "description": "Internal server error"
@@ -1178,39 +1184,43 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Updates report with given text and photos",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Report"
+                    "Location"
                 ],
-                "summary": "Update report",
+                "summary": "Rename location",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Id of report to update",
+                        "description": "Location id",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Report text",
-                        "name": "text",
-                        "in": "formData",
-                        "required": true
+                        "description": "New location name",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.UpdateLocationRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully update report"
+                        "description": "Updated location",
+                        "schema": {
+                            "$ref": "#/definitions/docs.LocationResponse"
+                        }
                     },
                     "400": {
-                        "description": "Invalid data for updating report",
+                        "description": "Invalid data for updating location",
                         "schema": {
                             "type": "string"
                         }
@@ -1219,7 +1229,19 @@ const docTemplate = `{
                         "description": "Unauthorized"
                     },
                     "403": {
-                        "description": "Only available for reviewer"
+                        "description": "Only available with catalog:write permission"
+                    },
+                    "404": {
+                        "description": "Location not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Location with this name already exists",
+                        "schema": {
+                            "type": "string"
+                        }
                     },
                     "500": {
                         "description": "Internal server error"
@@ -1227,48 +1249,76 @@ const docTemplate = `{
                 }
             }
         },
-        "/report/{id}/confirm": {
-            "patch": {
+        "/offer-template/": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Confirms or declains report",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Returns all offer templates",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Report"
+                    "Offer template"
                 ],
-                "summary": "Confirm report",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Id of report to update",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Data for updating report",
+                "summary": "Get offer templates",
+                "responses": {
+                    "200": {
+                        "description": "Templates",
+                        "schema": {
+                            "$ref": "#/definitions/docs.GetOfferTemplatesResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Only available with offer:write permission"
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates recurring offer. Offers are created by scheduler ahead of time and published on their publish date",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Offer template"
+                ],
+                "summary": "Create offer template",
+                "parameters": [
+                    {
+                        "description": "Template data",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/docs.ConfirmReport"
+                            "$ref": "#/definitions/docs.CreateOfferTemplateRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Successfully update status of report"
+                    "201": {
+                        "description": "Template created",
+                        "schema": {
+                            "$ref": "#/definitions/docs.CreateOfferTemplateResponse"
+                        }
                     },
                     "400": {
-                        "description": "Invalid data for changing report status",
+                        "description": "Invalid data for creating template",
                         "schema": {
                             "type": "string"
                         }
@@ -1277,7 +1327,7 @@ const docTemplate = `{
                         "description": "Unauthorized"
                     },
                     "403": {
-                        "description": "Only available for admin"
+                        "description": "Only available with offer:write permission"
                     },
                     "500": {
                         "description": "Internal server error"
@@ -1285,30 +1335,38 @@ const docTemplate = `{
                 }
             }
         },
-        "/room/": {
+        "/offer-template/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "GetRooms all rooms",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Room"
+                    "Offer template"
+                ],
+                "summary": "Get offer template",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get rooms",
                 "responses": {
                     "200": {
-                        "description": "Page of rooms",
+                        "description": "Template",
                         "schema": {
-                            "$ref": "#/definitions/docs.GetRoomsResponse"
+                            "$ref": "#/definitions/docs.OfferTemplateResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid data for getting rooms",
+                        "description": "Invalid template id",
                         "schema": {
                             "type": "string"
                         }
@@ -1317,53 +1375,47 @@ const docTemplate = `{
                         "description": "Unauthorized"
                     },
                     "403": {
-                        "description": "Only available for admin"
+                        "description": "Only available with offer:write permission"
                     },
                     "404": {
-                        "description": "Page with given number not found"
+                        "description": "Template not found",
+                        "schema": {
+                            "type": "string"
+                        }
                     },
                     "500": {
                         "description": "Internal server error"
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/offer-template/{id}/activate": {
+            "patch": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Creates offer with given info",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+                "description": "Resumes creating offers by template. Occurrences whose application period has already ended are skipped",
                 "tags": [
-                    "Room"
+                    "Offer template"
                 ],
-                "summary": "Create offer",
+                "summary": "Activate offer template",
                 "parameters": [
                     {
-                        "description": "Data for creating room",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/docs.CreateRoomRequest"
-                        }
+                        "type": "string",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created room data",
-                        "schema": {
-                            "$ref": "#/definitions/docs.CreateRoomResponse"
-                        }
+                    "204": {
+                        "description": "Template activated"
                     },
                     "400": {
-                        "description": "Invalid data for creating room",
+                        "description": "Invalid template id, or template hotel or room was deleted",
                         "schema": {
                             "type": "string"
                         }
@@ -1372,7 +1424,13 @@ const docTemplate = `{
                         "description": "Unauthorized"
                     },
                     "403": {
-                        "description": "Only available for admin"
+                        "description": "Only available with offer:write permission"
+                    },
+                    "404": {
+                        "description": "Template not found",
+                        "schema": {
+                            "type": "string"
+                        }
                     },
                     "500": {
                         "description": "Internal server error"
@@ -1380,30 +1438,33 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/": {
-            "get": {
+        "/offer-template/{id}/deactivate": {
+            "patch": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "GetForPage data of current user",
-                "produces": [
-                    "application/json"
-                ],
+                "description": "Stops creating offers by template. Already created offers are not changed",
                 "tags": [
-                    "User"
+                    "Offer template"
+                ],
+                "summary": "Deactivate offer template",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "GetForPage me",
                 "responses": {
-                    "200": {
-                        "description": "User data",
-                        "schema": {
-                            "$ref": "#/definitions/docs.UserResponse"
-                        }
+                    "204": {
+                        "description": "Template deactivated"
                     },
                     "400": {
-                        "description": "Bad request to get user data",
+                        "description": "Invalid template id",
                         "schema": {
                             "type": "string"
                         }
@@ -1411,8 +1472,14 @@ const docTemplate = `{
                     "401": {
                         "description": "Unauthorized"
                     },
+                    "403": {
+                        "description": "Only available with offer:write permission"
+                    },
                     "404": {
-                        "description": "User not found"
+                        "description": "Template not found",
+                        "schema": {
+                            "type": "string"
+                        }
                     },
                     "500": {
                         "description": "Internal server error"
@@ -1420,58 +1487,69 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/log-in": {
-            "post": {
-                "description": "Log in with given name and password",
-                "consumes": [
-                    "application/json"
+        "/offer/": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "GetForPage all offers with pagination",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Offer"
                 ],
-                "summary": "Log in",
+                "summary": "GetForPage offers",
                 "parameters": [
                     {
-                        "description": "Data for log in",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/docs.LogInRequest"
-                        }
+                        "type": "integer",
+                        "description": "Number of page, from 0",
+                        "name": "pageNum",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Size of page, 20 by default, at most 100",
+                        "name": "pageSize",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Auth data",
+                        "description": "Page of offers",
                         "schema": {
-                            "$ref": "#/definitions/docs.AuthResponse"
+                            "$ref": "#/definitions/docs.GetOffersResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid data for log in",
+                        "description": "Invalid data for getting offers",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "401": {
-                        "description": "Wrong password"
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Only available for admin"
                     },
                     "404": {
-                        "description": "User not found"
+                        "description": "Page with given number not found"
                     },
                     "500": {
                         "description": "Internal server error"
                     }
                 }
-            }
-        },
-        "/user/refresh": {
+            },
             "post": {
-                "description": "Refresh auth credentials via refresh token",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates offer with given info",
                 "consumes": [
                     "application/json"
                 ],
@@ -1479,119 +1557,2841 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Offer"
                 ],
-                "summary": "Refresh credentials",
+                "summary": "Create offer",
                 "parameters": [
                     {
-                        "description": "Data for refresh",
+                        "description": "Data for creating offer",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/docs.RefreshRequest"
+                            "$ref": "#/definitions/docs.CreateOfferRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Auth data",
+                    "201": {
+                        "description": "Created offer data",
                         "schema": {
-                            "$ref": "#/definitions/docs.AuthResponse"
+                            "$ref": "#/definitions/docs.CreateOfferResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid data for refresh",
+                        "description": "Invalid data for creating offer",
                         "schema": {
                             "type": "string"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Only available for admin"
+                    },
                     "500": {
                         "description": "Internal server error"
                     }
                 }
             }
         },
-        "/user/sign-up": {
+        "/offer/import": {
             "post": {
-                "description": "Sign up with given name and password",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates offers from CSV or XLSX file with header hotel,room,task,check_in,check_out,expiration_at,participants_limit\nand optional winners_count,draft. Hotel and room are matched by id or name.\nOffers are created all at once: if any row is invalid, nothing is created and errors are returned for every row",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Offer"
                 ],
-                "summary": "Sign up",
+                "summary": "Import offers",
                 "parameters": [
                     {
-                        "description": "Data for sign up",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/docs.SignUpRequest"
-                        }
+                        "type": "file",
+                        "description": "Offers in CSV or XLSX",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "csv or xlsx, by default taken from file extension",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only validate rows, do not create offers",
+                        "name": "dryRun",
+                        "in": "query"
                     }
                 ],
                 "responses": {
+                    "200": {
+                        "description": "Dry run passed",
+                        "schema": {
+                            "$ref": "#/definitions/docs.OfferImportResponse"
+                        }
+                    },
                     "201": {
-                        "description": "Auth data",
+                        "description": "Offers created",
                         "schema": {
-                            "$ref": "#/definitions/docs.AuthResponse"
+                            "$ref": "#/definitions/docs.OfferImportResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid data for sign up",
+                        "description": "Invalid file",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "404": {
-                        "description": "User not found"
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Only available with offer:write permission"
+                    },
+                    "422": {
+                        "description": "Some rows are invalid, nothing created",
+                        "schema": {
+                            "$ref": "#/definitions/docs.OfferImportResponse"
+                        }
                     },
                     "500": {
                         "description": "Internal server error"
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "docs.AchievementResponse": {
-            "type": "object",
-            "properties": {
-                "name": {
-                    "type": "string"
-                },
-                "raiting_limit": {
-                    "type": "integer"
-                }
+        },
+        "/offer/map": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Open offers of hotels with coordinates as GeoJSON FeatureCollection, one point per offer",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Offer"
+                ],
+                "summary": "Offers map",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Id of city",
+                        "name": "cityId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Latitude of search point",
+                        "name": "lat",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Longitude of search point",
+                        "name": "lon",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Max distance from search point in km, requires lat and lon",
+                        "name": "radiusKm",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Offers",
+                        "schema": {
+                            "$ref": "#/definitions/docs.OffersGeoJSON"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid search params",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Only available for reviewer"
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            }
+        },
+        "/offer/search": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Find offers with given search params. Dates are RFC3339 or YYYY-MM-DD, checkOut date includes the whole day",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Offer"
+                ],
+                "summary": "Find offers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Id of required city",
+                        "name": "cityId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Id of hotel",
+                        "name": "hotelId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Id of room type",
+                        "name": "roomId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Stay starts not earlier than this date",
+                        "name": "checkIn",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Stay ends not later than this date",
+                        "name": "checkOut",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Full-text search in task",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only offers open for applications: published, not expired and not full",
+                        "name": "onlyOpen",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Latitude of search point, hotels without coordinates are skipped",
+                        "name": "lat",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Longitude of search point",
+                        "name": "lon",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Max distance from search point in km, requires lat and lon",
+                        "name": "radiusKm",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "expiration, check_in, remaining_slots or distance (requires lat and lon)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "asc (default) or desc",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of page, from 0",
+                        "name": "pageNum",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor from previous page, empty for the first page. Can not be used with pageNum",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Size of page, 20 by default, at most 100",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page of offers found",
+                        "schema": {
+                            "$ref": "#/definitions/docs.GetOffersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data for finding offers",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Only available for reviewer"
+                    },
+                    "404": {
+                        "description": "Page with given number not found"
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            }
+        },
+        "/offer/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "GetForPage offer by id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Offer"
+                ],
+                "summary": "GetForPage by id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Id of requested offer",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Requested offer",
+                        "schema": {
+                            "$ref": "#/definitions/docs.OfferResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data for getting offer by id",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Only available for admin"
+                    },
+                    "404": {
+                        "description": "Offer with given id not found"
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update offer with given id and data. Only fields that are set are changed.\nVersion of offer (ETag of GET /offer/{id}) must be passed in If-Match header or in body",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Offer"
+                ],
+                "summary": "Update offer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Id of offer to update",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of offer",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Data for updating offer",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.UpdateOfferRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated offer",
+                        "schema": {
+                            "$ref": "#/definitions/docs.OfferResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data for updating offer",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Only available for admin"
+                    },
+                    "404": {
+                        "description": "Offer with given id not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Draw has already started or offer for this hotel, room and dates already exists",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "412": {
+                        "description": "Offer has been changed by someone else",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "428": {
+                        "description": "Version of offer is required",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            }
+        },
+        "/offer/{id}/cancel": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cancels offer, declines pending applications and notifies their authors",
+                "tags": [
+                    "Offer"
+                ],
+                "summary": "Cancel offer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Id of offer to cancel",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Offer cancelled"
+                    },
+                    "400": {
+                        "description": "Invalid offer id",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Only available for admin"
+                    },
+                    "404": {
+                        "description": "Offer with given id not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Offer can not be cancelled from its current status, e.g. after draw",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            }
+        },
+        "/offer/{id}/draws": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns audit records of offer draws: candidates, ratings, weights, seed and winners",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Draw"
+                ],
+                "summary": "Get draws of offer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Offer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Draws of offer, oldest first",
+                        "schema": {
+                            "$ref": "#/definitions/docs.GetDrawsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid offer id",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Only available with draw:audit permission"
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            }
+        },
+        "/offer/{id}/publish": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Publishes draft offer so reviewers can apply to it",
+                "tags": [
+                    "Offer"
+                ],
+                "summary": "Publish offer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Id of offer to publish",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Offer published"
+                    },
+                    "400": {
+                        "description": "Invalid offer id",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Only available for admin"
+                    },
+                    "404": {
+                        "description": "Offer with given id not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Offer can not be published from its current status",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            }
+        },
+        "/promocode/": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns page of promocodes filtered by user and status",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Promocode"
+                ],
+                "summary": "Get promocodes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Owner of promocode",
+                        "name": "userId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "issued, redeemed, expired or revoked",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of page, from 0",
+                        "name": "pageNum",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Size of page, 20 by default, at most 100",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page of promocodes",
+                        "schema": {
+                            "$ref": "#/definitions/docs.GetPromocodesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Only available with promocode:manage permission"
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            }
+        },
+        "/promocode/redeem": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Marks issued promocode as redeemed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Promocode"
+                ],
+                "summary": "Redeem promocode",
+                "parameters": [
+                    {
+                        "description": "Code to redeem",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.RedeemPromocodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Redeemed promocode",
+                        "schema": {
+                            "$ref": "#/definitions/docs.PromocodeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data for redeeming",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Only available with promocode:manage permission"
+                    },
+                    "404": {
+                        "description": "Promocode not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Promocode is already redeemed, expired or revoked",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            }
+        },
+        "/promocode/{id}/reissue": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Issues new code with the same value instead of given one. Old code is revoked",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Promocode"
+                ],
+                "summary": "Reissue promocode",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Promocode ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "New promocode",
+                        "schema": {
+                            "$ref": "#/definitions/docs.PromocodeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid promocode id",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Only available with promocode:manage permission"
+                    },
+                    "404": {
+                        "description": "Promocode not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Promocode is already redeemed or replaced",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            }
+        },
+        "/promocode/{id}/revoke": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revokes issued promocode so it can not be redeemed",
+                "tags": [
+                    "Promocode"
+                ],
+                "summary": "Revoke promocode",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Promocode ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Promocode revoked"
+                    },
+                    "400": {
+                        "description": "Invalid promocode id",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Only available with promocode:manage permission"
+                    },
+                    "404": {
+                        "description": "Promocode not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Promocode is already redeemed, expired or revoked",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            }
+        },
+        "/report/": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "GetForPage all reports with pagination",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Report"
+                ],
+                "summary": "GetForPage reports",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Number of page, from 0",
+                        "name": "pageNum",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Size of page, 20 by default, at most 100",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page of reports",
+                        "schema": {
+                            "$ref": "#/definitions/docs.GetReportsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data for getting reports",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Only available for admin"
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            }
+        },
+        "/report/my": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "GetForPage all reports of current user with pagination",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Report"
+                ],
+                "summary": "GetForPage my reports",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Number of page, from 0",
+                        "name": "pageNum",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor from previous page, empty for the first page. Can not be used with pageNum",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Size of page, 20 by default, at most 100",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page of reports, newest first",
+                        "schema": {
+                            "$ref": "#/definitions/docs.GetReportsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data for getting reports",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "User is not reviewer"
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            }
+        },
+        "/report/my/application/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get my report by application id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Report"
+                ],
+                "summary": "Get by application id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Id of corresponding application",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Requested report",
+                        "schema": {
+                            "$ref": "#/definitions/docs.GetByApplicationIdResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data for getting report by id",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "User is not reviewer or this report does not belong to user",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Report with given id not found"
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            }
+        },
+        "/report/my/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "GetForPage report of current user by id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Report"
+                ],
+                "summary": "GetForPage my by id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Id of requested report",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Requested report",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ReportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data for getting report by id",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "User is not reviewer or this report does not belong to user",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Report with given id not found"
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            }
+        },
+        "/report/search": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "GetReportsByFilter reports by filter with pagination",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Report"
+                ],
+                "summary": "GetReportsByFilter reports by filter",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "cityId if report",
+                        "name": "cityId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "hotelId of report",
+                        "name": "hotelId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Status of report",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of page, from 0",
+                        "name": "pageNum",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Size of page, 20 by default, at most 100",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page of reports",
+                        "schema": {
+                            "$ref": "#/definitions/docs.GetReportsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data for getting reports",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Only available for admin"
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            }
+        },
+        "/report/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "GetForPage report by id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Report"
+                ],
+                "summary": "GetForPage by id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Id of requested report",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Requested report",
+                        "schema": {
+                            "$ref": "#/definitions/docs.ReportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data for getting report by id",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Only available for admin"
+                    },
+                    "404": {
+                        "description": "Report with given id not found"
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Updates report with given text, photos and checklist answers. Photos for checklist item are passed\nin field images_\u003citem_id\u003e. Report is not saved until all required checklist items are answered",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Report"
+                ],
+                "summary": "Update report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Id of report to update",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Report text",
+                        "name": "text",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "JSON array of docs.ReportAnswerRequest",
+                        "name": "answers",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully update report"
+                    },
+                    "400": {
+                        "description": "Invalid data for updating report",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Only available for reviewer"
+                    },
+                    "422": {
+                        "description": "Checklist answers are invalid or required items are not answered",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            }
+        },
+        "/report/{id}/confirm": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Confirms or declains report",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Report"
+                ],
+                "summary": "Confirm report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Id of report to update",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Data for updating report",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.ConfirmReport"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully update status of report"
+                    },
+                    "400": {
+                        "description": "Invalid data for changing report status",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Only available for admin"
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            }
+        },
+        "/role/": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns all roles with their permissions",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Role"
+                ],
+                "summary": "Get roles",
+                "responses": {
+                    "200": {
+                        "description": "Roles",
+                        "schema": {
+                            "$ref": "#/definitions/docs.GetRolesResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Only available with user:manage_roles permission"
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            }
+        },
+        "/role/user/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns roles of user with given id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Role"
+                ],
+                "summary": "Get user roles",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User roles",
+                        "schema": {
+                            "$ref": "#/definitions/docs.UserRolesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user id",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Only available with user:manage_roles permission"
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Assigns role to user with given id",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Role"
+                ],
+                "summary": "Assign role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Role to assign",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.AssignRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Role assigned"
+                    },
+                    "400": {
+                        "description": "Invalid data for assigning role",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Only available with user:manage_roles permission"
+                    },
+                    "404": {
+                        "description": "User or role not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            }
+        },
+        "/role/user/{id}/{role}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revokes role from user with given id",
+                "tags": [
+                    "Role"
+                ],
+                "summary": "Revoke role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Role name",
+                        "name": "role",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Role revoked"
+                    },
+                    "400": {
+                        "description": "Invalid data for revoking role",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Only available with user:manage_roles permission"
+                    },
+                    "404": {
+                        "description": "User has no such role",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            }
+        },
+        "/room/": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "GetRooms all rooms. With hotelId returns rooms of the hotel and shared room types",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Room"
+                ],
+                "summary": "Get rooms",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Hotel id",
+                        "name": "hotelId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor from previous page, empty for the first page. Without cursor the whole list is returned",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Size of page, 20 by default, at most 100",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page of rooms",
+                        "schema": {
+                            "$ref": "#/definitions/docs.GetRoomsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data for getting rooms",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Only available for admin"
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates offer with given info",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Room"
+                ],
+                "summary": "Create offer",
+                "parameters": [
+                    {
+                        "description": "Data for creating room",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.CreateRoomRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created room data",
+                        "schema": {
+                            "$ref": "#/definitions/docs.CreateRoomResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data for creating room",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Only available for admin"
+                    },
+                    "409": {
+                        "description": "Room with this name already exists",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            }
+        },
+        "/room/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Room"
+                ],
+                "summary": "Get room by id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Room id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Room",
+                        "schema": {
+                            "$ref": "#/definitions/docs.RoomResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid room id",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Room not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Marks room as deleted. Room used in offers in progress or active templates can not be deleted",
+                "tags": [
+                    "Room"
+                ],
+                "summary": "Delete room",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Room id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Room deleted"
+                    },
+                    "400": {
+                        "description": "Invalid room id",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Only available with catalog:write permission"
+                    },
+                    "404": {
+                        "description": "Room not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Room has active offers",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Room"
+                ],
+                "summary": "Rename room",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Room id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New room name",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.UpdateRoomRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated room",
+                        "schema": {
+                            "$ref": "#/definitions/docs.RoomResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data for updating room",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Only available with catalog:write permission"
+                    },
+                    "404": {
+                        "description": "Room not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Room with this name already exists",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            }
+        },
+        "/user/": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "GetForPage data of current user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "GetForPage me",
+                "responses": {
+                    "200": {
+                        "description": "User data",
+                        "schema": {
+                            "$ref": "#/definitions/docs.UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request to get user data",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "User not found"
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            }
+        },
+        "/user/log-in": {
+            "post": {
+                "description": "Log in with given name and password",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Log in",
+                "parameters": [
+                    {
+                        "description": "Data for log in",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.LogInRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Auth data",
+                        "schema": {
+                            "$ref": "#/definitions/docs.AuthResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data for log in",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid login or password",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many attempts, see Retry-After header",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            }
+        },
+        "/user/log-out": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Ends current session, its access and refresh tokens stop working immediately",
+                "tags": [
+                    "User"
+                ],
+                "summary": "Log out",
+                "responses": {
+                    "204": {
+                        "description": "Logged out"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            }
+        },
+        "/user/password/change": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Changes password of current user, all other sessions are ended",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Change password",
+                "parameters": [
+                    {
+                        "description": "Old and new passwords",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.ChangePasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Password changed"
+                    },
+                    "400": {
+                        "description": "Invalid new password",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized or wrong old password",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many wrong old passwords",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            }
+        },
+        "/user/password/reset": {
+            "post": {
+                "description": "Sets new password using reset token, all sessions of user are ended",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Reset password",
+                "parameters": [
+                    {
+                        "description": "Reset token and new password",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.ResetPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Password changed"
+                    },
+                    "400": {
+                        "description": "Invalid password or reset token",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            }
+        },
+        "/user/password/reset-request": {
+            "post": {
+                "description": "Sends single-use reset link to user's email. Responds the same way whether user exists or not, requests are limited per login and IP",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Request password reset",
+                "parameters": [
+                    {
+                        "description": "Login of user",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.PasswordResetRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Reset link sent if user exists and limit is not reached"
+                    },
+                    "400": {
+                        "description": "Invalid body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/refresh": {
+            "post": {
+                "description": "Refresh auth credentials via refresh token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Refresh credentials",
+                "parameters": [
+                    {
+                        "description": "Data for refresh",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.RefreshRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Auth data",
+                        "schema": {
+                            "$ref": "#/definitions/docs.AuthResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data for refresh",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Refresh token is invalid, expired or already used"
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            }
+        },
+        "/user/sessions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get active sessions of current user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get sessions",
+                "responses": {
+                    "200": {
+                        "description": "Active sessions",
+                        "schema": {
+                            "$ref": "#/definitions/docs.GetSessionsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            }
+        },
+        "/user/sessions/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Ends one of current user's sessions",
+                "tags": [
+                    "User"
+                ],
+                "summary": "Revoke session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Session revoked"
+                    },
+                    "400": {
+                        "description": "Invalid session id",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Session not found"
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            }
+        },
+        "/user/sign-up": {
+            "post": {
+                "description": "Sign up with given name and password. Account stays pending until code sent to Ostrovok email is confirmed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Sign up",
+                "parameters": [
+                    {
+                        "description": "Data for sign up",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.SignUpRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Auth data",
+                        "schema": {
+                            "$ref": "#/definitions/docs.AuthResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid data for sign up",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found"
+                    },
+                    "429": {
+                        "description": "Verification code was sent recently",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    },
+                    "503": {
+                        "description": "Ostrovok is unavailable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/sign-up/confirm": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Confirms ownership of Ostrovok account with code sent to its email and activates account",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Confirm sign up",
+                "parameters": [
+                    {
+                        "description": "Verification code",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/docs.ConfirmSignUpRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Account activated"
+                    },
+                    "400": {
+                        "description": "Invalid or expired code",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "409": {
+                        "description": "Account is already verified",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            }
+        },
+        "/user/sign-up/resend": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Sends new verification code to Ostrovok email, previous code stops working",
+                "tags": [
+                    "User"
+                ],
+                "summary": "Resend sign up code",
+                "responses": {
+                    "202": {
+                        "description": "Code sent"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "409": {
+                        "description": "Account is already verified",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "Code was sent recently or daily limit is reached",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            }
+        },
+        "/user/{id}/sessions": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Ends all sessions of user with given id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Revoke user sessions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Number of revoked sessions",
+                        "schema": {
+                            "$ref": "#/definitions/docs.RevokeSessionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user id",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Only available with user:manage_sessions permission"
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "docs.AchievementResponse": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "raiting_limit": {
+                    "type": "integer"
+                }
+            }
+        },
+        "docs.AnalyticsResponse": {
+            "type": "object",
+            "properties": {
+                "accepted_reports": {
+                    "type": "integer"
+                },
+                "applications_received": {
+                    "type": "integer"
+                },
+                "completed_offers": {
+                    "type": "integer"
+                }
+            }
+        },
+        "docs.ApplicationResponse": {
+            "type": "object",
+            "properties": {
+                "expiration_at": {
+                    "type": "string"
+                },
+                "hotel_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "offer_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "waitlist_position": {
+                    "description": "Только для заявок в листе ожидания, начиная с 1",
+                    "type": "integer"
+                }
+            }
+        },
+        "docs.AssignRoleRequest": {
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "docs.AuthResponse": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string"
+                },
+                "access_ttl": {
+                    "type": "integer"
+                },
+                "refresh_token": {
+                    "type": "string"
+                },
+                "refresh_ttl": {
+                    "type": "integer"
+                }
+            }
+        },
+        "docs.CatalogImportErrorResponse": {
+            "type": "object",
+            "properties": {
+                "external_id": {
+                    "type": "string"
+                },
+                "line": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "docs.CatalogImportResponse": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.CatalogImportErrorResponse"
+                    }
+                },
+                "skipped": {
+                    "type": "integer"
+                },
+                "updated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "docs.ChangePasswordRequest": {
+            "type": "object",
+            "required": [
+                "new_password",
+                "old_password"
+            ],
+            "properties": {
+                "new_password": {
+                    "type": "string"
+                },
+                "old_password": {
+                    "type": "string"
+                }
+            }
+        },
+        "docs.ChecklistItemRequest": {
+            "type": "object",
+            "required": [
+                "title",
+                "type"
+            ],
+            "properties": {
+                "required": {
+                    "type": "boolean"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "description": "Type - yes_no, score (от 1 до 5), text или photo",
+                    "type": "string"
+                }
+            }
+        },
+        "docs.ChecklistItemResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "required": {
+                    "type": "boolean"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "docs.ConfirmReport": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "docs.ConfirmSignUpRequest": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
+                }
+            }
+        },
+        "docs.CreateApplicationRequest": {
+            "type": "object",
+            "required": [
+                "offer_id"
+            ],
+            "properties": {
+                "offer_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "docs.CreateApplicationResponse": {
+            "type": "object",
+            "properties": {
+                "application_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "__app_created или __app_waitlisted, если мест уже нет",
+                    "type": "string"
+                }
+            }
+        },
+        "docs.CreateHotelRequest": {
+            "type": "object",
+            "required": [
+                "location_id",
+                "name"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "latitude": {
+                    "description": "Latitude и Longitude передаются вместе",
+                    "type": "number"
+                },
+                "location_id": {
+                    "type": "string"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "stars": {
+                    "type": "integer"
+                },
+                "website": {
+                    "type": "string"
+                }
             }
         },
-        "docs.AnalyticsResponse": {
+        "docs.CreateHotelResponse": {
             "type": "object",
             "properties": {
-                "accepted_reports": {
+                "hotel_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "docs.CreateLocationRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "docs.CreateLocationResponse": {
+            "type": "object",
+            "properties": {
+                "location_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "docs.CreateOfferRequest": {
+            "type": "object",
+            "required": [
+                "check_in",
+                "check_out",
+                "expiration_at",
+                "hotel_id",
+                "location_id",
+                "participants_limit",
+                "room_id",
+                "task"
+            ],
+            "properties": {
+                "check_in": {
+                    "type": "string"
+                },
+                "check_out": {
+                    "type": "string"
+                },
+                "checklist": {
+                    "description": "Checklist - пункты задания, на которые победитель отвечает в отчете",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.ChecklistItemRequest"
+                    }
+                },
+                "draft": {
+                    "description": "Draft - создать черновик, который пользователи не увидят до публикации",
+                    "type": "boolean"
+                },
+                "expiration_at": {
+                    "type": "string"
+                },
+                "hotel_id": {
+                    "type": "string"
+                },
+                "location_id": {
+                    "type": "string"
+                },
+                "participants_limit": {
                     "type": "integer"
                 },
-                "applications_received": {
+                "room_id": {
+                    "type": "string"
+                },
+                "task": {
+                    "type": "string"
+                },
+                "winners_count": {
+                    "description": "WinnersCount - сколько номеров разыгрывается, по умолчанию один",
+                    "type": "integer"
+                }
+            }
+        },
+        "docs.CreateOfferResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                }
+            }
+        },
+        "docs.CreateOfferTemplateRequest": {
+            "type": "object",
+            "required": [
+                "application_days",
+                "check_in_after_days",
+                "hotel_id",
+                "nights",
+                "participants_limit",
+                "period",
+                "room_id",
+                "starts_at",
+                "task"
+            ],
+            "properties": {
+                "application_days": {
                     "type": "integer"
                 },
-                "completed_offers": {
+                "check_in_after_days": {
+                    "type": "integer"
+                },
+                "hotel_id": {
+                    "type": "string"
+                },
+                "nights": {
+                    "type": "integer"
+                },
+                "participants_limit": {
+                    "type": "integer"
+                },
+                "period": {
+                    "description": "Period - weekly или monthly",
+                    "type": "string"
+                },
+                "room_id": {
+                    "type": "string"
+                },
+                "starts_at": {
+                    "description": "StartsAt - дата первой публикации",
+                    "type": "string"
+                },
+                "task": {
+                    "type": "string"
+                },
+                "winners_count": {
                     "type": "integer"
                 }
             }
         },
-        "docs.ApplicationResponse": {
+        "docs.CreateOfferTemplateResponse": {
             "type": "object",
             "properties": {
-                "expiration_at": {
+                "id": {
+                    "type": "string"
+                }
+            }
+        },
+        "docs.CreateRoomRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "hotel_id": {
+                    "description": "HotelID - номер конкретного отеля. Без него создается общий тип номера",
                     "type": "string"
                 },
-                "hotel_name": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "docs.CreateRoomResponse": {
+            "type": "object",
+            "properties": {
+                "room_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "docs.DrawCandidateResponse": {
+            "type": "object",
+            "properties": {
+                "application_id": {
+                    "type": "string"
+                },
+                "rating": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "weight": {
+                    "type": "number"
+                },
+                "winner_order": {
+                    "description": "Номер победителя начиная с 1, нет у проигравших",
+                    "type": "integer"
+                }
+            }
+        },
+        "docs.DrawResponse": {
+            "type": "object",
+            "properties": {
+                "algorithm_version": {
+                    "type": "integer"
+                },
+                "candidates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.DrawCandidateResponse"
+                    }
+                },
+                "created_at": {
                     "type": "string"
                 },
                 "id": {
@@ -1600,340 +4400,583 @@ const docTemplate = `{
                 "offer_id": {
                     "type": "string"
                 },
-                "status": {
+                "seed": {
+                    "description": "Строкой, чтобы int64 не терял точность в JS",
                     "type": "string"
                 },
-                "user_id": {
+                "winners": {
+                    "description": "Заявки победителей в порядке выбора",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "winners_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "docs.GeoJSONPoint": {
+            "type": "object",
+            "properties": {
+                "coordinates": {
+                    "description": "Coordinates - долгота и широта, именно в таком порядке",
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "type": {
+                    "type": "string",
+                    "example": "Point"
+                }
+            }
+        },
+        "docs.GetApplicationsResponse": {
+            "type": "object",
+            "properties": {
+                "applications": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.ApplicationResponse"
+                    }
+                },
+                "next_cursor": {
                     "type": "string"
+                },
+                "pages_count": {
+                    "type": "integer"
                 }
             }
         },
-        "docs.AuthResponse": {
+        "docs.GetByApplicationIdResponse": {
             "type": "object",
             "properties": {
-                "access_token": {
+                "id": {
                     "type": "string"
+                }
+            }
+        },
+        "docs.GetDrawsResponse": {
+            "type": "object",
+            "properties": {
+                "draws": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.DrawResponse"
+                    }
+                }
+            }
+        },
+        "docs.GetHotelsResponse": {
+            "type": "object",
+            "properties": {
+                "hotels": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.HotelResponse"
+                    }
                 },
-                "access_ttl": {
+                "next_cursor": {
+                    "type": "string"
+                },
+                "pages_count": {
                     "type": "integer"
+                }
+            }
+        },
+        "docs.GetLocationsResponse": {
+            "type": "object",
+            "properties": {
+                "locations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.LocationResponse"
+                    }
                 },
-                "refresh_token": {
+                "next_cursor": {
+                    "type": "string"
+                },
+                "pages_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "docs.GetOfferTemplatesResponse": {
+            "type": "object",
+            "properties": {
+                "templates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.OfferTemplateResponse"
+                    }
+                }
+            }
+        },
+        "docs.GetOffersResponse": {
+            "type": "object",
+            "properties": {
+                "next_cursor": {
                     "type": "string"
                 },
-                "refresh_ttl": {
+                "offers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.OfferResponse"
+                    }
+                },
+                "pages_count": {
                     "type": "integer"
                 }
             }
         },
-        "docs.ConfirmReport": {
+        "docs.GetPromocodesResponse": {
             "type": "object",
-            "required": [
-                "status"
-            ],
             "properties": {
-                "status": {
+                "next_cursor": {
                     "type": "string"
+                },
+                "pages_count": {
+                    "type": "integer"
+                },
+                "promocodes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.PromocodeResponse"
+                    }
                 }
             }
         },
-        "docs.CreateApplicationRequest": {
+        "docs.GetReportsResponse": {
             "type": "object",
-            "required": [
-                "offer_id"
-            ],
             "properties": {
-                "offer_id": {
+                "next_cursor": {
                     "type": "string"
+                },
+                "pages_count": {
+                    "type": "integer"
+                },
+                "reports": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.ReportResponse"
+                    }
                 }
             }
         },
-        "docs.CreateApplicationResponse": {
+        "docs.GetRolesResponse": {
             "type": "object",
             "properties": {
-                "application_id": {
-                    "type": "string"
+                "roles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.RoleResponse"
+                    }
                 }
             }
         },
-        "docs.CreateHotelRequest": {
+        "docs.GetRoomsResponse": {
             "type": "object",
-            "required": [
-                "location_id",
-                "name"
-            ],
             "properties": {
-                "location_id": {
+                "next_cursor": {
                     "type": "string"
                 },
-                "name": {
-                    "type": "string"
+                "pages_count": {
+                    "type": "integer"
+                },
+                "rooms": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.RoomResponse"
+                    }
                 }
             }
         },
-        "docs.CreateHotelResponse": {
+        "docs.GetSessionsResponse": {
             "type": "object",
             "properties": {
-                "hotel_id": {
-                    "type": "string"
+                "sessions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.SessionResponse"
+                    }
                 }
             }
         },
-        "docs.CreateLocationRequest": {
+        "docs.GetUserAppLimitInfoResponse": {
             "type": "object",
-            "required": [
-                "name"
-            ],
             "properties": {
-                "name": {
-                    "type": "string"
+                "active_app_count": {
+                    "type": "integer"
+                },
+                "limit": {
+                    "type": "integer"
                 }
             }
         },
-        "docs.CreateLocationResponse": {
+        "docs.HotelImageResponse": {
             "type": "object",
             "properties": {
-                "location_id": {
+                "id": {
+                    "type": "string"
+                },
+                "link": {
                     "type": "string"
                 }
             }
         },
-        "docs.CreateOfferRequest": {
+        "docs.HotelResponse": {
             "type": "object",
-            "required": [
-                "check_in",
-                "check_out",
-                "expiration_at",
-                "hotel_id",
-                "location_id",
-                "participants_limit",
-                "room_id",
-                "task"
-            ],
             "properties": {
-                "check_in": {
+                "address": {
                     "type": "string"
                 },
-                "check_out": {
+                "description": {
                     "type": "string"
                 },
-                "expiration_at": {
+                "email": {
                     "type": "string"
                 },
-                "hotel_id": {
+                "id": {
                     "type": "string"
                 },
+                "images": {
+                    "description": "Images есть только в ответе по одному отелю",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.HotelImageResponse"
+                    }
+                },
+                "latitude": {
+                    "type": "number"
+                },
                 "location_id": {
                     "type": "string"
                 },
-                "participants_limit": {
-                    "type": "integer"
+                "location_name": {
+                    "type": "string"
                 },
-                "room_id": {
+                "longitude": {
+                    "type": "number"
+                },
+                "name": {
                     "type": "string"
                 },
-                "task": {
+                "phone": {
+                    "type": "string"
+                },
+                "stars": {
+                    "type": "integer"
+                },
+                "website": {
                     "type": "string"
                 }
             }
         },
-        "docs.CreateOfferResponse": {
+        "docs.LocationResponse": {
             "type": "object",
             "properties": {
                 "id": {
                     "type": "string"
+                },
+                "name": {
+                    "type": "string"
                 }
             }
         },
-        "docs.CreateRoomRequest": {
+        "docs.LogInRequest": {
             "type": "object",
             "required": [
-                "name"
+                "ostrovok_login",
+                "password"
             ],
             "properties": {
-                "name": {
+                "ostrovok_login": {
                     "type": "string"
-                }
-            }
-        },
-        "docs.CreateRoomResponse": {
-            "type": "object",
-            "properties": {
-                "room_id": {
+                },
+                "password": {
                     "type": "string"
                 }
             }
         },
-        "docs.GetApplicationsResponse": {
+        "docs.OfferGeoJSONFeature": {
             "type": "object",
             "properties": {
-                "applications": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/docs.ApplicationResponse"
-                    }
+                "geometry": {
+                    "$ref": "#/definitions/docs.GeoJSONPoint"
                 },
-                "pages_count": {
-                    "type": "integer"
+                "properties": {
+                    "$ref": "#/definitions/docs.OfferMapProperties"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "Feature"
                 }
             }
         },
-        "docs.GetByApplicationIdResponse": {
+        "docs.OfferImportErrorResponse": {
             "type": "object",
             "properties": {
-                "id": {
+                "line": {
+                    "type": "integer"
+                },
+                "reason": {
                     "type": "string"
                 }
             }
         },
-        "docs.GetHotelsResponse": {
-            "type": "object",
-            "properties": {
-                "hotels": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/docs.HotelResponse"
-                    }
-                }
-            }
-        },
-        "docs.GetLocationsResponse": {
+        "docs.OfferImportResponse": {
             "type": "object",
             "properties": {
-                "locations": {
+                "created": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/docs.LocationResponse"
+                        "type": "string"
                     }
-                }
-            }
-        },
-        "docs.GetOffersResponse": {
-            "type": "object",
-            "properties": {
-                "offers": {
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "errors": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/docs.OfferResponse"
+                        "$ref": "#/definitions/docs.OfferImportErrorResponse"
                     }
                 },
-                "pages_count": {
+                "rows": {
+                    "description": "Rows - сколько строк с офферами в файле",
                     "type": "integer"
                 }
             }
         },
-        "docs.GetReportsResponse": {
+        "docs.OfferMapProperties": {
             "type": "object",
             "properties": {
-                "pages_count": {
+                "check_in_at": {
+                    "type": "string"
+                },
+                "check_out_at": {
+                    "type": "string"
+                },
+                "distance_km": {
+                    "type": "number"
+                },
+                "expiration_at": {
+                    "type": "string"
+                },
+                "hotel_address": {
+                    "type": "string"
+                },
+                "hotel_id": {
+                    "type": "string"
+                },
+                "hotel_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "remaining_slots": {
                     "type": "integer"
                 },
-                "reports": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/docs.ReportResponse"
-                    }
+                "room_name": {
+                    "type": "string"
                 }
             }
         },
-        "docs.GetRoomsResponse": {
+        "docs.OfferResponse": {
             "type": "object",
             "properties": {
-                "rooms": {
+                "awarded_count": {
+                    "type": "integer"
+                },
+                "check_in_at": {
+                    "type": "string"
+                },
+                "check_out_at": {
+                    "type": "string"
+                },
+                "checklist": {
+                    "description": "Checklist - только в GET /offer/{id}",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/docs.RoomResponse"
+                        "$ref": "#/definitions/docs.ChecklistItemResponse"
                     }
-                }
-            }
-        },
-        "docs.GetUserAppLimitInfoResponse": {
-            "type": "object",
-            "properties": {
-                "active_app_count": {
+                },
+                "distance_km": {
+                    "type": "number"
+                },
+                "expiration_at": {
+                    "type": "string"
+                },
+                "hotel_address": {
+                    "type": "string"
+                },
+                "hotel_id": {
+                    "type": "string"
+                },
+                "hotel_latitude": {
+                    "type": "number"
+                },
+                "hotel_longitude": {
+                    "type": "number"
+                },
+                "hotel_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "participants_count": {
+                    "type": "integer"
+                },
+                "participants_limit": {
+                    "type": "integer"
+                },
+                "room_id": {
+                    "type": "string"
+                },
+                "room_name": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "task": {
+                    "type": "string"
+                },
+                "version": {
                     "type": "integer"
                 },
-                "limit": {
+                "winners_count": {
                     "type": "integer"
                 }
             }
         },
-        "docs.HotelResponse": {
+        "docs.OfferTemplateResponse": {
             "type": "object",
             "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "application_days": {
+                    "type": "integer"
+                },
+                "check_in_after_days": {
+                    "type": "integer"
+                },
+                "hotel_id": {
+                    "type": "string"
+                },
+                "hotel_name": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "string"
                 },
-                "location_id": {
+                "next_publish_at": {
                     "type": "string"
                 },
-                "location_name": {
+                "nights": {
+                    "type": "integer"
+                },
+                "participants_limit": {
+                    "type": "integer"
+                },
+                "period": {
                     "type": "string"
                 },
-                "name": {
+                "room_id": {
+                    "type": "string"
+                },
+                "room_name": {
+                    "type": "string"
+                },
+                "starts_at": {
                     "type": "string"
+                },
+                "task": {
+                    "type": "string"
+                },
+                "winners_count": {
+                    "type": "integer"
                 }
             }
         },
-        "docs.LocationResponse": {
+        "docs.OffersGeoJSON": {
             "type": "object",
             "properties": {
-                "id": {
-                    "type": "string"
+                "features": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.OfferGeoJSONFeature"
+                    }
                 },
-                "name": {
-                    "type": "string"
+                "type": {
+                    "type": "string",
+                    "example": "FeatureCollection"
                 }
             }
         },
-        "docs.LogInRequest": {
+        "docs.PasswordResetRequest": {
             "type": "object",
             "required": [
-                "ostrovok_login",
-                "password"
+                "ostrovok_login"
             ],
             "properties": {
                 "ostrovok_login": {
                     "type": "string"
-                },
-                "password": {
-                    "type": "string"
                 }
             }
         },
-        "docs.OfferResponse": {
+        "docs.PromocodeResponse": {
             "type": "object",
             "properties": {
-                "check_in_at": {
+                "code": {
                     "type": "string"
                 },
-                "check_out_at": {
+                "currency": {
                     "type": "string"
                 },
-                "expiration_at": {
+                "expires_at": {
                     "type": "string"
                 },
-                "hotel_id": {
+                "id": {
                     "type": "string"
                 },
-                "hotel_name": {
+                "issued_at": {
                     "type": "string"
                 },
-                "id": {
+                "redeemed_at": {
                     "type": "string"
                 },
-                "participants_count": {
-                    "type": "integer"
+                "replaced_by": {
+                    "type": "string"
                 },
-                "participants_limit": {
-                    "type": "integer"
+                "report_id": {
+                    "type": "string"
                 },
-                "room_id": {
+                "revoked_at": {
                     "type": "string"
                 },
-                "room_name": {
+                "status": {
                     "type": "string"
                 },
-                "task": {
+                "user_id": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "integer"
+                }
+            }
+        },
+        "docs.RedeemPromocodeRequest": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
                     "type": "string"
                 }
             }
@@ -1949,12 +4992,66 @@ const docTemplate = `{
                 }
             }
         },
+        "docs.ReplayDrawResponse": {
+            "type": "object",
+            "properties": {
+                "draw": {
+                    "$ref": "#/definitions/docs.DrawResponse"
+                },
+                "weights_match": {
+                    "type": "boolean"
+                },
+                "winners": {
+                    "description": "Победители повтора в порядке выбора",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "winners_match": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "docs.ReportChecklistItemResponse": {
+            "type": "object",
+            "properties": {
+                "answered": {
+                    "type": "boolean"
+                },
+                "checked": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "required": {
+                    "type": "boolean"
+                },
+                "score": {
+                    "type": "integer"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
         "docs.ReportImageResponse": {
             "type": "object",
             "properties": {
                 "id": {
                     "type": "string"
                 },
+                "item_id": {
+                    "description": "ItemId - пункт чек-листа, к которому приложено фото",
+                    "type": "string"
+                },
                 "link": {
                     "type": "string"
                 }
@@ -1969,6 +5066,13 @@ const docTemplate = `{
                 "check_out_at": {
                     "type": "string"
                 },
+                "checklist": {
+                    "description": "Checklist - только при получении отчета по id",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.ReportChecklistItemResponse"
+                    }
+                },
                 "expiration_at": {
                     "type": "string"
                 },
@@ -2007,9 +5111,55 @@ const docTemplate = `{
                 }
             }
         },
+        "docs.ResetPasswordRequest": {
+            "type": "object",
+            "required": [
+                "new_password",
+                "token"
+            ],
+            "properties": {
+                "new_password": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "docs.RevokeSessionsResponse": {
+            "type": "object",
+            "properties": {
+                "revoked": {
+                    "type": "integer"
+                }
+            }
+        },
+        "docs.RoleResponse": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "permissions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
         "docs.RoomResponse": {
             "type": "object",
             "properties": {
+                "hotel_id": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "string"
                 },
@@ -2018,6 +5168,29 @@ const docTemplate = `{
                 }
             }
         },
+        "docs.SessionResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "current": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "last_seen_at": {
+                    "type": "string"
+                },
+                "user_agent": {
+                    "type": "string"
+                }
+            }
+        },
         "docs.SignUpRequest": {
             "type": "object",
             "required": [
@@ -2037,6 +5210,53 @@ const docTemplate = `{
                 }
             }
         },
+        "docs.UpdateHotelRequest": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "latitude": {
+                    "description": "Latitude и Longitude передаются вместе",
+                    "type": "number"
+                },
+                "location_id": {
+                    "type": "string"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "stars": {
+                    "type": "integer"
+                },
+                "website": {
+                    "type": "string"
+                }
+            }
+        },
+        "docs.UpdateLocationRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
         "docs.UpdateOfferRequest": {
             "type": "object",
             "properties": {
@@ -2046,17 +5266,45 @@ const docTemplate = `{
                 "check_out_at": {
                     "type": "string"
                 },
+                "checklist": {
+                    "description": "Checklist заменяет чек-лист целиком, пустой список удаляет его",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.ChecklistItemRequest"
+                    }
+                },
                 "expiration_at": {
                     "type": "string"
                 },
                 "hotel_id": {
                     "type": "string"
                 },
+                "participants_limit": {
+                    "type": "integer"
+                },
                 "room_id": {
                     "type": "string"
                 },
                 "task": {
                     "type": "string"
+                },
+                "version": {
+                    "description": "Version - версия из GET /offer/{id}, можно передать заголовком If-Match",
+                    "type": "integer"
+                },
+                "winners_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "docs.UpdateRoomRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string"
                 }
             }
         },
@@ -2081,8 +5329,40 @@ const docTemplate = `{
                 "ostrovok_login": {
                     "type": "string"
                 },
+                "permissions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "promocodes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/docs.PromocodeResponse"
+                    }
+                },
                 "rating": {
                     "type": "integer"
+                },
+                "roles": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "docs.UserRolesResponse": {
+            "type": "object",
+            "properties": {
+                "roles": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         }
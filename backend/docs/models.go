@@ -2,12 +2,34 @@ package docs
 
 import (
 	"mime/multipart"
+	"strconv"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/application"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/catalog"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/draw"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/offer"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/offertemplate"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/promocode"
 	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/user"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/pkg"
 )
 
+// PageResponse - общая часть ответов со списками. pages_count приходит при запросе по pageNum,
+// next_cursor - при запросе по cursor, пока есть следующая страница
+type PageResponse struct {
+	PagesCount int    `json:"pages_count,omitempty"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+func NewPageResponse[T any](page pkg.Page[T]) PageResponse {
+	return PageResponse{
+		PagesCount: page.PagesCount,
+		NextCursor: page.NextCursor,
+	}
+}
+
 type ApplicationResponse struct {
 	Id           string    `json:"id"`
 	UserId       string    `json:"user_id"`
@@ -15,6 +37,8 @@ type ApplicationResponse struct {
 	Status       string    `json:"status"`
 	ExpirationAt time.Time `json:"expiration_at"`
 	HotelName    string    `json:"hotel_name"`
+	// Только для заявок в листе ожидания, начиная с 1
+	WaitlistPosition *int `json:"waitlist_position,omitempty"`
 }
 
 type GetUserAppLimitInfoResponse struct {
@@ -24,12 +48,13 @@ type GetUserAppLimitInfoResponse struct {
 
 func ApplicationModelToResponse(model *application.Application) *ApplicationResponse {
 	return &ApplicationResponse{
-		Id:           model.Id.String(),
-		UserId:       model.UserId.String(),
-		OfferId:      model.OfferId.String(),
-		Status:       string(model.Status),
-		ExpirationAt: model.ExpirationAt,
-		HotelName:    model.HotelName,
+		Id:               model.Id.String(),
+		UserId:           model.UserId.String(),
+		OfferId:          model.OfferId.String(),
+		Status:           string(model.Status),
+		ExpirationAt:     model.ExpirationAt,
+		HotelName:        model.HotelName,
+		WaitlistPosition: model.WaitlistPosition,
 	}
 }
 
@@ -39,11 +64,13 @@ type CreateApplicationRequest struct {
 
 type CreateApplicationResponse struct {
 	ApplicationId string `json:"application_id"`
+	// __app_created или __app_waitlisted, если мест уже нет
+	Status string `json:"status"`
 }
 
 type GetApplicationsResponse struct {
 	Applications []*ApplicationResponse `json:"applications"`
-	PagesCount   int                    `json:"pages_count"`
+	PageResponse
 }
 
 type OfferResponse struct {
@@ -53,11 +80,60 @@ type OfferResponse struct {
 	RoomName          string    `json:"room_name"`
 	HotelID           string    `json:"hotel_id"`
 	HotelName         string    `json:"hotel_name"`
+	HotelAddress      string    `json:"hotel_address,omitempty"`
+	HotelLatitude     *float64  `json:"hotel_latitude,omitempty"`
+	HotelLongitude    *float64  `json:"hotel_longitude,omitempty"`
+	DistanceKm        *float64  `json:"distance_km,omitempty"`
 	CheckIn           time.Time `json:"check_in_at"`
 	CheckOut          time.Time `json:"check_out_at"`
 	ExpirationAt      time.Time `json:"expiration_at"`
 	ParticipantsLimit uint      `json:"participants_limit"`
 	ParticipantsCount uint      `json:"participants_count"`
+	WinnersCount      uint      `json:"winners_count"`
+	AwardedCount      uint      `json:"awarded_count"`
+	Status            string    `json:"status"`
+	Version           int       `json:"version"`
+	// Checklist - только в GET /offer/{id}
+	Checklist []*ChecklistItemResponse `json:"checklist,omitempty"`
+}
+
+type ChecklistItemRequest struct {
+	// Type - yes_no, score (от 1 до 5), text или photo
+	Type     string `json:"type" binding:"required"`
+	Title    string `json:"title" binding:"required"`
+	Required bool   `json:"required"`
+}
+
+type ChecklistItemResponse struct {
+	Id       string `json:"id"`
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Required bool   `json:"required"`
+}
+
+func ChecklistToResponse(items []offer.ChecklistItem) []*ChecklistItemResponse {
+	resp := make([]*ChecklistItemResponse, 0, len(items))
+	for _, item := range items {
+		resp = append(resp, &ChecklistItemResponse{
+			Id:       item.ID.String(),
+			Type:     string(item.Type),
+			Title:    item.Title,
+			Required: item.Required,
+		})
+	}
+	return resp
+}
+
+func ChecklistFromRequest(items []ChecklistItemRequest) []offer.ChecklistItem {
+	res := make([]offer.ChecklistItem, 0, len(items))
+	for _, item := range items {
+		res = append(res, offer.ChecklistItem{
+			Type:     offer.ChecklistItemType(item.Type),
+			Title:    item.Title,
+			Required: item.Required,
+		})
+	}
+	return res
 }
 
 type CreateOfferRequest struct {
@@ -69,6 +145,12 @@ type CreateOfferRequest struct {
 	RoomID            string    `json:"room_id" binding:"required"`
 	CheckIn           time.Time `json:"check_in" binding:"required"`
 	CheckOut          time.Time `json:"check_out" binding:"required"`
+	// WinnersCount - сколько номеров разыгрывается, по умолчанию один
+	WinnersCount uint `json:"winners_count"`
+	// Draft - создать черновик, который пользователи не увидят до публикации
+	Draft bool `json:"draft"`
+	// Checklist - пункты задания, на которые победитель отвечает в отчете
+	Checklist []ChecklistItemRequest `json:"checklist"`
 }
 
 type CreateOfferResponse struct {
@@ -76,17 +158,92 @@ type CreateOfferResponse struct {
 }
 
 type GetOffersResponse struct {
-	Offers     []*OfferResponse `json:"offers"`
-	PagesCount int              `json:"pages_count"`
+	Offers []*OfferResponse `json:"offers"`
+	PageResponse
 }
 
 type UpdateOfferRequest struct {
-	Task         string    `json:"task"`
-	RoomID       string    `json:"room_id"`
-	HotelID      string    `json:"hotel_id"`
-	CheckIn      time.Time `json:"check_in_at"`
-	CheckOut     time.Time `json:"check_out_at"`
-	ExpirationAT time.Time `json:"expiration_at"`
+	Task              string    `json:"task"`
+	RoomID            string    `json:"room_id"`
+	HotelID           string    `json:"hotel_id"`
+	CheckIn           time.Time `json:"check_in_at"`
+	CheckOut          time.Time `json:"check_out_at"`
+	ExpirationAT      time.Time `json:"expiration_at"`
+	ParticipantsLimit *uint     `json:"participants_limit"`
+	WinnersCount      *uint     `json:"winners_count"`
+	// Checklist заменяет чек-лист целиком, пустой список удаляет его
+	Checklist []ChecklistItemRequest `json:"checklist"`
+	// Version - версия из GET /offer/{id}, можно передать заголовком If-Match
+	Version int `json:"version"`
+}
+
+type CreateOfferTemplateRequest struct {
+	HotelId           string `json:"hotel_id" binding:"required"`
+	RoomId            string `json:"room_id" binding:"required"`
+	Task              string `json:"task" binding:"required"`
+	ParticipantsLimit uint   `json:"participants_limit" binding:"required"`
+	WinnersCount      uint   `json:"winners_count"`
+	// Period - weekly или monthly
+	Period string `json:"period" binding:"required"`
+	// StartsAt - дата первой публикации
+	StartsAt         time.Time `json:"starts_at" binding:"required"`
+	CheckInAfterDays int       `json:"check_in_after_days" binding:"required"`
+	Nights           int       `json:"nights" binding:"required"`
+	ApplicationDays  int       `json:"application_days" binding:"required"`
+}
+
+type CreateOfferTemplateResponse struct {
+	Id string `json:"id"`
+}
+
+type OfferTemplateResponse struct {
+	Id                string    `json:"id"`
+	HotelId           string    `json:"hotel_id"`
+	HotelName         string    `json:"hotel_name"`
+	RoomId            string    `json:"room_id"`
+	RoomName          string    `json:"room_name"`
+	Task              string    `json:"task"`
+	ParticipantsLimit uint      `json:"participants_limit"`
+	WinnersCount      uint      `json:"winners_count"`
+	Period            string    `json:"period"`
+	StartsAt          time.Time `json:"starts_at"`
+	CheckInAfterDays  int       `json:"check_in_after_days"`
+	Nights            int       `json:"nights"`
+	ApplicationDays   int       `json:"application_days"`
+	NextPublishAt     time.Time `json:"next_publish_at"`
+	Active            bool      `json:"active"`
+}
+
+func OfferTemplateModelToResponse(t *offertemplate.Template) *OfferTemplateResponse {
+	return &OfferTemplateResponse{
+		Id:                t.ID.String(),
+		HotelId:           t.HotelID.String(),
+		HotelName:         t.HotelName,
+		RoomId:            t.RoomID.String(),
+		RoomName:          t.RoomName,
+		Task:              t.Task,
+		ParticipantsLimit: t.ParticipantsLimit,
+		WinnersCount:      t.WinnersCount,
+		Period:            string(t.Period),
+		StartsAt:          t.StartsAt,
+		CheckInAfterDays:  t.CheckInAfterDays,
+		Nights:            t.Nights,
+		ApplicationDays:   t.ApplicationDays,
+		NextPublishAt:     t.NextPublishAt,
+		Active:            t.Active,
+	}
+}
+
+type GetOfferTemplatesResponse struct {
+	Templates []*OfferTemplateResponse `json:"templates"`
+}
+
+func OfferTemplatesToResponse(templates []offertemplate.Template) *GetOfferTemplatesResponse {
+	resp := &GetOfferTemplatesResponse{Templates: make([]*OfferTemplateResponse, 0, len(templates))}
+	for i := range templates {
+		resp.Templates = append(resp.Templates, OfferTemplateModelToResponse(&templates[i]))
+	}
+	return resp
 }
 
 type AuthResponse struct {
@@ -106,8 +263,12 @@ type UserResponse struct {
 	OstrovokLogin string                `json:"ostrovok_login"`
 	Email         string                `json:"email"`
 	IsAdmin       bool                  `json:"is_admin"`
+	Status        string                `json:"status"`
+	Roles         []string              `json:"roles"`
+	Permissions   []string              `json:"permissions"`
 	Rating        int                   `json:"rating"`
 	Achievements  []AchievementResponse `json:"achievements"`
+	Promocodes    []*PromocodeResponse  `json:"promocodes"`
 }
 
 func UserModelToResponse(u *user.User) *UserResponse {
@@ -125,8 +286,12 @@ func UserModelToResponse(u *user.User) *UserResponse {
 		OstrovokLogin: u.OstrovokLogin,
 		Email:         u.Email,
 		IsAdmin:       u.IsAdmin,
+		Status:        string(u.Status),
+		Roles:         u.Roles,
+		Permissions:   u.Permissions,
 		Rating:        u.Rating,
 		Achievements:  achievements,
+		Promocodes:    PromocodesToResponse(u.Promocodes),
 	}
 }
 
@@ -145,9 +310,47 @@ type RefreshRequest struct {
 	RefreshToken string `json:"refresh_token" binding:"required"`
 }
 
+type ConfirmSignUpRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type ChangePasswordRequest struct {
+	OldPassword string `json:"old_password" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required"`
+}
+
+type PasswordResetRequest struct {
+	OstrovokLogin string `json:"ostrovok_login" binding:"required"`
+}
+
+type ResetPasswordRequest struct {
+	Token       string `json:"token" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required"`
+}
+
 type ReportImageResponse struct {
 	Id   string `json:"id"`
 	Link string `json:"link"`
+	// ItemId - пункт чек-листа, к которому приложено фото
+	ItemId string `json:"item_id,omitempty"`
+}
+
+// ReportChecklistItemResponse - пункт чек-листа оффера и ответ на него из отчета
+type ReportChecklistItemResponse struct {
+	ChecklistItemResponse
+	Answered bool    `json:"answered"`
+	Checked  *bool   `json:"checked,omitempty"`
+	Score    *int    `json:"score,omitempty"`
+	Text     *string `json:"text,omitempty"`
+}
+
+// ReportAnswerRequest - ответ на пункт чек-листа: checked для yes_no, score для score, text для text.
+// К пункту photo фото прикладываются полем images_<item_id>, text можно передать как комментарий
+type ReportAnswerRequest struct {
+	ItemId  string  `json:"item_id" binding:"required"`
+	Checked *bool   `json:"checked"`
+	Score   *int    `json:"score"`
+	Text    *string `json:"text"`
 }
 
 type ReportResponse struct {
@@ -164,16 +367,77 @@ type ReportResponse struct {
 	CheckInAt    time.Time              `json:"check_in_at"`
 	CheckOutAt   time.Time              `json:"check_out_at"`
 	Images       []*ReportImageResponse `json:"images"`
+	// Checklist - только при получении отчета по id
+	Checklist []*ReportChecklistItemResponse `json:"checklist,omitempty"`
 }
 
 type GetReportsResponse struct {
-	Reports    []*ReportResponse `json:"reports"`
-	PagesCount int               `json:"pages_count"`
+	Reports []*ReportResponse `json:"reports"`
+	PageResponse
+}
+
+type PromocodeResponse struct {
+	Id         string     `json:"id"`
+	Code       string     `json:"code"`
+	UserId     string     `json:"user_id"`
+	ReportId   string     `json:"report_id,omitempty"`
+	Value      int        `json:"value"`
+	Currency   string     `json:"currency"`
+	Status     string     `json:"status"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	IssuedAt   time.Time  `json:"issued_at"`
+	RedeemedAt *time.Time `json:"redeemed_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	ReplacedBy string     `json:"replaced_by,omitempty"`
+}
+
+func PromocodeModelToResponse(p *promocode.Promocode) *PromocodeResponse {
+	resp := &PromocodeResponse{
+		Id:         p.ID.String(),
+		Code:       p.Code,
+		UserId:     p.UserID.String(),
+		Value:      p.Value,
+		Currency:   p.Currency,
+		Status:     string(p.Status),
+		ExpiresAt:  p.ExpiresAt,
+		IssuedAt:   p.IssuedAt,
+		RedeemedAt: p.RedeemedAt,
+		RevokedAt:  p.RevokedAt,
+	}
+
+	if p.ReportID != uuid.Nil {
+		resp.ReportId = p.ReportID.String()
+	}
+
+	if p.ReplacedBy != nil {
+		resp.ReplacedBy = p.ReplacedBy.String()
+	}
+
+	return resp
+}
+
+func PromocodesToResponse(promocodes []promocode.Promocode) []*PromocodeResponse {
+	resp := make([]*PromocodeResponse, 0, len(promocodes))
+	for i := range promocodes {
+		resp = append(resp, PromocodeModelToResponse(&promocodes[i]))
+	}
+	return resp
+}
+
+type GetPromocodesResponse struct {
+	Promocodes []*PromocodeResponse `json:"promocodes"`
+	PageResponse
+}
+
+type RedeemPromocodeRequest struct {
+	Code string `json:"code" binding:"required"`
 }
 
 type UpdateReportRequest struct {
 	Text   string                  `form:"text"`
 	Images []*multipart.FileHeader `form:"image"`
+	// Answers - JSON-массив ReportAnswerRequest
+	Answers string `form:"answers"`
 }
 
 type ConfirmReport struct {
@@ -183,6 +447,30 @@ type ConfirmReport struct {
 type CreateHotelRequest struct {
 	Name       string `json:"name" binding:"required"`
 	LocationID string `json:"location_id" binding:"required"`
+	Address    string `json:"address"`
+	// Latitude и Longitude передаются вместе
+	Latitude    *float64 `json:"latitude"`
+	Longitude   *float64 `json:"longitude"`
+	Stars       *int     `json:"stars"`
+	Description string   `json:"description"`
+	Phone       string   `json:"phone"`
+	Email       string   `json:"email"`
+	Website     string   `json:"website"`
+}
+
+// UpdateHotelRequest - меняются только переданные поля
+type UpdateHotelRequest struct {
+	Name       *string `json:"name"`
+	LocationID *string `json:"location_id"`
+	Address    *string `json:"address"`
+	// Latitude и Longitude передаются вместе
+	Latitude    *float64 `json:"latitude"`
+	Longitude   *float64 `json:"longitude"`
+	Stars       *int     `json:"stars"`
+	Description *string  `json:"description"`
+	Phone       *string  `json:"phone"`
+	Email       *string  `json:"email"`
+	Website     *string  `json:"website"`
 }
 
 type CreateHotelResponse struct {
@@ -193,15 +481,35 @@ type CreateLocationRequest struct {
 	Name string `json:"name" binding:"required"`
 }
 
+type UpdateLocationRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
 type HotelResponse struct {
-	Id           string `json:"id"`
-	Name         string `json:"name"`
-	LocationId   string `json:"location_id"`
-	LocationName string `json:"location_name"`
+	Id           string   `json:"id"`
+	Name         string   `json:"name"`
+	LocationId   string   `json:"location_id"`
+	LocationName string   `json:"location_name"`
+	Address      string   `json:"address,omitempty"`
+	Latitude     *float64 `json:"latitude,omitempty"`
+	Longitude    *float64 `json:"longitude,omitempty"`
+	Stars        *int     `json:"stars,omitempty"`
+	Description  string   `json:"description,omitempty"`
+	Phone        string   `json:"phone,omitempty"`
+	Email        string   `json:"email,omitempty"`
+	Website      string   `json:"website,omitempty"`
+	// Images есть только в ответе по одному отелю
+	Images []*HotelImageResponse `json:"images,omitempty"`
+}
+
+type HotelImageResponse struct {
+	Id   string `json:"id"`
+	Link string `json:"link"`
 }
 
 type GetHotelsResponse struct {
 	Hotels []*HotelResponse `json:"hotels"`
+	PageResponse
 }
 
 type CreateLocationResponse struct {
@@ -215,10 +523,17 @@ type LocationResponse struct {
 
 type GetLocationsResponse struct {
 	Locations []*LocationResponse `json:"locations"`
+	PageResponse
 }
 
 type CreateRoomRequest struct {
 	Name string `json:"name" binding:"required"`
+	// HotelID - номер конкретного отеля. Без него создается общий тип номера
+	HotelID string `json:"hotel_id"`
+}
+
+type UpdateRoomRequest struct {
+	Name string `json:"name" binding:"required"`
 }
 
 type CreateRoomResponse struct {
@@ -226,12 +541,14 @@ type CreateRoomResponse struct {
 }
 
 type RoomResponse struct {
-	Id   string `json:"id"`
-	Name string `json:"name"`
+	Id      string `json:"id"`
+	Name    string `json:"name"`
+	HotelId string `json:"hotel_id,omitempty"`
 }
 
 type GetRoomsResponse struct {
 	Rooms []*RoomResponse `json:"rooms"`
+	PageResponse
 }
 
 type GetByApplicationIdResponse struct {
@@ -243,3 +560,253 @@ type AnalyticsResponse struct {
 	ApplicationsReceived uint64 `json:"applications_received"`
 	AcceptedReports      uint64 `json:"accepted_reports"`
 }
+
+type RoleResponse struct {
+	Id          string   `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+type GetRolesResponse struct {
+	Roles []*RoleResponse `json:"roles"`
+}
+
+type UserRolesResponse struct {
+	Roles []string `json:"roles"`
+}
+
+type AssignRoleRequest struct {
+	Role string `json:"role" binding:"required"`
+}
+
+type SessionResponse struct {
+	Id         string    `json:"id"`
+	UserAgent  string    `json:"user_agent"`
+	Ip         string    `json:"ip"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	Current    bool      `json:"current"`
+}
+
+type GetSessionsResponse struct {
+	Sessions []*SessionResponse `json:"sessions"`
+}
+
+type RevokeSessionsResponse struct {
+	Revoked int64 `json:"revoked"`
+}
+
+type CatalogImportErrorResponse struct {
+	Line       int    `json:"line"`
+	ExternalId string `json:"external_id,omitempty"`
+	Reason     string `json:"reason"`
+}
+
+type CatalogImportResponse struct {
+	DryRun  bool                          `json:"dry_run"`
+	Created int                           `json:"created"`
+	Updated int                           `json:"updated"`
+	Skipped int                           `json:"skipped"`
+	Errors  []*CatalogImportErrorResponse `json:"errors"`
+}
+
+func CatalogImportResultToResponse(result catalog.Result) *CatalogImportResponse {
+	resp := &CatalogImportResponse{
+		DryRun:  result.DryRun,
+		Created: result.Created,
+		Updated: result.Updated,
+		Skipped: result.Skipped,
+		Errors:  make([]*CatalogImportErrorResponse, 0, len(result.Errors)),
+	}
+
+	for _, e := range result.Errors {
+		resp.Errors = append(resp.Errors, &CatalogImportErrorResponse{
+			Line:       e.Line,
+			ExternalId: e.ExternalID,
+			Reason:     e.Reason,
+		})
+	}
+
+	return resp
+}
+
+type OfferImportErrorResponse struct {
+	Line   int    `json:"line"`
+	Reason string `json:"reason"`
+}
+
+type OfferImportResponse struct {
+	DryRun bool `json:"dry_run"`
+	// Rows - сколько строк с офферами в файле
+	Rows    int                         `json:"rows"`
+	Created []string                    `json:"created"`
+	Errors  []*OfferImportErrorResponse `json:"errors"`
+}
+
+func OfferImportResultToResponse(result offer.ImportResult) *OfferImportResponse {
+	resp := &OfferImportResponse{
+		DryRun:  result.DryRun,
+		Rows:    result.Rows,
+		Created: make([]string, 0, len(result.Created)),
+		Errors:  make([]*OfferImportErrorResponse, 0, len(result.Errors)),
+	}
+
+	for _, id := range result.Created {
+		resp.Created = append(resp.Created, id.String())
+	}
+
+	for _, e := range result.Errors {
+		resp.Errors = append(resp.Errors, &OfferImportErrorResponse{
+			Line:   e.Line,
+			Reason: e.Reason,
+		})
+	}
+
+	return resp
+}
+
+// OffersGeoJSON - FeatureCollection из RFC 7946, по точке на оффер
+type OffersGeoJSON struct {
+	Type     string                 `json:"type" example:"FeatureCollection"`
+	Features []*OfferGeoJSONFeature `json:"features"`
+}
+
+type OfferGeoJSONFeature struct {
+	Type       string             `json:"type" example:"Feature"`
+	Geometry   GeoJSONPoint       `json:"geometry"`
+	Properties OfferMapProperties `json:"properties"`
+}
+
+type GeoJSONPoint struct {
+	Type string `json:"type" example:"Point"`
+	// Coordinates - долгота и широта, именно в таком порядке
+	Coordinates [2]float64 `json:"coordinates"`
+}
+
+type OfferMapProperties struct {
+	Id             string    `json:"id"`
+	HotelId        string    `json:"hotel_id"`
+	HotelName      string    `json:"hotel_name"`
+	HotelAddress   string    `json:"hotel_address,omitempty"`
+	RoomName       string    `json:"room_name"`
+	CheckIn        time.Time `json:"check_in_at"`
+	CheckOut       time.Time `json:"check_out_at"`
+	ExpirationAt   time.Time `json:"expiration_at"`
+	RemainingSlots uint      `json:"remaining_slots"`
+	DistanceKm     *float64  `json:"distance_km,omitempty"`
+}
+
+func OffersToGeoJSON(offers []offer.Offer) *OffersGeoJSON {
+	resp := &OffersGeoJSON{
+		Type:     "FeatureCollection",
+		Features: make([]*OfferGeoJSONFeature, 0, len(offers)),
+	}
+
+	for _, o := range offers {
+		if o.HotelLatitude == nil || o.HotelLongitude == nil {
+			continue
+		}
+
+		var remaining uint
+		if o.ParticipantsLimit > o.ParticipantsCount {
+			remaining = o.ParticipantsLimit - o.ParticipantsCount
+		}
+
+		resp.Features = append(resp.Features, &OfferGeoJSONFeature{
+			Type: "Feature",
+			Geometry: GeoJSONPoint{
+				Type:        "Point",
+				Coordinates: [2]float64{*o.HotelLongitude, *o.HotelLatitude},
+			},
+			Properties: OfferMapProperties{
+				Id:             o.ID.String(),
+				HotelId:        o.HotelID.String(),
+				HotelName:      o.HotelName,
+				HotelAddress:   o.HotelAddress,
+				RoomName:       o.RoomName,
+				CheckIn:        o.CheckIn,
+				CheckOut:       o.CheckOut,
+				ExpirationAt:   o.ExpirationAt,
+				RemainingSlots: remaining,
+				DistanceKm:     o.DistanceKm,
+			},
+		})
+	}
+
+	return resp
+}
+
+type DrawCandidateResponse struct {
+	ApplicationId string  `json:"application_id"`
+	UserId        string  `json:"user_id"`
+	Rating        int     `json:"rating"`
+	Weight        float64 `json:"weight"`
+	// Номер победителя начиная с 1, нет у проигравших
+	WinnerOrder *int `json:"winner_order,omitempty"`
+}
+
+type DrawResponse struct {
+	Id               string `json:"id"`
+	OfferId          string `json:"offer_id"`
+	AlgorithmVersion int    `json:"algorithm_version"`
+	// Строкой, чтобы int64 не терял точность в JS
+	Seed         string                   `json:"seed"`
+	WinnersCount int                      `json:"winners_count"`
+	CreatedAt    time.Time                `json:"created_at"`
+	Candidates   []*DrawCandidateResponse `json:"candidates"`
+	// Заявки победителей в порядке выбора
+	Winners []string `json:"winners"`
+}
+
+type GetDrawsResponse struct {
+	Draws []*DrawResponse `json:"draws"`
+}
+
+type ReplayDrawResponse struct {
+	Draw *DrawResponse `json:"draw"`
+	// Победители повтора в порядке выбора
+	Winners      []string `json:"winners"`
+	WeightsMatch bool     `json:"weights_match"`
+	WinnersMatch bool     `json:"winners_match"`
+}
+
+func DrawToResponse(d draw.Draw) *DrawResponse {
+	resp := &DrawResponse{
+		Id:               d.ID.String(),
+		OfferId:          d.OfferID.String(),
+		AlgorithmVersion: d.Algorithm,
+		Seed:             strconv.FormatInt(d.Seed, 10),
+		WinnersCount:     d.WinnersCount,
+		CreatedAt:        d.CreatedAt,
+		Candidates:       make([]*DrawCandidateResponse, 0, len(d.Candidates)),
+		Winners:          uuidsToStrings(d.Winners()),
+	}
+	for _, c := range d.Candidates {
+		resp.Candidates = append(resp.Candidates, &DrawCandidateResponse{
+			ApplicationId: c.ApplicationID.String(),
+			UserId:        c.UserID.String(),
+			Rating:        c.Rating,
+			Weight:        c.Weight,
+			WinnerOrder:   c.WinnerOrder,
+		})
+	}
+	return resp
+}
+
+func ReplayToResponse(r draw.Replay) *ReplayDrawResponse {
+	return &ReplayDrawResponse{
+		Draw:         DrawToResponse(r.Draw),
+		Winners:      uuidsToStrings(r.Winners),
+		WeightsMatch: r.WeightsMatch,
+		WinnersMatch: r.WinnersMatch,
+	}
+}
+
+func uuidsToStrings(ids []uuid.UUID) []string {
+	res := make([]string, len(ids))
+	for i, id := range ids {
+		res[i] = id.String()
+	}
+	return res
+}
@@ -7,16 +7,26 @@ import (
 	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/worker"
 
 	"github.com/gin-gonic/gin"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/notifier"
 	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/ostrovok"
 	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/achievement"
 	analyticsRepo "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/analytics"
 	applicationRepo "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/application"
+	catalogRepo "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/catalog"
+	drawRepo "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/draw"
 	hotelRepo "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/hotel"
 	locationRepo "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/location"
+	loginAttemptRepo "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/loginattempt"
 	offerRepo "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/offer"
+	offerTemplateRepo "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/offertemplate"
+	passwordResetRepo "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/passwordreset"
+	promocodeRepo "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/promocode"
+	rbacRepo "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/rbac"
 	reportRepo "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/report"
 	roomRepo "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/room"
+	sessionRepo "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/session"
 	userRepo "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/user"
+	verificationRepo "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/verification"
 	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/s3/image"
 	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/config"
 
@@ -24,9 +34,14 @@ import (
 	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/handler/rest/middleware/auth"
 	analyticsUC "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/usecase/analytics"
 	applicationUC "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/usecase/application"
+	catalogUC "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/usecase/catalog"
+	drawUC "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/usecase/draw"
 	hotelUC "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/usecase/hotel"
 	locationUC "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/usecase/location"
 	offerUC "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/usecase/offer"
+	offerTemplateUC "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/usecase/offertemplate"
+	promocodeUC "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/usecase/promocode"
+	rbacUC "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/usecase/rbac"
 	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/usecase/report"
 	roomUC "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/usecase/room"
 	userUC "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/usecase/user"
@@ -36,8 +51,17 @@ func MustConfigureApp(engine *gin.Engine, cfg *config.Config) func() {
 
 	logger := log.New(os.Stdout, cfg.LoggerConfig.Prefix, cfg.LoggerConfig.Flag)
 
+	// Иначе gin верит X-Forwarded-For от любого клиента, и блокировку входа по IP можно обойти или навести на чужой IP
+	if err := engine.SetTrustedProxies(cfg.RestConfig.TrustedProxies); err != nil {
+		log.Fatalf("failed to set trusted proxies: %s", err.Error())
+	}
+
 	//Clients
-	ostrovokClient := ostrovok.NewClient()
+	ostrovokClient, err := ostrovok.New(&cfg.OstrovokConfig)
+	if err != nil {
+		log.Fatalf("failed to init ostrovok client: %s", err.Error())
+	}
+
 	sqlClient := initPostgresClient(&cfg.PostgresConfig)
 	minioClient, err := initMinioConnection(&cfg.MinioConfig)
 
@@ -45,6 +69,16 @@ func MustConfigureApp(engine *gin.Engine, cfg *config.Config) func() {
 		log.Fatalf("failed to connect to minio: %s", err.Error())
 	}
 
+	jwtKeys, err := initJWTKeys(&cfg.JWTConfig)
+	if err != nil {
+		log.Fatalf("failed to load jwt keys: %s", err.Error())
+	}
+
+	userNotifier, err := notifier.New(&cfg.NotifierConfig)
+	if err != nil {
+		log.Fatalf("failed to init notifier: %s", err.Error())
+	}
+
 	//Repos
 
 	applicationRepository := applicationRepo.NewApplicationRepo(sqlClient)
@@ -56,25 +90,60 @@ func MustConfigureApp(engine *gin.Engine, cfg *config.Config) func() {
 	reportRepository := reportRepo.NewRepo(sqlClient)
 	analyticsRepository := analyticsRepo.NewRepo(sqlClient)
 	achieventRepository := achievement.NewRepo(sqlClient)
+	sessionRepository := sessionRepo.NewRepo(sqlClient)
+	rbacRepository := rbacRepo.NewRepo(sqlClient)
+	loginAttemptRepository := loginAttemptRepo.NewRepo(sqlClient)
+	passwordResetRepository := passwordResetRepo.NewRepo(sqlClient)
+	verificationRepository := verificationRepo.NewRepo(sqlClient)
+	promocodeRepository := promocodeRepo.NewRepo(sqlClient)
+	catalogRepository := catalogRepo.NewRepo(sqlClient)
+	offerTemplateRepository := offerTemplateRepo.NewRepo(sqlClient)
+	drawRepository := drawRepo.NewRepo(sqlClient)
 
 	imageRepo := image.NewImageRepoMinio(minioClient, cfg.MinioConfig.PublicEndpoint, cfg.MinioConfig.BucketName)
 
 	//UseCases
 
 	applicationService := applicationUC.NewApplicationService(applicationRepository)
-	userUseCase := userUC.NewUseCase(userRepository, ostrovokClient, achieventRepository)
-	offerUseCase := offerUC.NewUseCase(offerRepository)
-	hotelUseCase := hotelUC.NewUseCase(hotelRepository)
+	userUseCase := userUC.NewUseCase(
+		userRepository,
+		ostrovokClient,
+		achieventRepository,
+		sessionRepository,
+		rbacRepository,
+		loginAttemptRepository,
+		passwordResetRepository,
+		verificationRepository,
+		promocodeRepository,
+		userNotifier,
+		cfg.NotifierConfig.ResetURL,
+		jwtKeys,
+	)
+	offerUseCase := offerUC.NewUseCase(
+		offerRepository,
+		userRepository,
+		hotelRepository,
+		roomRepository,
+		ostrovokClient,
+		userNotifier,
+	)
+	hotelUseCase := hotelUC.NewUseCase(hotelRepository, locationRepository, imageRepo)
 	locationUseCase := locationUC.NewUseCase(locationRepository)
-	roomUseCase := roomUC.NewUseCase(roomRepository)
+	roomUseCase := roomUC.NewUseCase(roomRepository, hotelRepository)
+	rbacUseCase := rbacUC.NewUseCase(rbacRepository)
+	promocodeUseCase := promocodeUC.NewUseCase(promocodeRepository, ostrovokClient)
+	catalogUseCase := catalogUC.NewUseCase(catalogRepository)
+	offerTemplateUseCase := offerTemplateUC.NewUseCase(offerTemplateRepository, offerUseCase, cfg.OfferTemplateConfig.Lookahead)
+	drawUseCase := drawUC.NewUseCase(drawRepository)
 
 	reportUsccase := report.New(
 		reportRepository,
 		imageRepo,
-		ostrovokClient,
+		promocodeUseCase,
 		userRepository,
 		applicationRepository,
 		achieventRepository,
+		offerUseCase,
 	)
 
 	analyticsUseCase := analyticsUC.NewAnalyticsUseCase(analyticsRepository)
@@ -84,15 +153,21 @@ func MustConfigureApp(engine *gin.Engine, cfg *config.Config) func() {
 	userHandler := handlers.NewUserHandler(userUseCase)
 	applicationHandler := handlers.NewApplicationHandler(applicationService)
 	offerHandler := handlers.NewOfferHandler(offerUseCase)
+	offerTemplateHandler := handlers.NewOfferTemplateHandler(offerTemplateUseCase)
 	reportHandler := handlers.NewReportHandler(reportUsccase)
 	hotelHandler := handlers.NewHotelHandler(hotelUseCase)
 	locationHandler := handlers.NewLocationHandler(locationUseCase)
 	roomHandler := handlers.NewRoomHandler(roomUseCase)
-	heathHandler := handlers.NewHealthHandler(sqlClient, minioClient)
+	heathHandler := handlers.NewHealthHandler(sqlClient, minioClient, ostrovokClient)
 	analyticsHandler := handlers.NewAnalyticsHandler(analyticsUseCase)
+	roleHandler := handlers.NewRoleHandler(rbacUseCase)
+	promocodeHandler := handlers.NewPromocodeHandler(promocodeUseCase)
+	catalogHandler := handlers.NewCatalogHandler(catalogUseCase)
+	drawHandler := handlers.NewDrawHandler(drawUseCase)
+	jwksHandler := handlers.NewJWKSHandler(jwtKeys)
 
 	//MiddleWare
-	authMiddleWare := auth.NewAuth(userUseCase)
+	authMiddleWare := auth.NewAuth(userUseCase, rbacUseCase)
 
 	//InitEndpoints
 	initAllEndpoints(
@@ -102,19 +177,29 @@ func MustConfigureApp(engine *gin.Engine, cfg *config.Config) func() {
 		userHandler,
 		applicationHandler,
 		offerHandler,
+		offerTemplateHandler,
 		reportHandler,
 		hotelHandler,
 		locationHandler,
 		roomHandler,
 		analyticsHandler,
+		roleHandler,
+		promocodeHandler,
+		catalogHandler,
+		drawHandler,
+		jwksHandler,
 		heathHandler,
 		sqlClient,
 	)
 
-	secretGuestWorker := worker.NewSecretGuestWorker(offerRepository, applicationRepository, reportRepository, userRepository)
+	secretGuestWorker := worker.NewSecretGuestWorker(drawUseCase)
 	secretGuestWorker.Start()
 
+	offerTemplateWorker := worker.NewOfferTemplateWorker(offerTemplateUseCase, offerUseCase)
+	offerTemplateWorker.Start()
+
 	return func() {
 		secretGuestWorker.Stop()
+		offerTemplateWorker.Stop()
 	}
 }
@@ -0,0 +1,73 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/docs"
+	catalogRepo "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/catalog"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/config"
+	model "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/catalog"
+	catalogUC "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/usecase/catalog"
+)
+
+// RunCommand выполняет подкоманду бинарника вместо запуска сервера, например
+// backend-app import-catalog -file feed.csv -dry-run
+func RunCommand(cfg *config.Config, args []string) error {
+	switch args[0] {
+	case "import-catalog":
+		return runImportCatalog(cfg, args[1:])
+	default:
+		return fmt.Errorf("unknown command: %s", args[0])
+	}
+}
+
+func runImportCatalog(cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("import-catalog", flag.ContinueOnError)
+	filePath := flags.String("file", "", "path to feed, - for stdin")
+	format := flags.String("format", "", "jsonl or csv, by default taken from file extension")
+	dryRun := flags.Bool("dry-run", false, "only report what would be changed")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *filePath == "" {
+		return errors.New("-file is required")
+	}
+
+	var feed io.Reader = os.Stdin
+	if *filePath != "-" {
+		file, err := os.Open(*filePath)
+		if err != nil {
+			return fmt.Errorf("failed to open feed: %w", err)
+		}
+		defer file.Close()
+		feed = file
+	}
+
+	feedFormat := model.Format(*format)
+	if feedFormat == "" {
+		feedFormat = catalogUC.FormatFromFileName(*filePath)
+	}
+
+	sqlClient := initPostgresClient(&cfg.PostgresConfig)
+	defer sqlClient.Close()
+
+	useCase := catalogUC.NewUseCase(catalogRepo.NewRepo(sqlClient))
+
+	result, err := useCase.Import(context.Background(), feed, feedFormat, *dryRun)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(docs.CatalogImportResultToResponse(result))
+}
@@ -21,6 +21,14 @@ VALUES ('f47ac10b-58cc-4372-a567-0e02b2c3d479', 'doverlof',
         'ee1a7dc746c6024bd64fe2e2245e6566fbff2d27f617284a002ec1202734d3c7', true);
 `
 
+const fillUserRole = `
+INSERT INTO user_role (user_id, role_id)
+SELECT u.id, r.id
+FROM "user" u
+         INNER JOIN role r ON r.name = CASE WHEN u.is_admin THEN 'admin' ELSE 'reviewer' END
+ON CONFLICT DO NOTHING;
+`
+
 const fillLocation = `
 INSERT INTO location (id, name)
 VALUES ('f47ac10b-58cc-4372-a567-0e02b2c3d479', 'Moscow'),
@@ -141,6 +149,7 @@ func InitDataHandler(client *sqlx.DB) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		scripts := []string{
 			fillUser,
+			fillUserRole,
 			fillLocation,
 			fillRoom,
 			fillHotel,
@@ -7,6 +7,7 @@ import (
 	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/handler/rest/handlers"
 	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/handler/rest/middleware/auth"
 	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/handler/rest/middleware/cors"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/rbac"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
@@ -29,28 +30,41 @@ func initAllEndpoints(
 	userHandler handlers.UserHandler,
 	applicationHandler handlers.ApplicationHandler,
 	offerHandler handlers.OfferHandler,
+	offerTemplateHandler handlers.OfferTemplateHandler,
 	reportHandler handlers.ReportHandler,
 	hotelHandler handlers.HotelHandler,
 	locationHandler handlers.LocationHandler,
 	roomHandler handlers.RoomHandler,
 	analyticsHandler handlers.AnalyticsHandler,
+	roleHandler handlers.RoleHandler,
+	promocodeHandler handlers.PromocodeHandler,
+	catalogHandler handlers.CatalogHandler,
+	drawHandler handlers.DrawHandler,
+	jwksHandler handlers.JWKSHandler,
 	healthHandler handlers.HealthHandler,
 	client *sqlx.DB,
 ) {
 	engine.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 	engine.Use(cors.CORS(cfg.AllowOrigin))
 	engine.GET("/health", healthHandler.Health)
+	engine.GET("/health/ostrovok-cache", healthHandler.OstrovokCacheStats)
+	engine.GET("/.well-known/jwks.json", jwksHandler.GetJWKS)
 
 	router := engine.Group("/api/v1")
 
 	initUserEndpoints(router, authProvider, userHandler)
 	initApplicationHandler(router, authProvider, applicationHandler)
 	initOfferHandler(router, authProvider, offerHandler)
+	initOfferTemplateHandler(router, authProvider, offerTemplateHandler)
 	initReportHandler(router, authProvider, reportHandler)
 	initHotelHandler(router, authProvider, hotelHandler)
 	initLocationHandler(router, authProvider, locationHandler)
 	initRoomHandler(router, authProvider, roomHandler)
 	initAnalyticsHandler(router, authProvider, analyticsHandler)
+	initRoleHandler(router, authProvider, roleHandler)
+	initPromocodeHandler(router, authProvider, promocodeHandler)
+	initCatalogHandler(router, authProvider, catalogHandler)
+	initDrawHandler(router, authProvider, drawHandler)
 
 	router.POST("test", InitDataHandler(client))
 }
@@ -61,8 +75,17 @@ func initUserEndpoints(router *gin.RouterGroup, authProvider auth.Auth, h handle
 	{
 		group.POST("/log-in", h.LogIn)
 		group.POST("/sign-up", h.SignUp)
+		group.POST("/sign-up/confirm", authProvider.LoginProtected(), h.ConfirmSignUp)
+		group.POST("/sign-up/resend", authProvider.LoginProtected(), h.ResendSignUpCode)
 		group.POST("/refresh", h.Refresh)
+		group.POST("/password/reset-request", h.RequestPasswordReset)
+		group.POST("/password/reset", h.ResetPassword)
+		group.POST("/password/change", authProvider.LoginProtected(), h.ChangePassword)
 		group.GET("/", authProvider.LoginProtected(), h.GetMe)
+		group.POST("/log-out", authProvider.LoginProtected(), h.LogOut)
+		group.GET("/sessions", authProvider.LoginProtected(), h.GetSessions)
+		group.DELETE("/sessions/:id", authProvider.LoginProtected(), h.RevokeSession)
+		group.DELETE("/:id/sessions", authProvider.PermissionProtected(rbac.PermUserManageSessions), h.RevokeUserSessions)
 	}
 }
 
@@ -71,11 +94,12 @@ func initApplicationHandler(router *gin.RouterGroup, authProvider auth.Auth, h h
 	group := router.Group("/application")
 
 	{
-		group.POST("/", authProvider.RoleProtected("reviewer"), h.CreateApplication)
-		group.GET("/", authProvider.RoleProtected("reviewer"), h.GetApplications)
-		group.GET("/limit", authProvider.RoleProtected("reviewer"), h.GetUserAppLimitInfo)
-		group.GET("/:id", authProvider.RoleProtected("reviewer"), h.GetApplicationById)
-		group.GET("/search", authProvider.RoleProtected("admin"), h.GetAppsByFilter)
+		group.POST("/", authProvider.PermissionProtected(rbac.PermApplicationApply), h.CreateApplication)
+		group.GET("/", authProvider.PermissionProtected(rbac.PermApplicationApply), h.GetApplications)
+		group.GET("/limit", authProvider.PermissionProtected(rbac.PermApplicationApply), h.GetUserAppLimitInfo)
+		group.GET("/:id", authProvider.PermissionProtected(rbac.PermApplicationApply), h.GetApplicationById)
+		group.PATCH("/:id/withdraw", authProvider.PermissionProtected(rbac.PermApplicationApply), h.WithdrawApplication)
+		group.GET("/search", authProvider.PermissionProtected(rbac.PermApplicationRead), h.GetAppsByFilter)
 	}
 }
 
@@ -84,12 +108,28 @@ func initOfferHandler(router *gin.RouterGroup, authProvider auth.Auth, h handler
 	group := router.Group("/offer")
 
 	{
-		group.POST("/", authProvider.RoleProtected("admin"), h.CreateOffer)
-		group.GET("/", authProvider.RoleProtected("admin"), h.GetOffers)
-		group.GET("/:id", authProvider.RoleProtected("admin"), h.GetOfferById)
-		group.PATCH("/:id", authProvider.RoleProtected("admin"), h.UpdateOffer)
+		group.POST("/", authProvider.PermissionProtected(rbac.PermOfferWrite), h.CreateOffer)
+		group.POST("/import", authProvider.PermissionProtected(rbac.PermOfferWrite), h.ImportOffers)
+		group.GET("/", authProvider.PermissionProtected(rbac.PermOfferRead), h.GetOffers)
+		group.GET("/:id", authProvider.PermissionProtected(rbac.PermOfferRead), h.GetOfferById)
+		group.PATCH("/:id", authProvider.PermissionProtected(rbac.PermOfferWrite), h.UpdateOffer)
+		group.PATCH("/:id/publish", authProvider.PermissionProtected(rbac.PermOfferWrite), h.PublishOffer)
+		group.PATCH("/:id/cancel", authProvider.PermissionProtected(rbac.PermOfferWrite), h.CancelOffer)
+
+		group.GET("/search", authProvider.PermissionProtected(rbac.PermOfferSearch), h.FindOffers)
+		group.GET("/map", authProvider.PermissionProtected(rbac.PermOfferSearch), h.GetOffersMap)
+	}
+}
 
-		group.GET("/search", authProvider.RoleProtected("reviewer"), h.FindOffers)
+func initOfferTemplateHandler(router *gin.RouterGroup, authProvider auth.Auth, h handlers.OfferTemplateHandler) {
+	group := router.Group("/offer-template")
+
+	{
+		group.POST("/", authProvider.PermissionProtected(rbac.PermOfferWrite), h.CreateOfferTemplate)
+		group.GET("/", authProvider.PermissionProtected(rbac.PermOfferWrite), h.GetOfferTemplates)
+		group.GET("/:id", authProvider.PermissionProtected(rbac.PermOfferWrite), h.GetOfferTemplateById)
+		group.PATCH("/:id/activate", authProvider.PermissionProtected(rbac.PermOfferWrite), h.ActivateOfferTemplate)
+		group.PATCH("/:id/deactivate", authProvider.PermissionProtected(rbac.PermOfferWrite), h.DeactivateOfferTemplate)
 	}
 }
 
@@ -98,16 +138,16 @@ func initReportHandler(router *gin.RouterGroup, authProvider auth.Auth, h handle
 	group := router.Group("/report")
 
 	{
-		group.GET("/", authProvider.RoleProtected("admin"), h.GetReports)
-		group.GET("/search", authProvider.RoleProtected("admin"), h.GetReportsByFilter)
-		group.GET("/:id", authProvider.RoleProtected("admin"), h.GetReportById)
-		group.PATCH("/:id/confirm", authProvider.RoleProtected("admin"), h.ConfirmReport)
+		group.GET("/", authProvider.PermissionProtected(rbac.PermReportRead), h.GetReports)
+		group.GET("/search", authProvider.PermissionProtected(rbac.PermReportRead), h.GetReportsByFilter)
+		group.GET("/:id", authProvider.PermissionProtected(rbac.PermReportRead), h.GetReportById)
+		group.PATCH("/:id/confirm", authProvider.PermissionProtected(rbac.PermReportConfirm), h.ConfirmReport)
 
-		group.GET("/my", authProvider.RoleProtected("reviewer"), h.GetMyReports)
-		group.GET("/my/:id", authProvider.RoleProtected("reviewer"), h.GetMyReportById)
-		group.PATCH("/:id", authProvider.RoleProtected("reviewer"), h.UpdateReport)
+		group.GET("/my", authProvider.PermissionProtected(rbac.PermReportSubmit), h.GetMyReports)
+		group.GET("/my/:id", authProvider.PermissionProtected(rbac.PermReportSubmit), h.GetMyReportById)
+		group.PATCH("/:id", authProvider.PermissionProtected(rbac.PermReportSubmit), h.UpdateReport)
 
-		group.GET("/my/application/:id", authProvider.RoleProtected("reviewer"), h.GetMyReportByApplicationId)
+		group.GET("/my/application/:id", authProvider.PermissionProtected(rbac.PermReportSubmit), h.GetMyReportByApplicationId)
 	}
 }
 
@@ -115,30 +155,82 @@ func initHotelHandler(router *gin.RouterGroup, authProvider auth.Auth, h handler
 	group := router.Group("/hotel")
 
 	{
-		group.POST("/", authProvider.RoleProtected("admin"), h.CreateHotel)
+		group.POST("/", authProvider.PermissionProtected(rbac.PermCatalogWrite), h.CreateHotel)
 		group.GET("/", h.GetHotels)
+		group.GET("/:id", h.GetHotelById)
+		group.PATCH("/:id", authProvider.PermissionProtected(rbac.PermCatalogWrite), h.UpdateHotel)
+		group.DELETE("/:id", authProvider.PermissionProtected(rbac.PermCatalogWrite), h.DeleteHotel)
+		group.POST("/:id/images", authProvider.PermissionProtected(rbac.PermCatalogWrite), h.AddHotelImages)
+		group.DELETE("/:id/images/:imageId", authProvider.PermissionProtected(rbac.PermCatalogWrite), h.DeleteHotelImage)
 	}
 }
 
 func initLocationHandler(router *gin.RouterGroup, authProvider auth.Auth, h handlers.LocationHandler) {
 	group := router.Group("/location")
 	{
-		group.POST("/", authProvider.RoleProtected("admin"), h.CreateLocation)
+		group.POST("/", authProvider.PermissionProtected(rbac.PermCatalogWrite), h.CreateLocation)
 		group.GET("/", h.GetLocations)
+		group.GET("/:id", h.GetLocationById)
+		group.PATCH("/:id", authProvider.PermissionProtected(rbac.PermCatalogWrite), h.UpdateLocation)
+		group.DELETE("/:id", authProvider.PermissionProtected(rbac.PermCatalogWrite), h.DeleteLocation)
 	}
 }
 
 func initRoomHandler(router *gin.RouterGroup, authProvider auth.Auth, h handlers.RoomHandler) {
 	group := router.Group("/room")
 	{
-		group.POST("/", authProvider.RoleProtected("admin"), h.CreateRoom)
+		group.POST("/", authProvider.PermissionProtected(rbac.PermCatalogWrite), h.CreateRoom)
 		group.GET("/", h.GetRooms)
+		group.GET("/:id", h.GetRoomById)
+		group.PATCH("/:id", authProvider.PermissionProtected(rbac.PermCatalogWrite), h.UpdateRoom)
+		group.DELETE("/:id", authProvider.PermissionProtected(rbac.PermCatalogWrite), h.DeleteRoom)
 	}
 }
 
 func initAnalyticsHandler(router *gin.RouterGroup, authProvider auth.Auth, h handlers.AnalyticsHandler) {
 	group := router.Group("/analytics")
 	{
-		group.GET("/", authProvider.RoleProtected("admin"), h.GetAnalytics)
+		group.GET("/", authProvider.PermissionProtected(rbac.PermAnalyticsRead), h.GetAnalytics)
+	}
+}
+
+func initRoleHandler(router *gin.RouterGroup, authProvider auth.Auth, h handlers.RoleHandler) {
+	group := router.Group("/role")
+
+	{
+		group.GET("/", authProvider.PermissionProtected(rbac.PermUserManageRoles), h.GetRoles)
+		group.GET("/user/:id", authProvider.PermissionProtected(rbac.PermUserManageRoles), h.GetUserRoles)
+		group.POST("/user/:id", authProvider.PermissionProtected(rbac.PermUserManageRoles), h.AssignRole)
+		group.DELETE("/user/:id/:role", authProvider.PermissionProtected(rbac.PermUserManageRoles), h.RevokeRole)
+	}
+}
+
+func initPromocodeHandler(router *gin.RouterGroup, authProvider auth.Auth, h handlers.PromocodeHandler) {
+	group := router.Group("/promocode")
+
+	{
+		group.GET("/", authProvider.PermissionProtected(rbac.PermPromocodeManage), h.GetPromocodes)
+		group.POST("/redeem", authProvider.PermissionProtected(rbac.PermPromocodeManage), h.RedeemPromocode)
+		group.PATCH("/:id/revoke", authProvider.PermissionProtected(rbac.PermPromocodeManage), h.RevokePromocode)
+		group.POST("/:id/reissue", authProvider.PermissionProtected(rbac.PermPromocodeManage), h.ReissuePromocode)
+	}
+}
+
+func initCatalogHandler(router *gin.RouterGroup, authProvider auth.Auth, h handlers.CatalogHandler) {
+	group := router.Group("/catalog")
+
+	{
+		group.POST("/import", authProvider.PermissionProtected(rbac.PermCatalogWrite), h.ImportCatalog)
+	}
+}
+
+func initDrawHandler(router *gin.RouterGroup, authProvider auth.Auth, h handlers.DrawHandler) {
+	router.GET("/offer/:id/draws", authProvider.PermissionProtected(rbac.PermDrawAudit), h.GetOfferDraws)
+
+	group := router.Group("/draw")
+
+	{
+		group.GET("/:id", authProvider.PermissionProtected(rbac.PermDrawAudit), h.GetDrawById)
+		group.POST("/:id/replay", authProvider.PermissionProtected(rbac.PermDrawAudit), h.ReplayDraw)
 	}
 }
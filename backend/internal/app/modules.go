@@ -3,12 +3,14 @@ package app
 import (
 	"context"
 	"fmt"
+	"os"
 
 	_ "github.com/jackc/pgx/v5/stdlib"
 	"github.com/jmoiron/sqlx"
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
 	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/config"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/pkg"
 )
 
 func initPostgresClient(config *config.PostgresConfig) *sqlx.DB {
@@ -62,3 +64,39 @@ func initMinioConnection(cfg *config.MinioConfig) (*minio.Client, error) {
 
 	return client, nil
 }
+
+func initJWTKeys(cfg *config.JWTConfig) (*pkg.JWTKeySet, error) {
+	keys := make([]pkg.JWTKey, 0, len(cfg.Keys))
+
+	for _, keyCfg := range cfg.Keys {
+		var (
+			key pkg.JWTKey
+			err error
+		)
+
+		switch {
+		case keyCfg.PrivateKeyFile != "":
+			data, readErr := os.ReadFile(keyCfg.PrivateKeyFile)
+			if readErr != nil {
+				return nil, fmt.Errorf("failed to read jwt key %s: %w", keyCfg.ID, readErr)
+			}
+			key, err = pkg.ParseJWTPrivateKey(keyCfg.ID, data)
+		case keyCfg.PublicKeyFile != "":
+			data, readErr := os.ReadFile(keyCfg.PublicKeyFile)
+			if readErr != nil {
+				return nil, fmt.Errorf("failed to read jwt key %s: %w", keyCfg.ID, readErr)
+			}
+			key, err = pkg.ParseJWTPublicKey(keyCfg.ID, data)
+		default:
+			return nil, fmt.Errorf("jwt key %s has neither private nor public key file", keyCfg.ID)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, key)
+	}
+
+	return pkg.NewJWTKeySet(cfg.SigningKeyID, keys...)
+}
@@ -0,0 +1,52 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	model "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/notification"
+)
+
+// logNotifier ничего не отправляет, а пишет сообщение в лог. Для локальной разработки
+type logNotifier struct{}
+
+func NewLogNotifier() Notifier {
+	return &logNotifier{}
+}
+
+func (n *logNotifier) Send(_ context.Context, msg model.Message) error {
+	log.Printf("notification to %s: %s\n%s", msg.To, msg.Subject, msg.Body)
+	return nil
+}
+
+// fileNotifier дописывает сообщения в файл, чтобы их можно было прочитать без почтового сервера
+type fileNotifier struct {
+	path string
+	mu   sync.Mutex
+}
+
+func NewFileNotifier(path string) Notifier {
+	return &fileNotifier{path: path}
+}
+
+func (n *fileNotifier) Send(_ context.Context, msg model.Message) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	f, err := os.OpenFile(n.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open notifications file: %w", err)
+	}
+	defer f.Close()
+
+	_, err = fmt.Fprintf(f, "%s\nTo: %s\nSubject: %s\n\n%s\n\n", time.Now().Format(time.RFC3339), msg.To, msg.Subject, msg.Body)
+	if err != nil {
+		return fmt.Errorf("failed to write notification: %w", err)
+	}
+
+	return nil
+}
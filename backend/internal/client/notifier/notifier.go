@@ -0,0 +1,27 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/config"
+	model "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/notification"
+)
+
+// Notifier доставляет пользователю сообщения (ссылки сброса пароля, коды подтверждения)
+type Notifier interface {
+	Send(ctx context.Context, msg model.Message) error
+}
+
+func New(cfg *config.NotifierConfig) (Notifier, error) {
+	switch cfg.Type {
+	case "smtp":
+		return NewSMTPNotifier(&cfg.SMTP), nil
+	case "file":
+		return NewFileNotifier(cfg.FilePath), nil
+	case "log", "":
+		return NewLogNotifier(), nil
+	default:
+		return nil, fmt.Errorf("unknown notifier type: %s", cfg.Type)
+	}
+}
@@ -0,0 +1,120 @@
+package notifier
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/config"
+	model "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/notification"
+)
+
+// smtpTimeout ограничивает отправку, если у ctx нет своего дедлайна
+const smtpTimeout = 30 * time.Second
+
+type smtpNotifier struct {
+	addr string
+	host string
+	auth smtp.Auth
+	from string
+}
+
+func NewSMTPNotifier(cfg *config.SMTPConfig) Notifier {
+	var auth smtp.Auth
+	if cfg.Username != "" {
+		auth = smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
+	}
+
+	return &smtpNotifier{
+		addr: net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port)),
+		host: cfg.Host,
+		auth: auth,
+		from: cfg.From,
+	}
+}
+
+func (n *smtpNotifier) Send(ctx context.Context, msg model.Message) error {
+	var b strings.Builder
+
+	b.WriteString("From: " + n.from + "\r\n")
+	b.WriteString("To: " + msg.To + "\r\n")
+	b.WriteString("Subject: " + msg.Subject + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(msg.Body)
+
+	if err := n.send(ctx, msg.To, []byte(b.String())); err != nil {
+		return fmt.Errorf("failed to send mail: %w", err)
+	}
+
+	return nil
+}
+
+// send повторяет smtp.SendMail, но соединение живет не дольше ctx
+func (n *smtpNotifier) send(ctx context.Context, to string, body []byte) error {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, smtpTimeout)
+		defer cancel()
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", n.addr)
+	if err != nil {
+		return err
+	}
+
+	deadline, _ := ctx.Deadline()
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return err
+	}
+
+	c, err := smtp.NewClient(conn, n.host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: n.host}); err != nil {
+			return err
+		}
+	}
+
+	if n.auth != nil {
+		if err := c.Auth(n.auth); err != nil {
+			return err
+		}
+	}
+
+	if err := c.Mail(n.from); err != nil {
+		return err
+	}
+
+	if err := c.Rcpt(to); err != nil {
+		return err
+	}
+
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.Write(body); err != nil {
+		return err
+	}
+
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return c.Quit()
+}
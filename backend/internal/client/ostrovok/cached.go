@@ -0,0 +1,83 @@
+package ostrovok
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync/atomic"
+	"time"
+
+	model "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/ostrovok"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/pkg"
+)
+
+// CacheStats - счетчики обращений к кэшу профилей
+type CacheStats struct {
+	Hits      uint64 `json:"hits"`
+	Misses    uint64 `json:"misses"`
+	StaleHits uint64 `json:"stale_hits"`
+	Size      int    `json:"size"`
+}
+
+type CachedClient interface {
+	Client
+	Stats() CacheStats
+}
+
+// cachedClient кэширует профили пользователей. Если Островок недоступен,
+// отдает последний полученный профиль, даже если он просрочен
+type cachedClient struct {
+	next      Client
+	cache     *pkg.TTLCache[string, model.OstrovokUser]
+	hits      atomic.Uint64
+	misses    atomic.Uint64
+	staleHits atomic.Uint64
+}
+
+func NewCachedClient(next Client, size int, ttl time.Duration) CachedClient {
+	return &cachedClient{
+		next:  next,
+		cache: pkg.NewTTLCache[string, model.OstrovokUser](size, ttl),
+	}
+}
+
+func (c *cachedClient) GetUserByLogin(ctx context.Context, login string) (*model.OstrovokUser, error) {
+	cached, fresh, ok := c.cache.Get(login)
+	if fresh {
+		c.hits.Add(1)
+		return &cached, nil
+	}
+
+	c.misses.Add(1)
+
+	user, err := c.next.GetUserByLogin(ctx, login)
+	switch {
+	case errors.Is(err, ErrUserNotExists):
+		c.cache.Delete(login)
+		return nil, err
+	case err != nil && ok:
+		c.staleHits.Add(1)
+		log.Printf("ostrovok: serving stale profile of %s: %v", login, err)
+		return &cached, nil
+	case err != nil:
+		return nil, err
+	}
+
+	c.cache.Set(login, *user)
+
+	return user, nil
+}
+
+// GeneratePromocode не кэшируется: каждый вызов должен давать новый код
+func (c *cachedClient) GeneratePromocode(ctx context.Context) (string, error) {
+	return c.next.GeneratePromocode(ctx)
+}
+
+func (c *cachedClient) Stats() CacheStats {
+	return CacheStats{
+		Hits:      c.hits.Load(),
+		Misses:    c.misses.Load(),
+		StaleHits: c.staleHits.Load(),
+		Size:      c.cache.Len(),
+	}
+}
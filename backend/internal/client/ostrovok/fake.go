@@ -0,0 +1,71 @@
+package ostrovok
+
+import (
+	"context"
+	"math/rand"
+	"strings"
+
+	model "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/ostrovok"
+)
+
+// fakeUsers - пользователи Островка для локальной разработки, совпадают с тестовыми данными в базе
+var fakeUsers = map[string]*model.OstrovokUser{
+	"doverlof": {
+		Login: "doverlof",
+		Email: "doverlof@exampleemail.com",
+	},
+	"notblinkyet": {
+		Login: "notblinkyet",
+		Email: "notblinkyet@exampleemail.com",
+	},
+	"smokingElk": {
+		Login: "smokingElk",
+		Email: "chicherin@exampleemail.com",
+	},
+	"sophistik": {
+		Login: "sophistik",
+		Email: "chicherin@exampleemail.com",
+	},
+	"chicherin": {
+		Login: "chicherin",
+		Email: "chicherin@exampleemail.com",
+	},
+	"root": {
+		Login: "root",
+		Email: "root@exampleemail.com",
+	},
+}
+
+// fakeClient отвечает без сети: пользователи берутся из fakeUsers, промокоды генерируются случайно
+type fakeClient struct {
+}
+
+func NewFakeClient() Client {
+	return &fakeClient{}
+}
+
+func (c *fakeClient) GetUserByLogin(_ context.Context, login string) (*model.OstrovokUser, error) {
+	if user, ok := fakeUsers[login]; ok {
+		return user, nil
+	}
+	return nil, ErrUserNotExists
+}
+
+func (c *fakeClient) GeneratePromocode(ctx context.Context) (string, error) {
+	const letters = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	const digits = "0123456789"
+
+	var result strings.Builder
+
+	result.WriteByte(letters[rand.Intn(len(letters))])
+	result.WriteByte(digits[rand.Intn(len(digits))])
+	result.WriteByte(digits[rand.Intn(len(digits))])
+
+	result.WriteByte('-')
+
+	result.WriteByte(letters[rand.Intn(len(letters))])
+	result.WriteByte(digits[rand.Intn(len(digits))])
+	result.WriteByte(digits[rand.Intn(len(digits))])
+
+	return result.String(), nil
+}
@@ -0,0 +1,191 @@
+package ostrovok
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/config"
+	model "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/ostrovok"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/pkg"
+)
+
+const maxErrorBodySize = 1024
+
+type httpClient struct {
+	baseURL    string
+	apiKey     string
+	http       *http.Client
+	breaker    *pkg.CircuitBreaker
+	maxRetries int
+	retryBase  time.Duration
+	retryMax   time.Duration
+}
+
+func NewHTTPClient(cfg *config.OstrovokConfig) (Client, error) {
+	if cfg.BaseURL == "" {
+		return nil, errors.New("ostrovok base url is not set")
+	}
+
+	if _, err := url.Parse(cfg.BaseURL); err != nil {
+		return nil, fmt.Errorf("invalid ostrovok base url: %w", err)
+	}
+
+	return &httpClient{
+		baseURL:    strings.TrimRight(cfg.BaseURL, "/"),
+		apiKey:     cfg.APIKey,
+		http:       &http.Client{Timeout: cfg.Timeout},
+		breaker:    pkg.NewCircuitBreaker(cfg.BreakerFailures, cfg.BreakerOpenTimeout),
+		maxRetries: cfg.MaxRetries,
+		retryBase:  cfg.RetryBaseDelay,
+		retryMax:   cfg.RetryMaxDelay,
+	}, nil
+}
+
+type userResponse struct {
+	Login string `json:"login"`
+	Email string `json:"email"`
+}
+
+func (c *httpClient) GetUserByLogin(ctx context.Context, login string) (*model.OstrovokUser, error) {
+	var resp userResponse
+
+	err := c.do(ctx, http.MethodGet, "/api/v1/users/"+url.PathEscape(login), nil, "", &resp)
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		return nil, ErrUserNotExists
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.OstrovokUser{
+		Login: resp.Login,
+		Email: resp.Email,
+	}, nil
+}
+
+type promocodeResponse struct {
+	Code string `json:"code"`
+}
+
+func (c *httpClient) GeneratePromocode(ctx context.Context) (string, error) {
+	var resp promocodeResponse
+
+	// Один ключ на все повторы, чтобы при потерянном ответе Островок не выпустил второй промокод
+	idempotencyKey := uuid.NewString()
+
+	if err := c.do(ctx, http.MethodPost, "/api/v1/promocodes", struct{}{}, idempotencyKey, &resp); err != nil {
+		return "", err
+	}
+
+	return resp.Code, nil
+}
+
+// do выполняет запрос с повторами. Повторяются сетевые ошибки, 429 и 5xx,
+// между попытками - экспоненциальная задержка с jitter.
+func (c *httpClient) do(ctx context.Context, method, path string, body any, idempotencyKey string, out any) error {
+	var payload []byte
+
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return fmt.Errorf("failed to marshal ostrovok request: %w", err)
+		}
+	}
+
+	var lastErr error
+
+	for attempt := 0; attempt <= c.maxRetries; attempt++ {
+		if attempt > 0 {
+			delay := pkg.FullJitter(pkg.ExponentialBackoff(c.retryBase, c.retryMax, attempt-1))
+
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(delay):
+			}
+		}
+
+		if err := c.breaker.Allow(); err != nil {
+			return fmt.Errorf("%w: %s", ErrUnavailable, err.Error())
+		}
+
+		retryable, err := c.attempt(ctx, method, path, payload, idempotencyKey, out)
+		if err == nil {
+			c.breaker.Success()
+			return nil
+		}
+
+		if !retryable {
+			// Ошибки клиента (4xx) не говорят о недоступности Островка
+			c.breaker.Success()
+			return err
+		}
+
+		c.breaker.Failure()
+		lastErr = err
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+
+	return fmt.Errorf("%w: %s", ErrUnavailable, lastErr.Error())
+}
+
+func (c *httpClient) attempt(ctx context.Context, method, path string, payload []byte, idempotencyKey string, out any) (bool, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return false, fmt.Errorf("failed to create ostrovok request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+	if idempotencyKey != "" {
+		req.Header.Set("Idempotency-Key", idempotencyKey)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return true, fmt.Errorf("failed to call ostrovok: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return false, fmt.Errorf("failed to decode ostrovok response: %w", err)
+		}
+		return false, nil
+	}
+
+	errBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Body:       string(errBody),
+	}
+
+	retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+
+	return retryable, apiErr
+}
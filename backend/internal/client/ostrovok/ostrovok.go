@@ -3,76 +3,52 @@ package ostrovok
 import (
 	"context"
 	"errors"
-	"math/rand"
-	"strings"
+	"fmt"
 
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/config"
 	model "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/ostrovok"
 )
 
 var (
 	ErrUserNotExists = errors.New("user don't found in ostrovok")
-	users            = map[string]*model.OstrovokUser{
-		"doverlof": {
-			Login: "doverlof",
-			Email: "doverlof@exampleemail.com",
-		},
-		"notblinkyet": {
-			Login: "notblinkyet",
-			Email: "notblinkyet@exampleemail.com",
-		},
-		"smokingElk": {
-			Login: "smokingElk",
-			Email: "chicherin@exampleemail.com",
-		},
-		"sophistik": {
-			Login: "sophistik",
-			Email: "chicherin@exampleemail.com",
-		},
-		"chicherin": {
-			Login: "chicherin",
-			Email: "chicherin@exampleemail.com",
-		},
-		"root": {
-			Login: "root",
-			Email: "root@exampleemail.com",
-		},
-	}
+	// ErrUnavailable - Островок не ответил после всех повторов или цепь разомкнута
+	ErrUnavailable = errors.New("ostrovok is unavailable")
 )
 
-type Client interface {
-	GetUserByLogin(ctx context.Context, login string) (*model.OstrovokUser, error)
-	GeneratePromocode(ctx context.Context) (string, error)
+// APIError - ответ Островка с кодом, который не имеет смысла повторять
+type APIError struct {
+	StatusCode int
+	Body       string
 }
 
-type client struct {
+func (e *APIError) Error() string {
+	return fmt.Sprintf("ostrovok api error: status %d: %s", e.StatusCode, e.Body)
 }
 
-func NewClient() Client {
-	return &client{}
+type Client interface {
+	GetUserByLogin(ctx context.Context, login string) (*model.OstrovokUser, error)
+	GeneratePromocode(ctx context.Context) (string, error)
 }
 
-func (c *client) GetUserByLogin(_ context.Context, login string) (*model.OstrovokUser, error) {
-	if user, ok := users[login]; ok {
-		return user, nil
+func New(cfg *config.OstrovokConfig) (Client, error) {
+	var client Client
+
+	switch cfg.Mode {
+	case "http":
+		httpClient, err := NewHTTPClient(cfg)
+		if err != nil {
+			return nil, err
+		}
+		client = httpClient
+	case "fake", "":
+		client = NewFakeClient()
+	default:
+		return nil, fmt.Errorf("unknown ostrovok client mode: %s", cfg.Mode)
 	}
-	return nil, ErrUserNotExists
-}
-
-func (c *client) GeneratePromocode(ctx context.Context) (string, error) {
-	const letters = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
-	const digits = "0123456789"
-
-	var result strings.Builder
 
-	result.WriteByte(letters[rand.Intn(len(letters))])
-	result.WriteByte(digits[rand.Intn(len(digits))])
-	result.WriteByte(digits[rand.Intn(len(digits))])
-
-	result.WriteByte('-')
-
-	result.WriteByte(letters[rand.Intn(len(letters))])
-	result.WriteByte(digits[rand.Intn(len(digits))])
-	result.WriteByte(digits[rand.Intn(len(digits))])
+	if cfg.CacheSize > 0 {
+		client = NewCachedClient(client, cfg.CacheSize, cfg.CacheTTL)
+	}
 
-	return result.String(), nil
+	return client, nil
 }
@@ -23,7 +23,7 @@ func NewRepo(db *sqlx.DB) Repo {
 
 const queryGetAnalytics = `
 SELECT 
-	COUNT(DISTINCT CASE WHEN o.status = 'done' 
+	COUNT(DISTINCT CASE WHEN o.status IN ('awarded', 'completed')
     AND o.check_in_at >= DATE_TRUNC('month', CURRENT_DATE)
     AND o.check_in_at < DATE_TRUNC('month', CURRENT_DATE) + INTERVAL '1 month'
     THEN o.id END) as completed_offers,
@@ -8,8 +8,9 @@ import (
 )
 
 type LimitsDTO struct {
-	ParticipantsLimit uint `db:"participants_limit"`
-	ParticipantsCount uint `db:"participants_count"`
+	ParticipantsLimit uint   `db:"participants_limit"`
+	ParticipantsCount uint   `db:"participants_count"`
+	OfferStatus       string `db:"status"`
 }
 
 type UserAppLimitInfoDTO struct {
@@ -24,16 +25,21 @@ type ApplicationDTO struct {
 	Status       string    `db:"status"`
 	ExpirationAt time.Time `db:"expiration_at"`
 	HotelName    string    `db:"name"`
+	CreatedAt    time.Time `db:"created_at"`
+	// Заполняется только в GetApplicationById
+	WaitlistPosition *int `db:"waitlist_position"`
 }
 
 func (d *ApplicationDTO) ToApplicationModel() *application.Application {
 	return &application.Application{
-		Id:           d.Id,
-		UserId:       d.UserId,
-		OfferId:      d.OfferId,
-		Status:       application.ApplicationStatus(d.Status),
-		ExpirationAt: d.ExpirationAt,
-		HotelName:    d.HotelName,
+		Id:               d.Id,
+		UserId:           d.UserId,
+		OfferId:          d.OfferId,
+		Status:           application.ApplicationStatus(d.Status),
+		ExpirationAt:     d.ExpirationAt,
+		HotelName:        d.HotelName,
+		CreatedAt:        d.CreatedAt,
+		WaitlistPosition: d.WaitlistPosition,
 	}
 }
 
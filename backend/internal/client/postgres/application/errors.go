@@ -4,9 +4,9 @@ import "errors"
 
 var (
 	ErrOfferNotExist       = errors.New("offer for this application not exists")
+	ErrOfferNotOpen        = errors.New("offer is not accepting applications")
 	ErrUserNotExist        = errors.New("user for this application not exists")
 	ErrApplicationNotFound = errors.New("application not found")
-	ErrPageNotFound        = errors.New("page not found")
-	ErrParticipantsLimit   = errors.New("all places for participants taken")
 	ErrAppLimit            = errors.New("user application limit reached")
+	ErrNotWithdrawable     = errors.New("application can not be withdrawn")
 )
@@ -11,6 +11,8 @@ import (
 	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq"
 	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/application"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/offer"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/pkg"
 )
 
 const pgForeginKeyErr = "foreign_key_violation"
@@ -31,7 +33,7 @@ func NewApplicationRepo(db *sqlx.DB) ApplicationRepo {
 
 func (r *applicationRepo) CreateApplication(
 	ctx context.Context,
-	application *application.Application,
+	app *application.Application,
 ) error {
 	tx, err := r.db.Beginx()
 
@@ -47,12 +49,13 @@ func (r *applicationRepo) CreateApplication(
 
 	query := `SELECT 
 		o.participants_limit,
-		(SELECT COUNT(*) FROM application a WHERE a.offer_id = o.id) as participants_count
-	FROM offer as o WHERE o.id = $1`
+		(SELECT COUNT(*) FROM application a WHERE a.offer_id = o.id AND a.status IN ($2, $3)) as participants_count,
+		o.status
+	FROM offer as o WHERE o.id = $1 FOR UPDATE`
 
 	var Limits LimitsDTO
 
-	err = tx.GetContext(ctx, &Limits, query, application.OfferId)
+	err = tx.GetContext(ctx, &Limits, query, app.OfferId, application.APPLICATION_CREATED, application.APPLICATION_ACCEPTED)
 
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -62,12 +65,18 @@ func (r *applicationRepo) CreateApplication(
 		return fmt.Errorf("failed to get limits from db: %w", err)
 	}
 
-	if Limits.ParticipantsCount >= Limits.ParticipantsLimit {
-		err = ErrParticipantsLimit
+	// Заявки принимаются только пока оффер опубликован
+	if Limits.OfferStatus != string(offer.StatusPublished) {
+		err = ErrOfferNotOpen
 		return err
 	}
 
-	userAppLimitInfo, err := getUserAppLimitInfo(tx, ctx, application.UserId)
+	// Мест нет - заявка встает в лист ожидания
+	if Limits.ParticipantsCount >= Limits.ParticipantsLimit {
+		app.Status = application.APPLICATION_WAITLISTED
+	}
+
+	userAppLimitInfo, err := getUserAppLimitInfo(tx, ctx, app.UserId)
 	if err != nil {
 		return fmt.Errorf("failed to get user app limit info: %w", err)
 	}
@@ -80,7 +89,7 @@ func (r *applicationRepo) CreateApplication(
 	INSERT INTO application (id, user_id, offer_id, status) VALUES ($1, $2, $3, $4)
 	`
 
-	_, err = tx.ExecContext(ctx, query, application.Id, application.UserId, application.OfferId, application.Status)
+	_, err = tx.ExecContext(ctx, query, app.Id, app.UserId, app.OfferId, app.Status)
 
 	if err != nil {
 		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == pgForeginKeyErr {
@@ -100,47 +109,40 @@ func (r *applicationRepo) CreateApplication(
 func (r *applicationRepo) GetApplications(
 	ctx context.Context,
 	userId uuid.UUID,
-	pageNum, pageSize int,
-) ([]*application.Application, int, error) {
-	offset := pageNum * pageSize
-
-	query := `
-	SELECT a.id, a.user_id, a.offer_id, a.status, o.expiration_at, h.name FROM application as a
-	INNER JOIN offer as o ON a.offer_id = o.id
-	INNER JOIN hotel as h ON o.hotel_id = h.id
-	WHERE a.user_id = $1
-	OFFSET $2
-	LIMIT $3
-	`
-
-	var apps []ApplicationDTO
-
-	err := r.db.SelectContext(ctx, &apps, query, userId, offset, pageSize)
+	limit, offset uint64,
+	after pkg.Opt[application.Cursor],
+) ([]*application.Application, error) {
+	sql := sq.Select(
+		"a.id",
+		"a.user_id",
+		"a.offer_id",
+		"a.status",
+		"a.created_at",
+		"o.expiration_at",
+		"h.name",
+	).From("application as a").
+		Join("offer as o ON a.offer_id = o.id").
+		Join("hotel as h ON o.hotel_id = h.id").
+		Where(sq.Eq{"a.user_id": userId}).
+		OrderBy("a.created_at DESC", "a.id DESC").
+		Limit(limit)
+
+	if cursor, ok := after.Get(); ok {
+		sql = sql.Where("(a.created_at, a.id) < (?, ?)", cursor.CreatedAt, cursor.ID)
+	} else {
+		sql = sql.Offset(offset)
+	}
 
+	query, args, err := sql.PlaceholderFormat(sq.Dollar).ToSql()
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			if pageNum == 0 {
-				return []*application.Application{}, 0, nil
-			}
-
-			return nil, 0, ErrPageNotFound
-		}
-
-		return nil, 0, fmt.Errorf("failed to get all applications from db: %w", err)
+		return nil, fmt.Errorf("failed to build sql query: %w", err)
 	}
 
-	var count int
-	query = "SELECT COUNT(*) FROM application WHERE user_id = $1"
-
-	err = r.db.Get(&count, query, userId)
+	var apps []ApplicationDTO
 
+	err = r.db.SelectContext(ctx, &apps, query, args...)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to get count of applications: %w", err)
-	}
-
-	pagesCount := count / pageSize
-	if count%pageSize != 0 {
-		pagesCount++
+		return nil, fmt.Errorf("failed to get all applications from db: %w", err)
 	}
 
 	res := make([]*application.Application, 0, len(apps))
@@ -149,7 +151,19 @@ func (r *applicationRepo) GetApplications(
 		res = append(res, app.ToApplicationModel())
 	}
 
-	return res, pagesCount, nil
+	return res, nil
+}
+
+func (r *applicationRepo) CountApplications(ctx context.Context, userId uuid.UUID) (int, error) {
+	var count int
+	query := "SELECT COUNT(*) FROM application WHERE user_id = $1"
+
+	err := r.db.GetContext(ctx, &count, query, userId)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get count of applications: %w", err)
+	}
+
+	return count, nil
 }
 
 func (r *applicationRepo) GetApplicationById(
@@ -157,7 +171,12 @@ func (r *applicationRepo) GetApplicationById(
 	applicationId uuid.UUID,
 ) (*application.Application, error) {
 	query := `
-	SELECT a.id, a.user_id, a.offer_id, a.status, o.expiration_at, h.name FROM application as a
+	SELECT a.id, a.user_id, a.offer_id, a.status, a.created_at, o.expiration_at, h.name,
+		CASE WHEN a.status = $2 THEN (
+			SELECT COUNT(*) FROM application w
+			WHERE w.offer_id = a.offer_id AND w.status = $2 AND (w.created_at, w.id) <= (a.created_at, a.id)
+		) END as waitlist_position
+	FROM application as a
 	INNER JOIN offer as o ON a.offer_id = o.id
 	INNER JOIN hotel as h ON o.hotel_id = h.id
 	WHERE a.id = $1
@@ -165,7 +184,7 @@ func (r *applicationRepo) GetApplicationById(
 
 	var app ApplicationDTO
 
-	err := r.db.GetContext(ctx, &app, query, applicationId)
+	err := r.db.GetContext(ctx, &app, query, applicationId, application.APPLICATION_WAITLISTED)
 
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -188,6 +207,7 @@ func (r *applicationRepo) GetByOfferID(
 	SELECT id, user_id, offer_id, status
 	FROM application
 	WHERE offer_id = $1
+	ORDER BY created_at, id
 	`
 
 	var apps []ApplicationDTO
@@ -222,24 +242,29 @@ func getUserAppLimitInfo(s Getter, ctx context.Context, userID uuid.UUID) (*User
 	SELECT u.app_limit, (
     	SELECT COUNT(*)
     	FROM application a
-    	WHERE a.user_id = u.id AND a.status = $2
+    	WHERE a.user_id = u.id AND a.status IN ($2, $3)
 	) AS active_app_count
 	FROM "user" u
 	WHERE u.id = $1;
 	`
 	var userLimitInfo UserAppLimitInfoDTO
 
-	err := s.GetContext(ctx, &userLimitInfo, query, userID, application.APPLICATION_CREATED)
+	// Заявка в листе ожидания тоже занимает лимит пользователя
+	err := s.GetContext(ctx, &userLimitInfo, query, userID, application.APPLICATION_CREATED, application.APPLICATION_WAITLISTED)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get count of user applications: %w", err)
 	}
 	return &userLimitInfo, nil
 }
 
-func (r *applicationRepo) UpdateApplicationStatus(ctx context.Context, application *application.Application) error {
+func (r *applicationRepo) UpdateApplicationStatus(ctx context.Context, app *application.Application) error {
+	if app.Status == application.APPLICATION_DECLINED {
+		return r.release(ctx, app.Id, app.Status, false)
+	}
+
 	query := `UPDATE application SET status = $1 WHERE id = $2`
 
-	_, err := r.db.ExecContext(ctx, query, application.Status, application.Id)
+	_, err := r.db.ExecContext(ctx, query, app.Status, app.Id)
 
 	if err != nil {
 		return err
@@ -248,6 +273,94 @@ func (r *applicationRepo) UpdateApplicationStatus(ctx context.Context, applicati
 	return nil
 }
 
+// Withdraw отзывает заявку до розыгрыша, освободившееся место получает первый из листа ожидания
+func (r *applicationRepo) Withdraw(ctx context.Context, applicationId uuid.UUID) error {
+	return r.release(ctx, applicationId, application.APPLICATION_WITHDRAWN, true)
+}
+
+type releaseDTO struct {
+	Status      string    `db:"status"`
+	OfferID     uuid.UUID `db:"offer_id"`
+	OfferStatus string    `db:"offer_status"`
+}
+
+// release переводит заявку в status и, если оффер еще принимает заявки, продвигает лист ожидания.
+// Блокирует строку оффера, как и CreateApplication, чтобы не разойтись с подсчетом мест
+func (r *applicationRepo) release(ctx context.Context, applicationId uuid.UUID, status application.ApplicationStatus, strict bool) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+	SELECT a.status, a.offer_id, o.status as offer_status
+	FROM application a
+	INNER JOIN offer o ON o.id = a.offer_id
+	WHERE a.id = $1
+	FOR UPDATE OF o
+	`
+
+	var app releaseDTO
+	if err := tx.GetContext(ctx, &app, query, applicationId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrApplicationNotFound
+		}
+		return fmt.Errorf("failed to get application: %w", err)
+	}
+
+	open := app.OfferStatus == string(offer.StatusPublished)
+	if strict {
+		if !open {
+			return ErrOfferNotOpen
+		}
+		if app.Status != string(application.APPLICATION_CREATED) && app.Status != string(application.APPLICATION_WAITLISTED) {
+			return ErrNotWithdrawable
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE application SET status = $1 WHERE id = $2`, status, applicationId); err != nil {
+		return fmt.Errorf("failed to update application status: %w", err)
+	}
+
+	if open {
+		if err := PromoteWaitlisted(ctx, tx, app.OfferID); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit release application: %w", err)
+	}
+
+	return nil
+}
+
+const queryPromoteWaitlisted = `
+	UPDATE application SET status = $2
+	WHERE id IN (
+		SELECT w.id FROM application w
+		WHERE w.offer_id = $1 AND w.status = $3
+		ORDER BY w.created_at, w.id
+		LIMIT GREATEST(
+			(SELECT o.participants_limit FROM offer o WHERE o.id = $1) -
+			(SELECT COUNT(*) FROM application a WHERE a.offer_id = $1 AND a.status IN ($2, $4)),
+			0
+		)
+	)
+`
+
+// PromoteWaitlisted занимает свободные места оффера заявками из листа ожидания в порядке подачи.
+// Строка оффера должна быть заблокирована в tx, иначе места разойдутся с параллельной подачей заявок
+func PromoteWaitlisted(ctx context.Context, tx *sqlx.Tx, offerID uuid.UUID) error {
+	_, err := tx.ExecContext(ctx, queryPromoteWaitlisted, offerID,
+		application.APPLICATION_CREATED, application.APPLICATION_WAITLISTED, application.APPLICATION_ACCEPTED)
+	if err != nil {
+		return fmt.Errorf("failed to promote waitlisted applications: %w", err)
+	}
+	return nil
+}
+
 func (r *applicationRepo) GetByFilter(
 	ctx context.Context,
 	filter *application.Filter,
@@ -257,11 +370,13 @@ func (r *applicationRepo) GetByFilter(
 		"a.user_id",
 		"a.offer_id",
 		"a.status",
+		"a.created_at",
 		"o.expiration_at",
 		"h.name",
 	).From("application as a").
 		Join("offer as o ON a.offer_id = o.id").
-		Join("hotel as h ON o.hotel_id = h.id")
+		Join("hotel as h ON o.hotel_id = h.id").
+		OrderBy("a.created_at DESC", "a.id DESC")
 	if locationID, ok := filter.LocationID.Get(); ok {
 		sql = sql.Where(sq.Eq{"h.location_id": locationID})
 	}
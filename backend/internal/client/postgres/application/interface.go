@@ -5,17 +5,21 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/application"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/pkg"
 )
 
 type ApplicationRepo interface {
 	CreateApplication(ctx context.Context, application *application.Application) error
-	GetApplications(ctx context.Context, userId uuid.UUID, pageNum, pageSize int) ([]*application.Application, int, error)
+	// GetApplications - заявки пользователя от новых к старым, after - курсор вместо offset
+	GetApplications(ctx context.Context, userId uuid.UUID, limit, offset uint64, after pkg.Opt[application.Cursor]) ([]*application.Application, error)
+	CountApplications(ctx context.Context, userId uuid.UUID) (int, error)
 	GetApplicationById(ctx context.Context, applicationId uuid.UUID) (*application.Application, error)
 	GetByOfferID(ctx context.Context, offerID uuid.UUID) ([]*application.Application, error)
 
 	GetUserAppLimitInfo(ctx context.Context, userID uuid.UUID) (*application.UserAppLimitInfo, error)
 
 	UpdateApplicationStatus(ctx context.Context, application *application.Application) error
+	Withdraw(ctx context.Context, applicationId uuid.UUID) error
 
 	GetByFilter(ctx context.Context, filter *application.Filter) ([]*application.Application, error)
 	GetCountByFilter(ctx context.Context, filter *application.Filter) (int, error)
@@ -0,0 +1,11 @@
+package catalog
+
+import "errors"
+
+var (
+	ErrLocationNotFound = errors.New("location with given external id not found")
+	// ErrNameTaken - запись с таким именем уже привязана к другому external id
+	ErrNameTaken = errors.New("name is already taken by another external id")
+	// ErrDeleted - запись с этим external id удалена вручную и из фида не восстанавливается
+	ErrDeleted = errors.New("record with given external id was deleted")
+)
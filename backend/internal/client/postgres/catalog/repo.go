@@ -0,0 +1,200 @@
+package catalog
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	model "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/catalog"
+)
+
+type Repo interface {
+	// Import загружает строки в одной транзакции. Ошибка в строке откатывает только ее,
+	// при dryRun транзакция откатывается целиком и в базе ничего не меняется
+	Import(ctx context.Context, items []model.Item, dryRun bool) (model.Result, error)
+}
+
+type repo struct {
+	db *sqlx.DB
+}
+
+func NewRepo(db *sqlx.DB) Repo {
+	return &repo{db: db}
+}
+
+type catalogRow struct {
+	ID         uuid.UUID      `db:"id"`
+	Name       string         `db:"name"`
+	ExternalID sql.NullString `db:"external_id"`
+	LocationID uuid.NullUUID  `db:"location_id"`
+	Deleted    bool           `db:"deleted"`
+}
+
+// tableFor - имя таблицы берется только из фиксированного набора, поэтому его можно подставлять в запрос
+func tableFor(kind model.Kind) (string, error) {
+	switch kind {
+	case model.KindLocation, model.KindHotel, model.KindRoom:
+		return string(kind), nil
+	default:
+		return "", fmt.Errorf("unknown catalog kind: %s", kind)
+	}
+}
+
+func (r *repo) Import(ctx context.Context, items []model.Item, dryRun bool) (model.Result, error) {
+	result := model.Result{DryRun: dryRun}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return result, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, item := range items {
+		if _, err := tx.ExecContext(ctx, "SAVEPOINT catalog_item"); err != nil {
+			return result, fmt.Errorf("failed to create savepoint: %w", err)
+		}
+
+		action, err := importItem(ctx, tx, item)
+		if err != nil {
+			if _, rbErr := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT catalog_item"); rbErr != nil {
+				return result, fmt.Errorf("failed to rollback to savepoint: %w", rbErr)
+			}
+			result.Skip(item, err.Error())
+			continue
+		}
+
+		result.Add(action)
+	}
+
+	if dryRun {
+		return result, nil
+	}
+
+	if err := tx.Commit(); err != nil {
+		return result, fmt.Errorf("failed to commit import: %w", err)
+	}
+
+	return result, nil
+}
+
+const queryLocationByExternalID = `SELECT id FROM location WHERE external_id = $1 AND deleted_at IS NULL`
+
+func importItem(ctx context.Context, tx *sqlx.Tx, item model.Item) (model.Action, error) {
+	table, err := tableFor(item.Kind)
+	if err != nil {
+		return "", err
+	}
+
+	var locationID uuid.NullUUID
+	if item.Kind == model.KindHotel {
+		err := tx.GetContext(ctx, &locationID.UUID, queryLocationByExternalID, item.LocationExternalID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", ErrLocationNotFound
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to find location: %w", err)
+		}
+		locationID.Valid = true
+	}
+
+	current, err := findRow(ctx, tx, table, item)
+	if err != nil {
+		return "", err
+	}
+
+	if current == nil {
+		return model.ActionCreated, insertRow(ctx, tx, table, item, locationID)
+	}
+
+	if current.Deleted {
+		return "", ErrDeleted
+	}
+
+	if current.ExternalID.Valid && current.ExternalID.String != item.ExternalID {
+		return "", ErrNameTaken
+	}
+
+	if current.ExternalID.Valid && current.Name == item.Name && current.LocationID == locationID {
+		return model.ActionUnchanged, nil
+	}
+
+	return model.ActionUpdated, updateRow(ctx, tx, table, current.ID, item, locationID)
+}
+
+// findRow ищет запись по external id, а если ее нет - по имени среди неудаленных, чтобы подхватить записи,
+// созданные вручную. Номера отелей в фид не входят, по имени сопоставляются только общие номера
+func findRow(ctx context.Context, tx *sqlx.Tx, table string, item model.Item) (*catalogRow, error) {
+	locationColumn := "NULL::uuid AS location_id"
+	if table == string(model.KindHotel) {
+		locationColumn = "location_id"
+	}
+
+	nameScope := ""
+	if table == string(model.KindRoom) {
+		nameScope = "AND hotel_id IS NULL"
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, name, external_id, deleted_at IS NOT NULL AS deleted, %s
+		FROM %s
+		WHERE external_id = $1 OR (name = $2 AND deleted_at IS NULL %s)
+		ORDER BY COALESCE(external_id = $1, FALSE) DESC
+		LIMIT 1
+	`, locationColumn, table, nameScope)
+
+	var row catalogRow
+	err := tx.GetContext(ctx, &row, query, item.ExternalID, item.Name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find %s: %w", table, err)
+	}
+
+	return &row, nil
+}
+
+func insertRow(ctx context.Context, tx *sqlx.Tx, table string, item model.Item, locationID uuid.NullUUID) error {
+	var err error
+	if locationID.Valid {
+		_, err = tx.ExecContext(ctx,
+			fmt.Sprintf(`INSERT INTO %s (id, name, external_id, location_id) VALUES ($1, $2, $3, $4)`, table),
+			uuid.New(), item.Name, item.ExternalID, locationID.UUID,
+		)
+	} else {
+		_, err = tx.ExecContext(ctx,
+			fmt.Sprintf(`INSERT INTO %s (id, name, external_id) VALUES ($1, $2, $3)`, table),
+			uuid.New(), item.Name, item.ExternalID,
+		)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to insert %s: %w", table, err)
+	}
+
+	return nil
+}
+
+func updateRow(ctx context.Context, tx *sqlx.Tx, table string, id uuid.UUID, item model.Item, locationID uuid.NullUUID) error {
+	var err error
+	if locationID.Valid {
+		_, err = tx.ExecContext(ctx,
+			fmt.Sprintf(`UPDATE %s SET name = $2, external_id = $3, location_id = $4 WHERE id = $1`, table),
+			id, item.Name, item.ExternalID, locationID.UUID,
+		)
+	} else {
+		_, err = tx.ExecContext(ctx,
+			fmt.Sprintf(`UPDATE %s SET name = $2, external_id = $3 WHERE id = $1`, table),
+			id, item.Name, item.ExternalID,
+		)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to update %s: %w", table, err)
+	}
+
+	return nil
+}
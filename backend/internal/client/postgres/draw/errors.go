@@ -0,0 +1,7 @@
+package draw
+
+import "errors"
+
+var (
+	ErrNotFound = errors.New("draw not found")
+)
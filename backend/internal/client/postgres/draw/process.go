@@ -0,0 +1,172 @@
+package draw
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	appModel "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/application"
+	model "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/draw"
+	offerModel "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/offer"
+	reportModel "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/report"
+)
+
+type claimRow struct {
+	ID           uuid.UUID         `db:"id"`
+	Status       offerModel.Status `db:"status"`
+	WinnersCount int               `db:"winners_count"`
+	ExpirationAt time.Time         `db:"expiration_at"`
+}
+
+const queryGetCandidates = `
+	SELECT a.id AS application_id, a.user_id, u.rating
+	FROM application a
+	INNER JOIN "user" u ON u.id = a.user_id
+	WHERE a.offer_id = $1 AND a.status = $2
+	ORDER BY a.created_at, a.id
+`
+
+const queryDeclineWaitlisted = `UPDATE application SET status = $1 WHERE offer_id = $2 AND status = $3`
+
+const queryFinishOffer = `UPDATE offer SET status = $3, version = version + 1 WHERE id = $1 AND status = $2`
+
+const queryCreateReport = `
+	INSERT INTO report (id, application_id, expiration_at, status, text)
+	SELECT $1, $2, $3, $4, $5
+	WHERE NOT EXISTS (SELECT 1 FROM report WHERE application_id = $2)
+`
+
+func (r *repo) DrawNext(ctx context.Context, skip []uuid.UUID, drawer Drawer) (uuid.UUID, bool, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return uuid.Nil, false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Оффер, который уже разыгрывает другая реплика, пропускаем, а не ждем.
+	// drawing остается только у офферов, розыгрыш которых прервался до этой транзакции
+	query, args, err := sq.Select("id", "status", "winners_count", "expiration_at").
+		From("offer").
+		Where(sq.Eq{"status": []offerModel.Status{offerModel.StatusPublished, offerModel.StatusDrawing}}).
+		Where(sq.LtOrEq{"expiration_at": time.Now()}).
+		Where(sq.NotEq{"id": skip}).
+		OrderBy("expiration_at", "id").
+		Limit(1).
+		Suffix("FOR UPDATE SKIP LOCKED").
+		PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return uuid.Nil, false, err
+	}
+
+	var offer claimRow
+	if err := tx.GetContext(ctx, &offer, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return uuid.Nil, false, nil
+		}
+		return uuid.Nil, false, fmt.Errorf("failed to claim offer: %w", err)
+	}
+
+	if err := drawOffer(ctx, tx, offer, drawer); err != nil {
+		return offer.ID, true, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return offer.ID, true, fmt.Errorf("failed to commit draw: %w", err)
+	}
+	return offer.ID, true, nil
+}
+
+func drawOffer(ctx context.Context, tx *sqlx.Tx, offer claimRow, drawer Drawer) error {
+	// В розыгрыше участвуют только заявки в пределах лимита, лист ожидания закрывается
+	_, err := tx.ExecContext(ctx, queryDeclineWaitlisted, appModel.APPLICATION_DECLINED, offer.ID, appModel.APPLICATION_WAITLISTED)
+	if err != nil {
+		return fmt.Errorf("failed to decline waitlisted applications: %w", err)
+	}
+
+	winners, found, err := previousWinners(ctx, tx, offer)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		var rows []candidateRow
+		if err := tx.SelectContext(ctx, &rows, queryGetCandidates, offer.ID, appModel.APPLICATION_CREATED); err != nil {
+			return fmt.Errorf("failed to get draw candidates: %w", err)
+		}
+
+		candidates := make([]model.Candidate, len(rows))
+		for i, row := range rows {
+			candidates[i] = model.Candidate{ApplicationID: row.ApplicationID, UserID: row.UserID, Rating: row.Rating}
+		}
+
+		if len(candidates) > 0 {
+			record, err := drawer(offer.ID, offer.WinnersCount, candidates)
+			if err != nil {
+				return err
+			}
+			if err := create(ctx, tx, record); err != nil {
+				return err
+			}
+			winners = record.Winners()
+		}
+	}
+
+	for _, applicationID := range winners {
+		report := reportModel.NewReport(applicationID, offer.ExpirationAt)
+		_, err := tx.ExecContext(ctx, queryCreateReport, report.ID, report.ApplicationID, report.ExpirationAt, report.Status, report.Text)
+		if err != nil {
+			return fmt.Errorf("failed to create report: %w", err)
+		}
+
+		_, err = tx.ExecContext(ctx, `UPDATE application SET status = $1 WHERE id = $2`, appModel.APPLICATION_ACCEPTED, applicationID)
+		if err != nil {
+			return fmt.Errorf("failed to accept application: %w", err)
+		}
+	}
+
+	status := offerModel.StatusAwarded
+	if len(winners) == 0 {
+		status = offerModel.StatusCompleted
+	}
+
+	if !offer.Status.CanTransitionTo(status) {
+		return fmt.Errorf("offer %s can not be drawn: %s -> %s", offer.ID, offer.Status, status)
+	}
+
+	// Оффер заблокирован с момента выбора, но статус все равно сверяем, как и UpdateStatus
+	res, err := tx.ExecContext(ctx, queryFinishOffer, offer.ID, offer.Status, status)
+	if err != nil {
+		return fmt.Errorf("failed to update offer status: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return fmt.Errorf("offer %s status changed during draw", offer.ID)
+	}
+	return nil
+}
+
+// previousWinners - победители сохраненного розыгрыша оффера, застрявшего в drawing.
+// Такой розыгрыш уже мог быть показан участникам, поэтому результат применяем, а не разыгрываем заново
+func previousWinners(ctx context.Context, tx *sqlx.Tx, offer claimRow) ([]uuid.UUID, bool, error) {
+	if offer.Status != offerModel.StatusDrawing {
+		return nil, false, nil
+	}
+
+	draws, err := get(ctx, tx, baseGetSql.Where(sq.Eq{"offer_id": offer.ID}).OrderBy("created_at DESC", "id DESC").Limit(1))
+	if err != nil {
+		return nil, false, err
+	}
+	if len(draws) == 0 {
+		return nil, false, nil
+	}
+	return draws[0].Winners(), true, nil
+}
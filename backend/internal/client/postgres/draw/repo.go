@@ -0,0 +1,158 @@
+package draw
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	model "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/draw"
+)
+
+// Drawer проводит розыгрыш winnersCount мест среди кандидатов оффера и возвращает его запись для аудита
+type Drawer func(offerID uuid.UUID, winnersCount int, candidates []model.Candidate) (model.Draw, error)
+
+type Repo interface {
+	// DrawNext блокирует первый оффер, прием заявок в котором закончился, и разыгрывает его одной транзакцией.
+	// Офферы из skip не берутся. Возвращает id взятого оффера, false - готовых к розыгрышу офферов нет
+	DrawNext(ctx context.Context, skip []uuid.UUID, drawer Drawer) (uuid.UUID, bool, error)
+	GetByID(ctx context.Context, id uuid.UUID) (model.Draw, error)
+	// GetByOfferID - розыгрыши оффера от старых к новым
+	GetByOfferID(ctx context.Context, offerID uuid.UUID) ([]model.Draw, error)
+}
+
+type repo struct {
+	db *sqlx.DB
+}
+
+func NewRepo(db *sqlx.DB) Repo {
+	return &repo{db: db}
+}
+
+type drawRow struct {
+	ID           uuid.UUID `db:"id"`
+	OfferID      uuid.UUID `db:"offer_id"`
+	Algorithm    int       `db:"algorithm_version"`
+	Seed         int64     `db:"seed"`
+	WinnersCount int       `db:"winners_count"`
+	CreatedAt    time.Time `db:"created_at"`
+}
+
+type candidateRow struct {
+	DrawID        uuid.UUID `db:"draw_id"`
+	ApplicationID uuid.UUID `db:"application_id"`
+	UserID        uuid.UUID `db:"user_id"`
+	Rating        int       `db:"rating"`
+	Weight        float64   `db:"weight"`
+	WinnerOrder   *int      `db:"winner_order"`
+}
+
+const queryCreate = `
+	INSERT INTO draw (id, offer_id, algorithm_version, seed, winners_count)
+	VALUES ($1, $2, $3, $4, $5)
+`
+
+func create(ctx context.Context, tx *sqlx.Tx, draw model.Draw) error {
+	_, err := tx.ExecContext(ctx, queryCreate, draw.ID, draw.OfferID, draw.Algorithm, draw.Seed, draw.WinnersCount)
+	if err != nil {
+		return fmt.Errorf("failed to insert draw: %w", err)
+	}
+
+	if len(draw.Candidates) == 0 {
+		return nil
+	}
+
+	insert := sq.Insert("draw_candidate").
+		Columns("draw_id", "position", "application_id", "user_id", "rating", "weight", "winner_order")
+	for i, candidate := range draw.Candidates {
+		insert = insert.Values(
+			draw.ID, i, candidate.ApplicationID, candidate.UserID, candidate.Rating, candidate.Weight, candidate.WinnerOrder,
+		)
+	}
+	query, args, err := insert.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to insert draw candidates: %w", err)
+	}
+	return nil
+}
+
+var baseGetSql = sq.Select("id", "offer_id", "algorithm_version", "seed", "winners_count", "created_at").From("draw")
+
+func (r *repo) GetByID(ctx context.Context, id uuid.UUID) (model.Draw, error) {
+	draws, err := get(ctx, r.db, baseGetSql.Where(sq.Eq{"id": id}))
+	if err != nil {
+		return model.Draw{}, err
+	}
+	if len(draws) == 0 {
+		return model.Draw{}, ErrNotFound
+	}
+	return draws[0], nil
+}
+
+func (r *repo) GetByOfferID(ctx context.Context, offerID uuid.UUID) ([]model.Draw, error) {
+	return get(ctx, r.db, baseGetSql.Where(sq.Eq{"offer_id": offerID}).OrderBy("created_at", "id"))
+}
+
+func get(ctx context.Context, db sqlx.QueryerContext, sql sq.SelectBuilder) ([]model.Draw, error) {
+	query, args, err := sql.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []drawRow
+	if err := sqlx.SelectContext(ctx, db, &rows, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to get draws: %w", err)
+	}
+	if len(rows) == 0 {
+		return []model.Draw{}, nil
+	}
+
+	ids := make([]uuid.UUID, len(rows))
+	for i, row := range rows {
+		ids[i] = row.ID
+	}
+
+	query, args, err = sq.Select("draw_id", "application_id", "user_id", "rating", "weight", "winner_order").
+		From("draw_candidate").
+		Where(sq.Eq{"draw_id": ids}).
+		OrderBy("draw_id", "position").
+		PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []candidateRow
+	if err := sqlx.SelectContext(ctx, db, &candidates, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to get draw candidates: %w", err)
+	}
+
+	byDraw := make(map[uuid.UUID][]model.Candidate, len(rows))
+	for _, c := range candidates {
+		byDraw[c.DrawID] = append(byDraw[c.DrawID], model.Candidate{
+			ApplicationID: c.ApplicationID,
+			UserID:        c.UserID,
+			Rating:        c.Rating,
+			Weight:        c.Weight,
+			WinnerOrder:   c.WinnerOrder,
+		})
+	}
+
+	draws := make([]model.Draw, len(rows))
+	for i, row := range rows {
+		draws[i] = model.Draw{
+			ID:           row.ID,
+			OfferID:      row.OfferID,
+			Algorithm:    row.Algorithm,
+			Seed:         row.Seed,
+			WinnersCount: row.WinnersCount,
+			CreatedAt:    row.CreatedAt,
+			Candidates:   byDraw[row.ID],
+		}
+	}
+	return draws, nil
+}
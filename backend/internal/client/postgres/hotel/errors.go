@@ -0,0 +1,12 @@
+package hotel
+
+import "errors"
+
+var (
+	ErrNotFound  = errors.New("hotel not found")
+	ErrNameTaken = errors.New("hotel with this name already exists")
+	// ErrInUse - у отеля есть офферы в работе или активные шаблоны офферов
+	ErrInUse            = errors.New("hotel has active offers or offer templates")
+	ErrLocationNotFound = errors.New("location not found")
+	ErrImageNotFound    = errors.New("hotel image not found")
+)
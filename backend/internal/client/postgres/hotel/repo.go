@@ -2,16 +2,33 @@ package hotel
 
 import (
 	"context"
+	sql2 "database/sql"
+	"errors"
+	"fmt"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jmoiron/sqlx"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/catalog"
 	model "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/hotel"
+	offerModel "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/offer"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/pkg"
 )
 
 type Repo interface {
 	GetAll(ctx context.Context) ([]model.Hotel, error)
+	// GetPage - limit отелей по имени после курсора, без обложек
+	GetPage(ctx context.Context, limit uint64, after pkg.Opt[catalog.Cursor]) ([]model.Hotel, error)
+	GetByID(ctx context.Context, id uuid.UUID) (model.Hotel, error)
 	Create(ctx context.Context, create model.Create) (uuid.UUID, error)
+	Update(ctx context.Context, update model.Update) error
+	// Delete помечает отель и его номера удаленными, если у отеля нет офферов в работе
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	AddImages(ctx context.Context, hotelID uuid.UUID, images []model.Image) error
+	// DeleteImage удаляет запись об обложке и возвращает ее, чтобы удалить файл из хранилища
+	DeleteImage(ctx context.Context, hotelID, imageID uuid.UUID) (model.Image, error)
 }
 
 type repo struct {
@@ -24,17 +41,32 @@ func NewRepo(sqlClient *sqlx.DB) Repo {
 	}
 }
 
+const (
+	pgUniqueViolation     = "23505"
+	pgForeignKeyViolation = "23503"
+)
+
+var baseGetSql = sq.Select(
+	"h.id AS id",
+	"h.name AS name",
+	"l.id AS location_id",
+	"l.name AS location_name",
+	"COALESCE(h.address, '') AS address",
+	"h.latitude",
+	"h.longitude",
+	"h.stars",
+	"h.description",
+	"h.phone",
+	"h.email",
+	"h.website",
+).From("hotel h").Join("location l ON h.location_id = l.id").Where("h.deleted_at IS NULL")
+
 func (r *repo) GetAll(ctx context.Context) (hotels []model.Hotel, err error) {
-	query, _, err := sq.Select(
-		"h.id AS id",
-		"h.name AS name",
-		"l.id AS location_id",
-		"l.name AS location_name",
-	).From("hotel h").Join("location l ON h.location_id = l.id").ToSql()
+	query, args, err := baseGetSql.OrderBy("h.name").PlaceholderFormat(sq.Dollar).ToSql()
 	if err != nil {
 		return nil, err
 	}
-	err = r.sqlClient.SelectContext(ctx, &hotels, query)
+	err = r.sqlClient.SelectContext(ctx, &hotels, query, args...)
 	if err != nil {
 		//TODO обработка похитрее
 		return nil, err
@@ -42,24 +74,334 @@ func (r *repo) GetAll(ctx context.Context) (hotels []model.Hotel, err error) {
 	return hotels, nil
 }
 
+func (r *repo) GetPage(ctx context.Context, limit uint64, after pkg.Opt[catalog.Cursor]) ([]model.Hotel, error) {
+	sql := baseGetSql.OrderBy("h.name", "h.id").Limit(limit)
+	if cursor, ok := after.Get(); ok {
+		sql = sql.Where("(h.name, h.id) > (?, ?)", cursor.Name, cursor.ID)
+	}
+	query, args, err := sql.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var hotels []model.Hotel
+	if err := r.sqlClient.SelectContext(ctx, &hotels, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to get hotels: %w", err)
+	}
+	return hotels, nil
+}
+
+func (r *repo) GetByID(ctx context.Context, id uuid.UUID) (model.Hotel, error) {
+	query, args, err := baseGetSql.Where(sq.Eq{"h.id": id}).PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return model.Hotel{}, err
+	}
+
+	var hotel model.Hotel
+	err = r.sqlClient.GetContext(ctx, &hotel, query, args...)
+	if errors.Is(err, sql2.ErrNoRows) {
+		return model.Hotel{}, ErrNotFound
+	}
+	if err != nil {
+		return model.Hotel{}, fmt.Errorf("failed to get hotel: %w", err)
+	}
+
+	var images []struct {
+		ID   uuid.UUID `db:"id"`
+		Link string    `db:"s3_link"`
+	}
+	err = r.sqlClient.SelectContext(ctx, &images,
+		`SELECT id, s3_link FROM hotel_image WHERE hotel_id = $1 ORDER BY position`, id)
+	if err != nil {
+		return model.Hotel{}, fmt.Errorf("failed to get hotel images: %w", err)
+	}
+
+	hotel.Images = make([]model.Image, 0, len(images))
+	for _, image := range images {
+		hotel.Images = append(hotel.Images, model.Image{ID: image.ID, Link: image.Link})
+	}
+
+	return hotel, nil
+}
+
 func (r *repo) Create(ctx context.Context, create model.Create) (uuid.UUID, error) {
 	id := uuid.New()
+
+	var latitude, longitude *float64
+	if point, ok := create.Point.Get(); ok {
+		latitude, longitude = &point.Latitude, &point.Longitude
+	}
+
+	var stars *int
+	if value, ok := create.Stars.Get(); ok {
+		stars = &value
+	}
+
 	query, args, err := sq.Insert("hotel").Columns(
 		"id",
 		"name",
 		"location_id",
+		"address",
+		"latitude",
+		"longitude",
+		"stars",
+		"description",
+		"phone",
+		"email",
+		"website",
 	).Values(
 		id,
 		create.Name,
 		create.LocationID,
-	).ToSql()
+		create.Address,
+		latitude,
+		longitude,
+		stars,
+		create.Description,
+		create.Contacts.Phone,
+		create.Contacts.Email,
+		create.Contacts.Website,
+	).PlaceholderFormat(sq.Dollar).ToSql()
 	if err != nil {
 		return uuid.Nil, err
 	}
-	err = r.sqlClient.QueryRowContext(ctx, query, args...).Scan()
+
+	tx, err := r.sqlClient.BeginTxx(ctx, nil)
 	if err != nil {
-		//TODO обработка похитрее
+		return uuid.Nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := lockLocation(ctx, tx, create.LocationID); err != nil {
 		return uuid.Nil, err
 	}
+
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return uuid.Nil, convertError(err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return uuid.Nil, fmt.Errorf("failed to commit hotel: %w", err)
+	}
 	return id, nil
 }
+
+// lockLocation блокирует локацию FOR SHARE до конца транзакции. Удаление локации берет FOR UPDATE,
+// а проверка внешнего ключа после ожидания прошла бы и по уже удаленной локации
+func lockLocation(ctx context.Context, tx *sqlx.Tx, locationID uuid.UUID) error {
+	var locked uuid.UUID
+	err := tx.GetContext(ctx, &locked, `SELECT id FROM location WHERE id = $1 AND deleted_at IS NULL FOR SHARE`, locationID)
+	if errors.Is(err, sql2.ErrNoRows) {
+		return ErrLocationNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to lock location: %w", err)
+	}
+	return nil
+}
+
+func (r *repo) Update(ctx context.Context, update model.Update) error {
+	values := make(map[string]interface{})
+	if name, ok := update.Name.Get(); ok {
+		values["name"] = name
+	}
+	if locationID, ok := update.LocationID.Get(); ok {
+		values["location_id"] = locationID
+	}
+	if address, ok := update.Address.Get(); ok {
+		values["address"] = address
+	}
+	if point, ok := update.Point.Get(); ok {
+		values["latitude"], values["longitude"] = point.Latitude, point.Longitude
+	}
+	if stars, ok := update.Stars.Get(); ok {
+		values["stars"] = stars
+	}
+	if description, ok := update.Description.Get(); ok {
+		values["description"] = description
+	}
+	if phone, ok := update.Phone.Get(); ok {
+		values["phone"] = phone
+	}
+	if email, ok := update.Email.Get(); ok {
+		values["email"] = email
+	}
+	if website, ok := update.Website.Get(); ok {
+		values["website"] = website
+	}
+
+	// Пустая правка только проверяет, что отель существует
+	if len(values) == 0 {
+		_, err := r.GetByID(ctx, update.ID)
+		return err
+	}
+
+	query, args, err := sq.Update("hotel").SetMap(values).
+		Where(sq.Eq{"id": update.ID}).Where("deleted_at IS NULL").
+		PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.sqlClient.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if locationID, ok := update.LocationID.Get(); ok {
+		if err := lockLocation(ctx, tx, locationID); err != nil {
+			return err
+		}
+	}
+
+	res, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return convertError(err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit hotel update: %w", err)
+	}
+	return nil
+}
+
+func (r *repo) Delete(ctx context.Context, id uuid.UUID) error {
+	tx, err := r.sqlClient.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// FOR UPDATE конфликтует с FOR SHARE, которую берет на отель создание и правка оффера,
+	// поэтому оффер не появится между проверкой и удалением
+	var locked uuid.UUID
+	err = tx.GetContext(ctx, &locked, `SELECT id FROM hotel WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`, id)
+	if errors.Is(err, sql2.ErrNoRows) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to lock hotel: %w", err)
+	}
+
+	inUse, err := hasActiveOffers(ctx, tx, sq.Eq{"hotel_id": id})
+	if err != nil {
+		return err
+	}
+	if inUse {
+		return ErrInUse
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE hotel SET deleted_at = NOW() WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("failed to delete hotel: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE room SET deleted_at = NOW() WHERE hotel_id = $1 AND deleted_at IS NULL`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete hotel rooms: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit hotel delete: %w", err)
+	}
+	return nil
+}
+
+// hasActiveOffers - есть офферы в работе или активные шаблоны, подходящие под where
+func hasActiveOffers(ctx context.Context, tx *sqlx.Tx, where sq.Eq) (bool, error) {
+	offers := sq.Select("1").From("offer").Where(where).Where(sq.Eq{"status": offerModel.ActiveStatuses})
+	templates := sq.Select("1").From("offer_template").Where(where).Where("active")
+
+	query, args, err := sq.Select().
+		Column(sq.Expr("EXISTS (?)", offers)).
+		Column(sq.Expr("EXISTS (?)", templates)).
+		PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return false, err
+	}
+
+	var hasOffers, hasTemplates bool
+	if err := tx.QueryRowContext(ctx, query, args...).Scan(&hasOffers, &hasTemplates); err != nil {
+		return false, fmt.Errorf("failed to check active offers: %w", err)
+	}
+	return hasOffers || hasTemplates, nil
+}
+
+func (r *repo) AddImages(ctx context.Context, hotelID uuid.UUID, images []model.Image) error {
+	tx, err := r.sqlClient.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Блокировка отеля упорядочивает параллельные загрузки, чтобы не выдать одинаковые позиции
+	var next int
+	err = tx.GetContext(ctx, &next, `
+		SELECT COALESCE((SELECT MAX(position) + 1 FROM hotel_image WHERE hotel_id = h.id), 0)
+		FROM hotel h WHERE h.id = $1 AND h.deleted_at IS NULL FOR UPDATE
+	`, hotelID)
+	if errors.Is(err, sql2.ErrNoRows) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to lock hotel: %w", err)
+	}
+
+	if len(images) == 0 {
+		return nil
+	}
+
+	sql := sq.Insert("hotel_image").Columns("id", "hotel_id", "s3_link", "position")
+	for i, image := range images {
+		sql = sql.Values(image.ID, hotelID, image.Link, next+i)
+	}
+	query, args, err := sql.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to insert hotel images: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit hotel images: %w", err)
+	}
+	return nil
+}
+
+func (r *repo) DeleteImage(ctx context.Context, hotelID, imageID uuid.UUID) (model.Image, error) {
+	var image struct {
+		ID   uuid.UUID `db:"id"`
+		Link string    `db:"s3_link"`
+	}
+	err := r.sqlClient.GetContext(ctx, &image,
+		`DELETE FROM hotel_image WHERE id = $1 AND hotel_id = $2 RETURNING id, s3_link`, imageID, hotelID)
+	if errors.Is(err, sql2.ErrNoRows) {
+		return model.Image{}, ErrImageNotFound
+	}
+	if err != nil {
+		return model.Image{}, fmt.Errorf("failed to delete hotel image: %w", err)
+	}
+	return model.Image{ID: image.ID, Link: image.Link}, nil
+}
+
+func convertError(err error) error {
+	var pgErr *pgconn.PgError
+	switch {
+	case errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation:
+		return ErrNameTaken
+	case errors.As(err, &pgErr) && pgErr.Code == pgForeignKeyViolation:
+		return ErrLocationNotFound
+	default:
+		return err
+	}
+}
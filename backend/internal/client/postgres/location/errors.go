@@ -0,0 +1,10 @@
+package location
+
+import "errors"
+
+var (
+	ErrNotFound  = errors.New("location not found")
+	ErrNameTaken = errors.New("location with this name already exists")
+	// ErrHasHotels - в локации остались неудаленные отели
+	ErrHasHotels = errors.New("location has hotels")
+)
@@ -2,16 +2,28 @@ package location
 
 import (
 	"context"
+	sql2 "database/sql"
+	"errors"
+	"fmt"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jmoiron/sqlx"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/catalog"
 	model "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/location"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/pkg"
 )
 
 type Repo interface {
 	GetAll(ctx context.Context) ([]model.Location, error)
+	// GetPage - limit локаций по имени после курсора
+	GetPage(ctx context.Context, limit uint64, after pkg.Opt[catalog.Cursor]) ([]model.Location, error)
+	GetByID(ctx context.Context, id uuid.UUID) (model.Location, error)
 	Create(ctx context.Context, name string) (uuid.UUID, error)
+	Rename(ctx context.Context, id uuid.UUID, name string) error
+	// Delete помечает локацию удаленной, если в ней не осталось отелей
+	Delete(ctx context.Context, id uuid.UUID) error
 }
 
 type repo struct {
@@ -24,9 +36,13 @@ func NewRepo(sqlClient *sqlx.DB) Repo {
 	}
 }
 
+const pgUniqueViolation = "23505"
+
+var baseGetSql = sq.Select("id", "name").From("location").Where("deleted_at IS NULL")
+
 func (r *repo) GetAll(ctx context.Context) ([]model.Location, error) {
 	var locations []model.Location
-	query, _, err := sq.Select("id", "name").From("location").ToSql()
+	query, _, err := baseGetSql.OrderBy("name").ToSql()
 	if err != nil {
 		return nil, err
 	}
@@ -38,6 +54,40 @@ func (r *repo) GetAll(ctx context.Context) ([]model.Location, error) {
 	return locations, nil
 }
 
+func (r *repo) GetPage(ctx context.Context, limit uint64, after pkg.Opt[catalog.Cursor]) ([]model.Location, error) {
+	sql := baseGetSql.OrderBy("name", "id").Limit(limit)
+	if cursor, ok := after.Get(); ok {
+		sql = sql.Where("(name, id) > (?, ?)", cursor.Name, cursor.ID)
+	}
+	query, args, err := sql.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var locations []model.Location
+	if err := r.sqlClient.SelectContext(ctx, &locations, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to get locations: %w", err)
+	}
+	return locations, nil
+}
+
+func (r *repo) GetByID(ctx context.Context, id uuid.UUID) (model.Location, error) {
+	query, args, err := baseGetSql.Where(sq.Eq{"id": id}).PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return model.Location{}, err
+	}
+
+	var location model.Location
+	err = r.sqlClient.GetContext(ctx, &location, query, args...)
+	if errors.Is(err, sql2.ErrNoRows) {
+		return model.Location{}, ErrNotFound
+	}
+	if err != nil {
+		return model.Location{}, fmt.Errorf("failed to get location: %w", err)
+	}
+	return location, nil
+}
+
 func (r *repo) Create(ctx context.Context, name string) (uuid.UUID, error) {
 	id := uuid.New()
 	query, args, err := sq.Insert("location").Columns(
@@ -46,15 +96,76 @@ func (r *repo) Create(ctx context.Context, name string) (uuid.UUID, error) {
 	).Values(
 		id,
 		name,
-	).ToSql()
+	).PlaceholderFormat(sq.Dollar).ToSql()
 	if err != nil {
-		//TODO обработка похитрее
 		return uuid.Nil, err
 	}
-	err = r.sqlClient.QueryRowContext(ctx, query, args...).Scan()
+	_, err = r.sqlClient.ExecContext(ctx, query, args...)
 	if err != nil {
-		//TODO обработка похитрее
-		return uuid.Nil, err
+		return uuid.Nil, convertError(err)
 	}
 	return id, nil
 }
+
+func (r *repo) Rename(ctx context.Context, id uuid.UUID, name string) error {
+	res, err := r.sqlClient.ExecContext(ctx,
+		`UPDATE location SET name = $2 WHERE id = $1 AND deleted_at IS NULL`, id, name)
+	if err != nil {
+		return convertError(err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *repo) Delete(ctx context.Context, id uuid.UUID) error {
+	tx, err := r.sqlClient.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// FOR UPDATE конфликтует с FOR SHARE, которую берет на локацию создание и правка отеля,
+	// поэтому отель не появится между проверкой и удалением
+	var locked uuid.UUID
+	err = tx.GetContext(ctx, &locked, `SELECT id FROM location WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`, id)
+	if errors.Is(err, sql2.ErrNoRows) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to lock location: %w", err)
+	}
+
+	var hasHotels bool
+	err = tx.GetContext(ctx, &hasHotels,
+		`SELECT EXISTS (SELECT 1 FROM hotel WHERE location_id = $1 AND deleted_at IS NULL)`, id)
+	if err != nil {
+		return fmt.Errorf("failed to check location hotels: %w", err)
+	}
+	if hasHotels {
+		return ErrHasHotels
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE location SET deleted_at = NOW() WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("failed to delete location: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit location delete: %w", err)
+	}
+	return nil
+}
+
+func convertError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+		return ErrNameTaken
+	}
+	return err
+}
@@ -0,0 +1,117 @@
+package loginattempt
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	model "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/loginattempt"
+)
+
+type Repo interface {
+	// GetLockedUntil возвращает самую позднюю действующую блокировку среди ключей
+	GetLockedUntil(ctx context.Context, keys ...model.Key) (*time.Time, error)
+	// RegisterFailure увеличивает счетчик ошибок ключа и, если порог политики превышен, блокирует его.
+	// Возвращает время окончания блокировки или nil.
+	RegisterFailure(ctx context.Context, key model.Key, policy model.Policy, ip string) (*time.Time, error)
+	Reset(ctx context.Context, key model.Key) error
+}
+
+type repo struct {
+	db *sqlx.DB
+}
+
+func NewRepo(db *sqlx.DB) Repo {
+	return &repo{db: db}
+}
+
+const queryGetLockedUntil = `
+	SELECT MAX(locked_until)
+	FROM login_attempt
+	WHERE kind = $1 AND key = $2 AND locked_until > NOW()
+`
+
+func (r *repo) GetLockedUntil(ctx context.Context, keys ...model.Key) (*time.Time, error) {
+	var latest *time.Time
+
+	for _, key := range keys {
+		var lockedUntil sql.NullTime
+
+		if err := r.db.GetContext(ctx, &lockedUntil, queryGetLockedUntil, key.Kind, key.Value); err != nil {
+			return nil, fmt.Errorf("failed to get login lock: %w", err)
+		}
+
+		if lockedUntil.Valid && (latest == nil || lockedUntil.Time.After(*latest)) {
+			latest = &lockedUntil.Time
+		}
+	}
+
+	return latest, nil
+}
+
+// Счетчик сбрасывается, если с прошлой ошибки и с конца блокировки прошло больше окна политики.
+// Окно считаем от конца блокировки, иначе блокировка длиннее окна обнуляла бы счетчик и не росла
+const queryRegisterFailure = `
+	INSERT INTO login_attempt (kind, key, failures, last_failure_at)
+	VALUES ($1, $2, 1, NOW())
+	ON CONFLICT (kind, key) DO UPDATE SET
+		failures = CASE
+			WHEN GREATEST(login_attempt.last_failure_at, login_attempt.locked_until) < NOW() - make_interval(secs => $3) THEN 1
+			ELSE login_attempt.failures + 1
+		END,
+		last_failure_at = NOW()
+	RETURNING failures
+`
+
+const queryLock = `UPDATE login_attempt SET locked_until = $3 WHERE kind = $1 AND key = $2`
+
+const queryAuditLockout = `
+	INSERT INTO login_lockout (kind, key, failures, ip, locked_until)
+	VALUES ($1, $2, $3, $4, $5)
+`
+
+func (r *repo) RegisterFailure(ctx context.Context, key model.Key, policy model.Policy, ip string) (*time.Time, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var failures int
+	if err := tx.GetContext(ctx, &failures, queryRegisterFailure, key.Kind, key.Value, policy.Window.Seconds()); err != nil {
+		return nil, fmt.Errorf("failed to register login failure: %w", err)
+	}
+
+	var lockedUntil *time.Time
+
+	if lockout := policy.LockoutFor(failures); lockout > 0 {
+		until := time.Now().Add(lockout)
+		lockedUntil = &until
+
+		if _, err := tx.ExecContext(ctx, queryLock, key.Kind, key.Value, until); err != nil {
+			return nil, fmt.Errorf("failed to lock login: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, queryAuditLockout, key.Kind, key.Value, failures, ip, until); err != nil {
+			return nil, fmt.Errorf("failed to audit login lockout: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit login failure: %w", err)
+	}
+
+	return lockedUntil, nil
+}
+
+const queryReset = `DELETE FROM login_attempt WHERE kind = $1 AND key = $2`
+
+func (r *repo) Reset(ctx context.Context, key model.Key) error {
+	if _, err := r.db.ExecContext(ctx, queryReset, key.Kind, key.Value); err != nil {
+		return fmt.Errorf("failed to reset login attempts: %w", err)
+	}
+
+	return nil
+}
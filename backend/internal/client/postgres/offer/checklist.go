@@ -0,0 +1,67 @@
+package offer
+
+import (
+	"context"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	model "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/offer"
+)
+
+type checklistItemRow struct {
+	ID       uuid.UUID `db:"id"`
+	Type     string    `db:"type"`
+	Title    string    `db:"title"`
+	Required bool      `db:"required"`
+}
+
+const queryGetChecklist = `
+	SELECT id, type, title, required FROM offer_checklist_item
+	WHERE offer_id = $1
+	ORDER BY position
+`
+
+func (r *repo) GetChecklist(ctx context.Context, offerID uuid.UUID) ([]model.ChecklistItem, error) {
+	var rows []checklistItemRow
+	if err := r.sqlClient.SelectContext(ctx, &rows, queryGetChecklist, offerID); err != nil {
+		return nil, fmt.Errorf("failed to get checklist: %w", err)
+	}
+
+	items := make([]model.ChecklistItem, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, model.ChecklistItem{
+			ID:       row.ID,
+			Type:     model.ChecklistItemType(row.Type),
+			Title:    row.Title,
+			Required: row.Required,
+		})
+	}
+	return items, nil
+}
+
+// replaceChecklist удаляет старые пункты оффера вместе с ответами на них и записывает новые в заданном порядке
+func replaceChecklist(ctx context.Context, tx sqlx.ExecerContext, offerID uuid.UUID, items []model.ChecklistItem) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM offer_checklist_item WHERE offer_id = $1`, offerID); err != nil {
+		return fmt.Errorf("failed to delete checklist: %w", err)
+	}
+
+	if len(items) == 0 {
+		return nil
+	}
+
+	sql := sq.Insert("offer_checklist_item").Columns("id", "offer_id", "position", "type", "title", "required")
+	for i, item := range items {
+		sql = sql.Values(item.ID, offerID, i, item.Type, item.Title, item.Required)
+	}
+	query, args, err := sql.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to insert checklist: %w", err)
+	}
+	return nil
+}
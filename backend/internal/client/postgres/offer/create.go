@@ -4,10 +4,13 @@ import (
 	"context"
 	sql2 "database/sql"
 	"errors"
+	"fmt"
 	"log"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jmoiron/sqlx"
 	model "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/offer"
 )
 
@@ -16,7 +19,66 @@ var (
 )
 
 func (r *repo) Create(ctx context.Context, id uuid.UUID, create model.Create) error {
-	query, args, err := sq.Insert("offer").Columns(
+	query, args, err := insertSql(id, create)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.sqlClient.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := lockHotelAndRoom(ctx, tx, create.HotelID, create.RoomID); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, query, args...)
+	switch {
+	case errors.Is(err, sql2.ErrNoRows):
+		log.Printf("no user with id %d\n", id)
+		return ErrNotFroundUser
+	case err != nil:
+		return err
+	default:
+		log.Printf("create user with %v\n", id)
+	}
+
+	if err := replaceChecklist(ctx, tx, id, create.Checklist); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit offer: %w", err)
+	}
+	return nil
+}
+
+const (
+	queryLockHotel = `SELECT id FROM hotel WHERE id = $1 AND deleted_at IS NULL FOR SHARE`
+	queryLockRoom  = `SELECT id FROM room WHERE id = $1 AND deleted_at IS NULL AND (hotel_id IS NULL OR hotel_id = $2) FOR SHARE`
+)
+
+// lockHotelAndRoom блокирует отель и номер оффера до конца транзакции. Удаление берет FOR UPDATE,
+// поэтому либо дождется оффера и увидит его, либо успеет раньше, и тогда здесь отель или номер уже не найдутся
+func lockHotelAndRoom(ctx context.Context, tx *sqlx.Tx, hotelID, roomID uuid.UUID) error {
+	var locked uuid.UUID
+	err := tx.GetContext(ctx, &locked, queryLockHotel, hotelID)
+	if err == nil {
+		err = tx.GetContext(ctx, &locked, queryLockRoom, roomID, hotelID)
+	}
+	if errors.Is(err, sql2.ErrNoRows) {
+		return ErrHotelOrRoomNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to lock hotel and room: %w", err)
+	}
+	return nil
+}
+
+func insertSql(id uuid.UUID, create model.Create) (string, []interface{}, error) {
+	return sq.Insert("offer").Columns(
 		"id",
 		"hotel_id",
 		"room_id",
@@ -25,6 +87,8 @@ func (r *repo) Create(ctx context.Context, id uuid.UUID, create model.Create) er
 		"expiration_at",
 		"task",
 		"participants_limit",
+		"winners_count",
+		"status",
 	).Values(
 		id,
 		create.HotelID,
@@ -34,20 +98,74 @@ func (r *repo) Create(ctx context.Context, id uuid.UUID, create model.Create) er
 		create.ExpirationAT,
 		create.Task,
 		create.ParticipantsLimit,
+		create.WinnersCount,
+		create.Status,
 	).PlaceholderFormat(sq.Dollar).ToSql()
+}
 
+const (
+	pgUniqueViolation     = "23505"
+	pgForeignKeyViolation = "23503"
+)
+
+// CreateBatch создает офферы в одной транзакции. Каждая строка пишется под своей точкой сохранения,
+// чтобы собрать ошибки по всем строкам, а не только по первой. При любой ошибке или dryRun транзакция откатывается
+func (r *repo) CreateBatch(ctx context.Context, ids []uuid.UUID, creates []model.Create, dryRun bool) error {
+	tx, err := r.sqlClient.BeginTxx(ctx, nil)
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
-	_, err = r.sqlClient.ExecContext(ctx, query, args...)
-	switch {
-	case errors.Is(err, sql2.ErrNoRows):
-		log.Printf("no user with id %d\n", id)
-		return ErrNotFroundUser
-	case err != nil:
-		return err
-	default:
-		log.Printf("create user with %v\n", id)
+	defer tx.Rollback()
+
+	failed := make(map[int]error)
+	for i, create := range creates {
+		query, args, err := insertSql(ids[i], create)
+		if err != nil {
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx, "SAVEPOINT offer_row"); err != nil {
+			return fmt.Errorf("failed to create savepoint: %w", err)
+		}
+
+		err = lockHotelAndRoom(ctx, tx, create.HotelID, create.RoomID)
+		if err == nil {
+			_, err = tx.ExecContext(ctx, query, args...)
+		}
+		if err == nil {
+			err = replaceChecklist(ctx, tx, ids[i], create.Checklist)
+		}
+		if err == nil {
+			continue
+		}
+
+		var pgErr *pgconn.PgError
+		switch {
+		case errors.Is(err, ErrHotelOrRoomNotFound):
+			failed[i] = err
+		case errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation:
+			failed[i] = ErrOfferExists
+		case errors.As(err, &pgErr) && pgErr.Code == pgForeignKeyViolation:
+			failed[i] = ErrHotelOrRoomNotFound
+		default:
+			return fmt.Errorf("failed to create offer: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT offer_row"); err != nil {
+			return fmt.Errorf("failed to rollback to savepoint: %w", err)
+		}
+	}
+
+	if len(failed) > 0 {
+		return &BatchError{Rows: failed}
+	}
+
+	if dryRun {
+		return nil
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit offers: %w", err)
 	}
 	return nil
 }
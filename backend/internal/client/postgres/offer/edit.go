@@ -2,15 +2,23 @@ package offer
 
 import (
 	"context"
+	sql2 "database/sql"
+	"errors"
+	"fmt"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
 
 	sq "github.com/Masterminds/squirrel"
+	applicationRepo "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/application"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/application"
 	model "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/offer"
 )
 
-func (r *repo) Edit(ctx context.Context, edit model.Edit) error {
-	sql := sq.Update("offer")
+// Edit применяет правку, только если версия оффера совпадает с edit.Version и розыгрыш еще не начался.
+// Возвращает новую версию
+func (r *repo) Edit(ctx context.Context, edit model.Edit) (int, error) {
+	sql := sq.Update("offer").Set("version", sq.Expr("version + 1"))
 	if task, ok := edit.Task.Get(); ok {
 		sql = sql.Set("task", task)
 	}
@@ -21,33 +29,173 @@ func (r *repo) Edit(ctx context.Context, edit model.Edit) error {
 		sql = sql.Set("hotel_id", hotelID)
 	}
 	if checkIn, ok := edit.CheckIn.Get(); ok {
-		sql = sql.Set("check_in", checkIn)
+		sql = sql.Set("check_in_at", checkIn)
 	}
 	if checkOut, ok := edit.CheckOut.Get(); ok {
-		sql = sql.Set("check_out", checkOut)
+		sql = sql.Set("check_out_at", checkOut)
 	}
 	if expirationAt, ok := edit.ExpirationAT.Get(); ok {
 		sql = sql.Set("expiration_at", expirationAt)
 	}
-	query, args, err := sql.Where(sq.Eq{"id": edit.OfferID}).PlaceholderFormat(sq.Dollar).ToSql()
+	if participantsLimit, ok := edit.ParticipantsLimit.Get(); ok {
+		sql = sql.Set("participants_limit", participantsLimit)
+	}
+	if winnersCount, ok := edit.WinnersCount.Get(); ok {
+		sql = sql.Set("winners_count", winnersCount)
+	}
+	query, args, err := sql.Where(sq.Eq{
+		"id":      edit.OfferID,
+		"version": edit.Version,
+		"status":  []model.Status{model.StatusDraft, model.StatusPublished},
+	}).Suffix("RETURNING version, status, hotel_id, room_id").PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	tx, err := r.sqlClient.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var version int
+	var status model.Status
+	var hotelID, roomID uuid.UUID
+	err = tx.QueryRowContext(ctx, query, args...).Scan(&version, &status, &hotelID, &roomID)
+	var pgErr *pgconn.PgError
+	switch {
+	case errors.Is(err, sql2.ErrNoRows):
+		return 0, ErrStatusChanged
+	case errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation:
+		return 0, ErrOfferExists
+	case err != nil:
+		return 0, fmt.Errorf("failed to edit offer: %w", err)
+	}
+
+	_, hotelSet := edit.HotelID.Get()
+	_, roomSet := edit.RoomID.Get()
+	if hotelSet || roomSet {
+		if err := lockHotelAndRoom(ctx, tx, hotelID, roomID); err != nil {
+			return 0, err
+		}
+	}
+
+	// Новые места достаются листу ожидания, а не тем, кто подаст заявку после правки.
+	// UPDATE уже держит строку оффера, поэтому подача заявок ждет конца транзакции
+	if _, ok := edit.ParticipantsLimit.Get(); ok && status == model.StatusPublished {
+		if err := applicationRepo.PromoteWaitlisted(ctx, tx, edit.OfferID); err != nil {
+			return 0, err
+		}
+	}
+
+	if checklist, ok := edit.Checklist.Get(); ok {
+		if err := replaceChecklist(ctx, tx, edit.OfferID, checklist); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit offer edit: %w", err)
+	}
+	return version, nil
+}
+
+// UpdateStatus меняет статус, только если оффер все еще в статусе from.
+// Так воркер и админ не перезапишут изменения друг друга. Версия тоже растет, чтобы устарели открытые правки
+func (r *repo) UpdateStatus(ctx context.Context, offerID uuid.UUID, from, to model.Status) error {
+	query, args, err := sq.Update("offer").
+		Set("status", to).
+		Set("version", sq.Expr("version + 1")).
+		Where(sq.Eq{"id": offerID, "status": from}).PlaceholderFormat(sq.Dollar).ToSql()
 	if err != nil {
 		return err
 	}
-	err = r.sqlClient.QueryRowContext(ctx, query, args...).Scan()
+
+	res, err := r.sqlClient.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
 	if err != nil {
 		return err
 	}
+
+	if affected == 0 {
+		return ErrStatusChanged
+	}
 	return nil
 }
 
-func (r *repo) EditStatus(ctx context.Context, offerID uuid.UUID, status string) error {
-	query, args, err := sq.Update("offer").
-		Set("status", status).
-		Where(sq.Eq{"id": offerID}).PlaceholderFormat(sq.Dollar).ToSql()
+const queryCancel = `UPDATE offer SET status = $3, version = version + 1 WHERE id = $1 AND status = $2`
+
+const queryDeclinePendingApplications = `
+	UPDATE application SET status = $2
+	WHERE offer_id = $1 AND status IN ($3, $4)
+	RETURNING user_id
+`
+
+// Cancel отменяет оффер и отклоняет все необработанные заявки на него.
+// Возвращает пользователей, чьи заявки были отклонены
+func (r *repo) Cancel(ctx context.Context, offerID uuid.UUID, from model.Status) ([]uuid.UUID, error) {
+	tx, err := r.sqlClient.BeginTxx(ctx, nil)
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, queryCancel, offerID, from, model.StatusCancelled)
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel offer: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+
+	if affected == 0 {
+		return nil, ErrStatusChanged
 	}
 
-	_, err = r.sqlClient.ExecContext(ctx, query, args...)
-	return err
+	var userIDs []uuid.UUID
+	err = tx.SelectContext(ctx, &userIDs, queryDeclinePendingApplications,
+		offerID, application.APPLICATION_DECLINED, application.APPLICATION_CREATED, application.APPLICATION_WAITLISTED)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decline applications: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit cancel: %w", err)
+	}
+
+	return userIDs, nil
+}
+
+const queryGetIDByReportID = `
+	SELECT a.offer_id FROM report r
+	JOIN application a ON a.id = r.application_id
+	WHERE r.id = $1
+`
+
+func (r *repo) GetIDByReportID(ctx context.Context, reportID uuid.UUID) (uuid.UUID, error) {
+	var id uuid.UUID
+	err := r.sqlClient.GetContext(ctx, &id, queryGetIDByReportID, reportID)
+	if errors.Is(err, sql2.ErrNoRows) {
+		return uuid.Nil, ErrOfferNotFound
+	}
+	return id, err
+}
+
+const queryCountUnreviewedReports = `
+	SELECT COUNT(*) FROM report r
+	JOIN application a ON a.id = r.application_id
+	WHERE a.offer_id = $1 AND r.status NOT IN ('accepted', 'declined')
+`
+
+// CountUnreviewedReports - сколько отчетов победителей оффера еще ждут проверки
+func (r *repo) CountUnreviewedReports(ctx context.Context, offerID uuid.UUID) (int, error) {
+	var count int
+	err := r.sqlClient.GetContext(ctx, &count, queryCountUnreviewedReports, offerID)
+	return count, err
 }
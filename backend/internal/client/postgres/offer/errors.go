@@ -0,0 +1,37 @@
+package offer
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+var (
+	ErrOfferNotFound = errors.New("offer not found")
+	// ErrStatusChanged - статус или версию оффера успели изменить параллельно
+	ErrStatusChanged = errors.New("offer has been changed concurrently")
+	// ErrOfferExists - оффер на этот отель, номер и даты уже есть (uq_offer)
+	ErrOfferExists         = errors.New("offer for this hotel, room and dates already exists")
+	ErrHotelOrRoomNotFound = errors.New("hotel or room not found")
+)
+
+// BatchError - строки пакета, которые не удалось сохранить, по индексу в пакете.
+// Если она вернулась, не сохранена ни одна строка
+type BatchError struct {
+	Rows map[int]error
+}
+
+func (e *BatchError) Error() string {
+	indexes := make([]int, 0, len(e.Rows))
+	for i := range e.Rows {
+		indexes = append(indexes, i)
+	}
+	sort.Ints(indexes)
+
+	reasons := make([]string, 0, len(indexes))
+	for _, i := range indexes {
+		reasons = append(reasons, fmt.Sprintf("row %d: %v", i, e.Rows[i]))
+	}
+	return "failed to create offers: " + strings.Join(reasons, "; ")
+}
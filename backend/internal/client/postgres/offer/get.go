@@ -2,17 +2,29 @@ package offer
 
 import (
 	"context"
+	"fmt"
+	"math"
 	"time"
 
 	sq "github.com/Masterminds/squirrel"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/hotel"
 	model "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/offer"
 )
 
+// participantsCountSql - одно выражение и для колонки, и для фильтра/сортировки по свободным местам.
+// Лист ожидания, отклоненные и отозванные заявки места не занимают
+const participantsCountSql = "(SELECT COUNT(*) FROM application a WHERE a.offer_id = o.id AND a.status IN ('__app_created', '__app_accepted'))"
+
+const taskSearchSql = "to_tsvector('russian', COALESCE(o.task, '')) @@ websearch_to_tsquery('russian', ?)"
+
 var (
 	baseGetSql = sq.Select(
 		"o.id as offer_id",
 		"o.hotel_id as hotel_id",
 		"h.name as hotel_name",
+		"COALESCE(h.address, '') as hotel_address",
+		"h.latitude as hotel_latitude",
+		"h.longitude as hotel_longitude",
 		"o.room_id as room_id",
 		"r.name as room_name",
 		"o.check_in_at",
@@ -21,25 +33,142 @@ var (
 		"o.task",
 		"o.status",
 		"o.participants_limit",
-		"(SELECT COUNT(*) FROM application a WHERE a.offer_id = o.id) as participants_count",
+		"o.version",
+		"o.winners_count",
+		participantsCountSql+" as participants_count",
+		"(SELECT COUNT(*) FROM application a WHERE a.offer_id = o.id AND a.status = '__app_accepted') as awarded_count",
 	).From("offer o").
 		Join("hotel h ON o.hotel_id = h.id").
 		Join("room r ON o.room_id = r.id")
+
+	baseCountSql = sq.Select("COUNT(*)").
+			From("offer o").
+			Join("hotel h ON o.hotel_id = h.id").
+			Join("room r ON o.room_id = r.id")
+
+	sortColumns = map[model.Sort]string{
+		model.SortExpiration:     "o.expiration_at",
+		model.SortCheckIn:        "o.check_in_at",
+		model.SortRemainingSlots: "(o.participants_limit - " + participantsCountSql + ")",
+	}
+
+	// sortTypes - типы ключей сортировки: значение в курсоре хранится строкой
+	sortTypes = map[model.Sort]string{
+		model.SortExpiration:     "timestamptz",
+		model.SortCheckIn:        "timestamptz",
+		model.SortRemainingSlots: "bigint",
+		model.SortDistance:       "float8",
+	}
 )
 
+// distanceSql - расстояние от точки до отеля в километрах по формуле гаверсинуса, 6371 - радиус Земли.
+// LEAST - для почти противоположных точек округление дает под корнем чуть больше 1, и ASIN падает
+const distanceSql = `(2 * 6371 * ASIN(LEAST(1, SQRT(
+	POWER(SIN(RADIANS(h.latitude - ?) / 2), 2) +
+	COS(RADIANS(?)) * COS(RADIANS(h.latitude)) * POWER(SIN(RADIANS(h.longitude - ?) / 2), 2)
+))))`
+
+func distanceExpr(point hotel.Point, suffix string, args ...interface{}) sq.Sqlizer {
+	return sq.Expr(distanceSql+suffix, append([]interface{}{point.Latitude, point.Latitude, point.Longitude}, args...)...)
+}
+
+// boundingBox грубо отсекает отели по индексу до точного расчета расстояния.
+// Долготу не ограничиваем у полюсов и рядом с 180-м меридианом, там прямоугольник вырождается
+func boundingBox(point hotel.Point, radiusKm float64) sq.Sqlizer {
+	const kmPerDegree = 111.0
+
+	latDelta := radiusKm / kmPerDegree
+	box := sq.And{
+		sq.GtOrEq{"h.latitude": point.Latitude - latDelta},
+		sq.LtOrEq{"h.latitude": point.Latitude + latDelta},
+	}
+
+	cos := math.Cos(point.Latitude * math.Pi / 180)
+	if cos < 0.01 {
+		return box
+	}
+
+	lonDelta := radiusKm / (kmPerDegree * cos)
+	if point.Longitude-lonDelta < -180 || point.Longitude+lonDelta > 180 {
+		return box
+	}
+
+	return append(box,
+		sq.GtOrEq{"h.longitude": point.Longitude - lonDelta},
+		sq.LtOrEq{"h.longitude": point.Longitude + lonDelta},
+	)
+}
+
+// applyFilter - общие условия для выборки и подсчета, чтобы число страниц совпадало с результатом
+func applyFilter(sql sq.SelectBuilder, filter model.Filter) sq.SelectBuilder {
+	if id, ok := filter.ID.Get(); ok {
+		sql = sql.Where(sq.Eq{"o.id": id})
+	}
+	if locationID, ok := filter.LocationID.Get(); ok {
+		sql = sql.Where(sq.Eq{"h.location_id": locationID})
+	}
+	if hotelID, ok := filter.HotelID.Get(); ok {
+		sql = sql.Where(sq.Eq{"o.hotel_id": hotelID})
+	}
+	if roomID, ok := filter.RoomID.Get(); ok {
+		sql = sql.Where(sq.Eq{"o.room_id": roomID})
+	}
+	if checkInFrom, ok := filter.CheckInFrom.Get(); ok {
+		sql = sql.Where(sq.GtOrEq{"o.check_in_at": checkInFrom})
+	}
+	if checkOutTo, ok := filter.CheckOutTo.Get(); ok {
+		sql = sql.Where(sq.LtOrEq{"o.check_out_at": checkOutTo})
+	}
+	point, near := filter.Near.Get()
+	if near || filter.WithCoordinates {
+		sql = sql.Where(sq.NotEq{"h.latitude": nil})
+	}
+	if near {
+		if radius, ok := filter.RadiusKm.Get(); ok {
+			sql = sql.Where(boundingBox(point, radius)).Where(distanceExpr(point, " <= ?", radius))
+		}
+	}
+	if query, ok := filter.Query.Get(); ok {
+		sql = sql.Where(taskSearchSql, query)
+	}
+	if filter.OnlyOpen {
+		sql = sql.Where(sq.Eq{"o.status": model.StatusPublished}).
+			Where("o.expiration_at > NOW()").
+			Where(participantsCountSql + " < o.participants_limit")
+	}
+	if len(filter.Statuses) > 0 {
+		sql = sql.Where(sq.Eq{"o.status": filter.Statuses})
+	}
+	return sql
+}
+
 func (r *repo) GetByFilter(
 	ctx context.Context,
 	filter model.Filter,
 ) (offers []model.Offer, err error) {
 
 	// SELECT By filter
-	sql := baseGetSql
-	if id, ok := filter.ID.Get(); ok {
-		sql = sql.Where(sq.Eq{"o.id": id})
+	sql := applyFilter(baseGetSql, filter)
+	direction := " ASC"
+	if filter.Desc {
+		direction = " DESC"
 	}
-	if locationID, ok := filter.LocationID.Get(); ok {
-		sql = sql.Where(sq.Eq{"h.location_id": locationID})
+	point, near := filter.Near.Get()
+	if near {
+		sql = sql.Column(distanceExpr(point, " as distance_km"))
+	}
+	if column, ok := sortColumns[filter.Sort]; ok {
+		sql = sql.OrderBy(column + direction)
+	} else if filter.Sort == model.SortDistance && near {
+		sql = sql.OrderBy("distance_km" + direction)
 	}
+	// Без однозначного порядка страницы могут пересекаться. id идет в ту же сторону, что и ключ, иначе курсор не сработает
+	sql = sql.OrderBy("o.id" + direction)
+
+	if cursor, ok := filter.After.Get(); ok {
+		sql = sql.Where(afterCursor(cursor, filter))
+	}
+
 	query, args, err := sql.Limit(filter.Limit).Offset(filter.Offset).PlaceholderFormat(sq.Dollar).ToSql()
 
 	if err != nil {
@@ -52,16 +181,26 @@ func (r *repo) GetByFilter(
 	return offers, nil
 }
 
+// afterCursor - строки после курсора в порядке сортировки: сравнение пар (ключ, id)
+func afterCursor(cursor model.Cursor, filter model.Filter) sq.Sqlizer {
+	op := ">"
+	if filter.Desc {
+		op = "<"
+	}
+
+	if column, ok := sortColumns[filter.Sort]; ok {
+		return sq.Expr(fmt.Sprintf("(%s, o.id) %s (?::%s, ?::uuid)", column, op, sortTypes[filter.Sort]), cursor.Value, cursor.ID)
+	}
+	if point, near := filter.Near.Get(); filter.Sort == model.SortDistance && near {
+		return sq.Expr(fmt.Sprintf("(%s, o.id) %s (?::%s, ?::uuid)", distanceSql, op, sortTypes[filter.Sort]),
+			point.Latitude, point.Latitude, point.Longitude, cursor.Value, cursor.ID)
+	}
+	return sq.Expr(fmt.Sprintf("o.id %s ?::uuid", op), cursor.ID)
+}
+
 func (r *repo) GetCount(ctx context.Context, filter model.Filter) (int, error) {
 	var count int
-	sql := sq.Select("COUNT(*)").
-		From("offer o").
-		Join("hotel h ON o.hotel_id = h.id").
-		Join("room r ON o.room_id = r.id")
-	if locationID, ok := filter.LocationID.Get(); ok {
-		sql = sql.Where(sq.Eq{"h.location_id": locationID})
-	}
-	query, args, err := sql.PlaceholderFormat(sq.Dollar).ToSql()
+	query, args, err := applyFilter(baseCountSql, filter).PlaceholderFormat(sq.Dollar).ToSql()
 	if err != nil {
 		return 0, err
 	}
@@ -72,12 +211,12 @@ func (r *repo) GetCount(ctx context.Context, filter model.Filter) (int, error) {
 	return count, nil
 }
 
-func (r *repo) GetByExpirationTime(ctx context.Context) (offers []model.Offer, err error) { // поиск истекших оферов
-	sql := baseGetSql.Where(sq.LtOrEq{"o.expiration_at": time.Now()}). //истекшие оферы
-										Where(sq.Eq{"o.status": "created"}).
-										PlaceholderFormat(sq.Dollar).
-										Limit(10) // Берем по 10 оферов, позже запихну в воркер. Для равномерной нагрузки. А то можно надорвать мышцу если резко взять большой вес. А в приседе вообще геморрой может выскочить.
-	query, args, err := sql.ToSql()
+// GetScheduledForPublish - черновики из шаблонов, дата публикации которых наступила
+func (r *repo) GetScheduledForPublish(ctx context.Context) (offers []model.Offer, err error) {
+	query, args, err := baseGetSql.
+		Where(sq.Eq{"o.status": model.StatusDraft}).
+		Where(sq.LtOrEq{"o.publish_at": time.Now()}).
+		PlaceholderFormat(sq.Dollar).ToSql()
 	if err != nil {
 		return nil, err
 	}
@@ -86,6 +225,5 @@ func (r *repo) GetByExpirationTime(ctx context.Context) (offers []model.Offer, e
 	if err != nil {
 		return nil, err
 	}
-	// проверили две ошибки на запрос и на подключение
 	return offers, nil
 }
@@ -15,10 +15,15 @@ type Repo interface {
 	GetCount(ctx context.Context, filter model.Filter) (int, error)
 
 	Create(ctx context.Context, id uuid.UUID, create model.Create) error
-
-	Edit(ctx context.Context, filter model.Edit) error
-	GetByExpirationTime(ctx context.Context) ([]model.Offer, error)
-	EditStatus(ctx context.Context, offerID uuid.UUID, status string) error
+	CreateBatch(ctx context.Context, ids []uuid.UUID, creates []model.Create, dryRun bool) error
+
+	Edit(ctx context.Context, edit model.Edit) (int, error)
+	GetChecklist(ctx context.Context, offerID uuid.UUID) ([]model.ChecklistItem, error)
+	GetScheduledForPublish(ctx context.Context) ([]model.Offer, error)
+	UpdateStatus(ctx context.Context, offerID uuid.UUID, from, to model.Status) error
+	Cancel(ctx context.Context, offerID uuid.UUID, from model.Status) ([]uuid.UUID, error)
+	GetIDByReportID(ctx context.Context, reportID uuid.UUID) (uuid.UUID, error)
+	CountUnreviewedReports(ctx context.Context, offerID uuid.UUID) (int, error)
 }
 
 type repo struct {
@@ -0,0 +1,9 @@
+package offertemplate
+
+import "errors"
+
+var (
+	ErrTemplateNotFound = errors.New("offer template not found")
+	// ErrHotelOrRoomNotFound - в шаблоне указан несуществующий отель или номер
+	ErrHotelOrRoomNotFound = errors.New("hotel or room not found")
+)
@@ -0,0 +1,269 @@
+package offertemplate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jmoiron/sqlx"
+	offerModel "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/offer"
+	model "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/offertemplate"
+)
+
+type Repo interface {
+	// Create и SetActive(true) держат отель и номер FOR SHARE, как создание оффера, чтобы их не удалили параллельно
+	Create(ctx context.Context, id uuid.UUID, create model.Create) error
+	GetByID(ctx context.Context, id uuid.UUID) (model.Template, error)
+	GetAll(ctx context.Context) ([]model.Template, error)
+	SetActive(ctx context.Context, id uuid.UUID, active bool) error
+	// Materialize создает офферы по всем активным шаблонам, чья следующая публикация не позже until.
+	// Оффер на уже занятые даты (uq_offer) пропускается, шаблон все равно переходит к следующему повторению
+	Materialize(ctx context.Context, until time.Time) (model.MaterializeResult, error)
+}
+
+type repo struct {
+	db *sqlx.DB
+}
+
+func NewRepo(db *sqlx.DB) Repo {
+	return &repo{db: db}
+}
+
+const pgForeignKeyViolation = "23503"
+
+const queryCreate = `
+	INSERT INTO offer_template (
+		id, hotel_id, room_id, task, participants_limit, winners_count,
+		period, starts_at, check_in_after_days, nights, application_days, next_publish_at
+	) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $8)
+`
+
+const (
+	queryLockHotel = `SELECT id FROM hotel WHERE id = $1 AND deleted_at IS NULL FOR SHARE`
+	queryLockRoom  = `SELECT id FROM room WHERE id = $1 AND deleted_at IS NULL AND (hotel_id IS NULL OR hotel_id = $2) FOR SHARE`
+)
+
+// lockHotelAndRoom - проверка внешнего ключа после ожидания удаления прошла бы и по удаленной записи,
+// поэтому отель и номер блокируем явно с условием deleted_at IS NULL
+func lockHotelAndRoom(ctx context.Context, tx *sqlx.Tx, hotelID, roomID uuid.UUID) error {
+	var locked uuid.UUID
+	err := tx.GetContext(ctx, &locked, queryLockHotel, hotelID)
+	if err == nil {
+		err = tx.GetContext(ctx, &locked, queryLockRoom, roomID, hotelID)
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrHotelOrRoomNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to lock hotel and room: %w", err)
+	}
+	return nil
+}
+
+func (r *repo) Create(ctx context.Context, id uuid.UUID, create model.Create) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := lockHotelAndRoom(ctx, tx, create.HotelID, create.RoomID); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, queryCreate,
+		id,
+		create.HotelID,
+		create.RoomID,
+		create.Task,
+		create.ParticipantsLimit,
+		create.WinnersCount,
+		create.Period,
+		create.StartsAt,
+		create.CheckInAfterDays,
+		create.Nights,
+		create.ApplicationDays,
+	)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgForeignKeyViolation {
+		return ErrHotelOrRoomNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create offer template: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit offer template: %w", err)
+	}
+	return nil
+}
+
+const baseSelect = `
+	SELECT t.id, t.hotel_id, h.name AS hotel_name, t.room_id, r.name AS room_name, t.task,
+	       t.participants_limit, t.winners_count, t.period, t.starts_at, t.check_in_after_days,
+	       t.nights, t.application_days, t.materialized_count, t.next_publish_at, t.active, t.created_at
+	FROM offer_template t
+	JOIN hotel h ON h.id = t.hotel_id
+	JOIN room r ON r.id = t.room_id
+`
+
+func (r *repo) GetByID(ctx context.Context, id uuid.UUID) (model.Template, error) {
+	var template model.Template
+	err := r.db.GetContext(ctx, &template, baseSelect+`WHERE t.id = $1`, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.Template{}, ErrTemplateNotFound
+	}
+	if err != nil {
+		return model.Template{}, fmt.Errorf("failed to get offer template: %w", err)
+	}
+	return template, nil
+}
+
+func (r *repo) GetAll(ctx context.Context) ([]model.Template, error) {
+	templates := make([]model.Template, 0)
+	err := r.db.SelectContext(ctx, &templates, baseSelect+`ORDER BY t.created_at`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get offer templates: %w", err)
+	}
+	return templates, nil
+}
+
+func (r *repo) SetActive(ctx context.Context, id uuid.UUID, active bool) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var template struct {
+		HotelID uuid.UUID `db:"hotel_id"`
+		RoomID  uuid.UUID `db:"room_id"`
+	}
+	err = tx.GetContext(ctx, &template, `SELECT hotel_id, room_id FROM offer_template WHERE id = $1 FOR UPDATE`, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrTemplateNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to lock offer template: %w", err)
+	}
+
+	if active {
+		if err := lockHotelAndRoom(ctx, tx, template.HotelID, template.RoomID); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE offer_template SET active = $2 WHERE id = $1`, id, active); err != nil {
+		return fmt.Errorf("failed to update offer template: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit offer template: %w", err)
+	}
+	return nil
+}
+
+// queryLockDue - SKIP LOCKED, чтобы параллельные экземпляры планировщика не создавали одни и те же офферы.
+// Шаблоны с удаленным отелем или номером пропускаем, а живые отель и номер держим FOR SHARE до создания офферов
+const queryLockDue = `
+	SELECT t.id, t.hotel_id, t.room_id, t.task, t.participants_limit, t.winners_count, t.period,
+	       t.starts_at, t.check_in_after_days, t.nights, t.application_days, t.materialized_count,
+	       t.next_publish_at, t.active
+	FROM offer_template t
+	JOIN hotel h ON h.id = t.hotel_id AND h.deleted_at IS NULL
+	JOIN room r ON r.id = t.room_id AND r.deleted_at IS NULL AND (r.hotel_id IS NULL OR r.hotel_id = t.hotel_id)
+	WHERE t.active AND t.next_publish_at <= $1
+	FOR UPDATE OF t SKIP LOCKED
+	FOR SHARE OF h, r
+`
+
+const queryInsertOffer = `
+	INSERT INTO offer (
+		id, hotel_id, room_id, check_in_at, check_out_at, expiration_at, task,
+		participants_limit, winners_count, status, publish_at, template_id
+	) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	ON CONFLICT ON CONSTRAINT uq_offer DO NOTHING
+`
+
+const queryAdvance = `
+	UPDATE offer_template SET materialized_count = $2, next_publish_at = $3 WHERE id = $1
+`
+
+func (r *repo) Materialize(ctx context.Context, until time.Time) (model.MaterializeResult, error) {
+	var result model.MaterializeResult
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return result, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var templates []model.Template
+	if err := tx.SelectContext(ctx, &templates, queryLockDue, until); err != nil {
+		return result, fmt.Errorf("failed to lock offer templates: %w", err)
+	}
+
+	now := time.Now()
+	for _, template := range templates {
+		n := template.MaterializedCount
+		for ; !template.PublishAt(n).After(until); n++ {
+			occurrence := template.Occurrence(n)
+
+			// После долгой паузы шаблона не создаем офферы, заявки на которые уже не принять
+			if !occurrence.ExpirationAt.After(now) {
+				result.Skipped++
+				continue
+			}
+
+			// До даты публикации оффер - черновик, его опубликует планировщик
+			status := offerModel.StatusDraft
+			if !occurrence.PublishAt.After(now) {
+				status = offerModel.StatusPublished
+			}
+
+			res, err := tx.ExecContext(ctx, queryInsertOffer,
+				uuid.New(),
+				template.HotelID,
+				template.RoomID,
+				occurrence.CheckIn,
+				occurrence.CheckOut,
+				occurrence.ExpirationAt,
+				template.Task,
+				template.ParticipantsLimit,
+				template.WinnersCount,
+				status,
+				occurrence.PublishAt,
+				template.ID,
+			)
+			if err != nil {
+				return result, fmt.Errorf("failed to create offer from template %s: %w", template.ID, err)
+			}
+
+			affected, err := res.RowsAffected()
+			if err != nil {
+				return result, err
+			}
+
+			if affected == 0 {
+				result.Skipped++
+				continue
+			}
+			result.Created++
+		}
+
+		if _, err := tx.ExecContext(ctx, queryAdvance, template.ID, n, template.PublishAt(n)); err != nil {
+			return result, fmt.Errorf("failed to advance offer template %s: %w", template.ID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return result, fmt.Errorf("failed to commit materialize: %w", err)
+	}
+
+	return result, nil
+}
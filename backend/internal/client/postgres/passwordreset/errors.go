@@ -0,0 +1,9 @@
+package passwordreset
+
+import "errors"
+
+var (
+	ErrTokenNotFound = errors.New("password reset token not found")
+	ErrTokenUsed     = errors.New("password reset token already used")
+	ErrTokenExpired  = errors.New("password reset token expired")
+)
@@ -0,0 +1,107 @@
+package passwordreset
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type Repo interface {
+	// Create сохраняет новый токен и гасит все прежние неиспользованные токены пользователя
+	Create(ctx context.Context, userID uuid.UUID, tokenHash string, expiresAt time.Time) error
+	// Consume помечает токен использованным и возвращает его владельца
+	Consume(ctx context.Context, tokenHash string) (uuid.UUID, error)
+}
+
+type repo struct {
+	db *sqlx.DB
+}
+
+func NewRepo(db *sqlx.DB) Repo {
+	return &repo{db: db}
+}
+
+const queryInvalidate = `
+	UPDATE password_reset_token SET used_at = NOW()
+	WHERE user_id = $1 AND used_at IS NULL
+`
+
+const queryCreate = `
+	INSERT INTO password_reset_token (user_id, token_hash, expires_at)
+	VALUES ($1, $2, $3)
+`
+
+func (r *repo) Create(ctx context.Context, userID uuid.UUID, tokenHash string, expiresAt time.Time) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, queryInvalidate, userID); err != nil {
+		return fmt.Errorf("failed to invalidate reset tokens: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, queryCreate, userID, tokenHash, expiresAt); err != nil {
+		return fmt.Errorf("failed to create reset token: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit reset token: %w", err)
+	}
+
+	return nil
+}
+
+const queryGetForUpdate = `
+	SELECT id, user_id, expires_at, used_at
+	FROM password_reset_token
+	WHERE token_hash = $1
+	FOR UPDATE
+`
+
+const queryMarkUsed = `UPDATE password_reset_token SET used_at = NOW() WHERE id = $1`
+
+func (r *repo) Consume(ctx context.Context, tokenHash string) (uuid.UUID, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var token struct {
+		ID        uuid.UUID  `db:"id"`
+		UserID    uuid.UUID  `db:"user_id"`
+		ExpiresAt time.Time  `db:"expires_at"`
+		UsedAt    *time.Time `db:"used_at"`
+	}
+
+	if err := tx.GetContext(ctx, &token, queryGetForUpdate, tokenHash); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return uuid.Nil, ErrTokenNotFound
+		}
+		return uuid.Nil, fmt.Errorf("failed to get reset token: %w", err)
+	}
+
+	switch {
+	case token.UsedAt != nil:
+		return uuid.Nil, ErrTokenUsed
+	case token.ExpiresAt.Before(time.Now()):
+		return uuid.Nil, ErrTokenExpired
+	}
+
+	if _, err := tx.ExecContext(ctx, queryMarkUsed, token.ID); err != nil {
+		return uuid.Nil, fmt.Errorf("failed to mark reset token used: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return uuid.Nil, fmt.Errorf("failed to commit reset token: %w", err)
+	}
+
+	return token.UserID, nil
+}
@@ -0,0 +1,11 @@
+package promocode
+
+import "errors"
+
+var (
+	ErrPromocodeNotFound = errors.New("promocode not found")
+	ErrCodeTaken         = errors.New("promocode code already taken")
+	// ErrAlreadyIssued - за отчет уже выдан неотозванный промокод
+	ErrAlreadyIssued = errors.New("promocode already issued for report")
+	ErrNotActive     = errors.New("promocode is not active")
+)
@@ -0,0 +1,392 @@
+package promocode
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jmoiron/sqlx"
+	model "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/promocode"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/pkg"
+)
+
+type Repo interface {
+	Create(ctx context.Context, promocode model.Promocode) error
+	GetByID(ctx context.Context, id uuid.UUID) (model.Promocode, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]model.Promocode, error)
+	GetByFilter(ctx context.Context, filter Filter) ([]model.Promocode, error)
+	GetCountByFilter(ctx context.Context, filter Filter) (int, error)
+	// Revoke отзывает действующий промокод
+	Revoke(ctx context.Context, id uuid.UUID) error
+	// Reissue в одной транзакции отзывает старый промокод (если он еще действует),
+	// сохраняет новый и связывает их через replaced_by
+	Reissue(ctx context.Context, oldID uuid.UUID, promocode model.Promocode) error
+	// Redeem гасит действующий промокод по коду
+	Redeem(ctx context.Context, code string) (model.Promocode, error)
+}
+
+// Filter - параметры поиска промокодов в админке
+type Filter struct {
+	UserID pkg.Opt[uuid.UUID]
+	Status pkg.Opt[model.Status]
+	Limit  uint64
+	Offset uint64
+}
+
+type repo struct {
+	db *sqlx.DB
+}
+
+func NewRepo(db *sqlx.DB) Repo {
+	return &repo{db: db}
+}
+
+const (
+	pgUniqueViolation = "23505"
+
+	codeConstraint   = "promocode_code_key"
+	reportConstraint = "uq_promocode_report_active"
+)
+
+// statusColumn - истекшие промокоды отдаем со статусом expired, не дожидаясь фоновой задачи
+const statusColumn = `CASE WHEN status = 'issued' AND expires_at <= NOW() THEN 'expired' ELSE status END AS status`
+
+var selectColumns = []string{
+	"id",
+	"code",
+	"user_id",
+	"report_id",
+	"value",
+	"currency",
+	statusColumn,
+	"expires_at",
+	"issued_at",
+	"redeemed_at",
+	"revoked_at",
+	"replaced_by",
+}
+
+type promocodeRow struct {
+	ID         uuid.UUID  `db:"id"`
+	Code       string     `db:"code"`
+	UserID     uuid.UUID  `db:"user_id"`
+	ReportID   *uuid.UUID `db:"report_id"`
+	Value      int        `db:"value"`
+	Currency   string     `db:"currency"`
+	Status     string     `db:"status"`
+	ExpiresAt  time.Time  `db:"expires_at"`
+	IssuedAt   time.Time  `db:"issued_at"`
+	RedeemedAt *time.Time `db:"redeemed_at"`
+	RevokedAt  *time.Time `db:"revoked_at"`
+	ReplacedBy *uuid.UUID `db:"replaced_by"`
+}
+
+func (row promocodeRow) toModel() model.Promocode {
+	p := model.Promocode{
+		ID:         row.ID,
+		Code:       row.Code,
+		UserID:     row.UserID,
+		Value:      row.Value,
+		Currency:   row.Currency,
+		Status:     model.Status(row.Status),
+		ExpiresAt:  row.ExpiresAt,
+		IssuedAt:   row.IssuedAt,
+		RedeemedAt: row.RedeemedAt,
+		RevokedAt:  row.RevokedAt,
+		ReplacedBy: row.ReplacedBy,
+	}
+	if row.ReportID != nil {
+		p.ReportID = *row.ReportID
+	}
+	return p
+}
+
+func toModels(rows []promocodeRow) []model.Promocode {
+	res := make([]model.Promocode, 0, len(rows))
+	for _, row := range rows {
+		res = append(res, row.toModel())
+	}
+	return res
+}
+
+const queryCreate = `
+	INSERT INTO promocode (id, code, user_id, report_id, value, currency, status, expires_at, issued_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+`
+
+func (r *repo) Create(ctx context.Context, promocode model.Promocode) error {
+	return create(ctx, r.db, promocode)
+}
+
+func create(ctx context.Context, db sqlx.ExecerContext, promocode model.Promocode) error {
+	var reportID *uuid.UUID
+	if promocode.ReportID != uuid.Nil {
+		reportID = &promocode.ReportID
+	}
+
+	_, err := db.ExecContext(ctx, queryCreate,
+		promocode.ID,
+		promocode.Code,
+		promocode.UserID,
+		reportID,
+		promocode.Value,
+		promocode.Currency,
+		promocode.Status,
+		promocode.ExpiresAt,
+		promocode.IssuedAt,
+	)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+		switch pgErr.ConstraintName {
+		case codeConstraint:
+			return ErrCodeTaken
+		case reportConstraint:
+			return ErrAlreadyIssued
+		}
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to create promocode: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repo) GetByID(ctx context.Context, id uuid.UUID) (model.Promocode, error) {
+	query, args, err := sq.Select(selectColumns...).
+		From("promocode").
+		Where(sq.Eq{"id": id}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return model.Promocode{}, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	var row promocodeRow
+	if err := r.db.GetContext(ctx, &row, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.Promocode{}, ErrPromocodeNotFound
+		}
+		return model.Promocode{}, fmt.Errorf("failed to get promocode: %w", err)
+	}
+
+	return row.toModel(), nil
+}
+
+func (r *repo) GetByUserID(ctx context.Context, userID uuid.UUID) ([]model.Promocode, error) {
+	query, args, err := sq.Select(selectColumns...).
+		From("promocode").
+		Where(sq.Eq{"user_id": userID}).
+		OrderBy("issued_at DESC").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	var rows []promocodeRow
+	if err := r.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to get user promocodes: %w", err)
+	}
+
+	return toModels(rows), nil
+}
+
+func applyFilter(builder sq.SelectBuilder, filter Filter) sq.SelectBuilder {
+	if userID, ok := filter.UserID.Get(); ok {
+		builder = builder.Where(sq.Eq{"user_id": userID})
+	}
+
+	if status, ok := filter.Status.Get(); ok {
+		switch status {
+		case model.StatusIssued:
+			builder = builder.Where("status = 'issued' AND expires_at > NOW()")
+		case model.StatusExpired:
+			builder = builder.Where("status = 'issued' AND expires_at <= NOW()")
+		default:
+			builder = builder.Where(sq.Eq{"status": status})
+		}
+	}
+
+	return builder
+}
+
+func (r *repo) GetByFilter(ctx context.Context, filter Filter) ([]model.Promocode, error) {
+	query, args, err := applyFilter(sq.Select(selectColumns...).From("promocode"), filter).
+		OrderBy("issued_at DESC").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	var rows []promocodeRow
+	if err := r.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to get promocodes: %w", err)
+	}
+
+	return toModels(rows), nil
+}
+
+func (r *repo) GetCountByFilter(ctx context.Context, filter Filter) (int, error) {
+	query, args, err := applyFilter(sq.Select("COUNT(*)").From("promocode"), filter).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	var count int
+	if err := r.db.GetContext(ctx, &count, query, args...); err != nil {
+		return 0, fmt.Errorf("failed to count promocodes: %w", err)
+	}
+
+	return count, nil
+}
+
+const queryRevoke = `
+	UPDATE promocode SET status = 'revoked', revoked_at = NOW()
+	WHERE id = $1 AND status = 'issued' AND expires_at > NOW()
+`
+
+func (r *repo) Revoke(ctx context.Context, id uuid.UUID) error {
+	res, err := r.db.ExecContext(ctx, queryRevoke, id)
+	if err != nil {
+		return fmt.Errorf("failed to revoke promocode: %w", err)
+	}
+
+	return checkAffected(ctx, r.db, res, id)
+}
+
+const queryLock = `SELECT status, report_id, replaced_by FROM promocode WHERE id = $1 FOR UPDATE`
+
+type lockRow struct {
+	Status     string     `db:"status"`
+	ReportID   *uuid.UUID `db:"report_id"`
+	ReplacedBy *uuid.UUID `db:"replaced_by"`
+}
+
+// queryExistsForReport - за отчет уже есть другой не отозванный промокод: действующий, истекший или погашенный
+const queryExistsForReport = `
+	SELECT EXISTS(SELECT 1 FROM promocode WHERE report_id = $1 AND id <> $2 AND status <> 'revoked')
+`
+
+const queryRevokeForReissue = `
+	UPDATE promocode SET status = 'revoked', revoked_at = NOW()
+	WHERE id = $1 AND status = 'issued'
+`
+
+const querySetReplacedBy = `UPDATE promocode SET replaced_by = $2 WHERE id = $1`
+
+func (r *repo) Reissue(ctx context.Context, oldID uuid.UUID, promocode model.Promocode) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var old lockRow
+	if err := tx.GetContext(ctx, &old, queryLock, oldID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrPromocodeNotFound
+		}
+		return fmt.Errorf("failed to lock promocode: %w", err)
+	}
+
+	// Уже перевыпущенный код повторно не перевыпускаем, иначе за отчет выдадим вторую награду
+	if model.Status(old.Status) == model.StatusRedeemed || old.ReplacedBy != nil {
+		return ErrNotActive
+	}
+
+	if old.ReportID != nil {
+		var exists bool
+		if err := tx.GetContext(ctx, &exists, queryExistsForReport, *old.ReportID, oldID); err != nil {
+			return fmt.Errorf("failed to check report promocodes: %w", err)
+		}
+		if exists {
+			return ErrAlreadyIssued
+		}
+	}
+
+	// Истекший, но не отозванный промокод тоже гасим, чтобы освободить отчет под новый
+	if _, err := tx.ExecContext(ctx, queryRevokeForReissue, oldID); err != nil {
+		return fmt.Errorf("failed to revoke promocode: %w", err)
+	}
+
+	if err := create(ctx, tx, promocode); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, querySetReplacedBy, oldID, promocode.ID); err != nil {
+		return fmt.Errorf("failed to link reissued promocode: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit reissue: %w", err)
+	}
+
+	return nil
+}
+
+const queryRedeem = `
+	UPDATE promocode SET status = 'redeemed', redeemed_at = NOW()
+	WHERE code = $1 AND status = 'issued' AND expires_at > NOW()
+	RETURNING id
+`
+
+const queryExistsByCode = `SELECT EXISTS(SELECT 1 FROM promocode WHERE code = $1)`
+
+func (r *repo) Redeem(ctx context.Context, code string) (model.Promocode, error) {
+	var id uuid.UUID
+	err := r.db.GetContext(ctx, &id, queryRedeem, code)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		var exists bool
+		if err := r.db.GetContext(ctx, &exists, queryExistsByCode, code); err != nil {
+			return model.Promocode{}, fmt.Errorf("failed to check promocode: %w", err)
+		}
+		if !exists {
+			return model.Promocode{}, ErrPromocodeNotFound
+		}
+		return model.Promocode{}, ErrNotActive
+	}
+
+	if err != nil {
+		return model.Promocode{}, fmt.Errorf("failed to redeem promocode: %w", err)
+	}
+
+	return r.GetByID(ctx, id)
+}
+
+const queryExistsByID = `SELECT EXISTS(SELECT 1 FROM promocode WHERE id = $1)`
+
+// checkAffected отличает отсутствующий промокод от недействующего, если UPDATE ничего не изменил
+func checkAffected(ctx context.Context, db *sqlx.DB, res sql.Result, id uuid.UUID) error {
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if affected > 0 {
+		return nil
+	}
+
+	var exists bool
+	if err := db.GetContext(ctx, &exists, queryExistsByID, id); err != nil {
+		return fmt.Errorf("failed to check promocode: %w", err)
+	}
+
+	if !exists {
+		return ErrPromocodeNotFound
+	}
+
+	return ErrNotActive
+}
@@ -0,0 +1,8 @@
+package rbac
+
+import "errors"
+
+var (
+	ErrRoleNotFound = errors.New("role not found")
+	ErrUserNotFound = errors.New("user not found")
+)
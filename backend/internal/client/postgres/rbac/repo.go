@@ -0,0 +1,184 @@
+package rbac
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jmoiron/sqlx"
+	model "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/rbac"
+)
+
+const pgForeignKeyViolation = "23503"
+
+type Repo interface {
+	HasPermission(ctx context.Context, userID uuid.UUID, permission model.Permission) (bool, error)
+	GetUserPermissions(ctx context.Context, userID uuid.UUID) ([]model.Permission, error)
+	GetUserRoles(ctx context.Context, userID uuid.UUID) ([]string, error)
+	GetRoles(ctx context.Context) ([]model.Role, error)
+	AssignRole(ctx context.Context, userID uuid.UUID, role string) error
+	RevokeRole(ctx context.Context, userID uuid.UUID, role string) error
+}
+
+type repo struct {
+	db *sqlx.DB
+}
+
+func NewRepo(db *sqlx.DB) Repo {
+	return &repo{db: db}
+}
+
+const queryHasPermission = `
+	SELECT EXISTS(
+		SELECT 1
+		FROM user_role ur
+		INNER JOIN role_permission rp ON rp.role_id = ur.role_id
+		INNER JOIN permission p ON p.id = rp.permission_id
+		WHERE ur.user_id = $1 AND p.name = $2
+	)
+`
+
+func (r *repo) HasPermission(ctx context.Context, userID uuid.UUID, permission model.Permission) (bool, error) {
+	var ok bool
+
+	if err := r.db.GetContext(ctx, &ok, queryHasPermission, userID, permission); err != nil {
+		return false, fmt.Errorf("failed to check permission: %w", err)
+	}
+
+	return ok, nil
+}
+
+const queryGetUserPermissions = `
+	SELECT DISTINCT p.name
+	FROM user_role ur
+	INNER JOIN role_permission rp ON rp.role_id = ur.role_id
+	INNER JOIN permission p ON p.id = rp.permission_id
+	WHERE ur.user_id = $1
+	ORDER BY p.name
+`
+
+func (r *repo) GetUserPermissions(ctx context.Context, userID uuid.UUID) ([]model.Permission, error) {
+	permissions := make([]model.Permission, 0)
+
+	if err := r.db.SelectContext(ctx, &permissions, queryGetUserPermissions, userID); err != nil {
+		return nil, fmt.Errorf("failed to get user permissions: %w", err)
+	}
+
+	return permissions, nil
+}
+
+const queryGetUserRoles = `
+	SELECT r.name
+	FROM user_role ur
+	INNER JOIN role r ON r.id = ur.role_id
+	WHERE ur.user_id = $1
+	ORDER BY r.name
+`
+
+func (r *repo) GetUserRoles(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	roles := make([]string, 0)
+
+	if err := r.db.SelectContext(ctx, &roles, queryGetUserRoles, userID); err != nil {
+		return nil, fmt.Errorf("failed to get user roles: %w", err)
+	}
+
+	return roles, nil
+}
+
+const queryGetRoles = `
+	SELECT r.id, r.name, r.description, p.name AS permission
+	FROM role r
+	LEFT JOIN role_permission rp ON rp.role_id = r.id
+	LEFT JOIN permission p ON p.id = rp.permission_id
+	ORDER BY r.name, p.name
+`
+
+func (r *repo) GetRoles(ctx context.Context) ([]model.Role, error) {
+	var rows []struct {
+		ID          uuid.UUID `db:"id"`
+		Name        string    `db:"name"`
+		Description string    `db:"description"`
+		Permission  *string   `db:"permission"`
+	}
+
+	if err := r.db.SelectContext(ctx, &rows, queryGetRoles); err != nil {
+		return nil, fmt.Errorf("failed to get roles: %w", err)
+	}
+
+	roles := make([]model.Role, 0)
+
+	for _, row := range rows {
+		if len(roles) == 0 || roles[len(roles)-1].ID != row.ID {
+			roles = append(roles, model.Role{
+				ID:          row.ID,
+				Name:        row.Name,
+				Description: row.Description,
+				Permissions: make([]model.Permission, 0),
+			})
+		}
+
+		if row.Permission != nil {
+			role := &roles[len(roles)-1]
+			role.Permissions = append(role.Permissions, model.Permission(*row.Permission))
+		}
+	}
+
+	return roles, nil
+}
+
+const queryAssignRole = `
+	INSERT INTO user_role (user_id, role_id)
+	SELECT $1, r.id FROM role r WHERE r.name = $2
+	ON CONFLICT DO NOTHING
+`
+
+const queryRoleExists = `SELECT EXISTS(SELECT 1 FROM role WHERE name = $1)`
+
+func (r *repo) AssignRole(ctx context.Context, userID uuid.UUID, role string) error {
+	var exists bool
+
+	if err := r.db.GetContext(ctx, &exists, queryRoleExists, role); err != nil {
+		return fmt.Errorf("failed to check role: %w", err)
+	}
+
+	if !exists {
+		return ErrRoleNotFound
+	}
+
+	if _, err := r.db.ExecContext(ctx, queryAssignRole, userID, role); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgForeignKeyViolation {
+			return ErrUserNotFound
+		}
+
+		return fmt.Errorf("failed to assign role: %w", err)
+	}
+
+	return nil
+}
+
+const queryRevokeRole = `
+	DELETE FROM user_role ur
+	USING role r
+	WHERE ur.role_id = r.id AND ur.user_id = $1 AND r.name = $2
+`
+
+func (r *repo) RevokeRole(ctx context.Context, userID uuid.UUID, role string) error {
+	result, err := r.db.ExecContext(ctx, queryRevokeRole, userID, role)
+	if err != nil {
+		return fmt.Errorf("failed to revoke role: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return ErrRoleNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package report
+
+import (
+	"context"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	offerModel "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/offer"
+	model "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/report"
+)
+
+const queryGetChecklist = `
+	SELECT
+		i.id,
+		i.type,
+		i.title,
+		i.required,
+		ans.item_id as "answer_item_id",
+		ans.checked,
+		ans.score,
+		ans.text
+	FROM report r
+	INNER JOIN application a ON a.id = r.application_id
+	INNER JOIN offer_checklist_item i ON i.offer_id = a.offer_id
+	LEFT JOIN report_answer ans ON ans.report_id = r.id AND ans.item_id = i.id
+	WHERE r.id = $1
+	ORDER BY i.position
+`
+
+type checklistRow struct {
+	ID           uuid.UUID  `db:"id"`
+	Type         string     `db:"type"`
+	Title        string     `db:"title"`
+	Required     bool       `db:"required"`
+	AnswerItemID *uuid.UUID `db:"answer_item_id"`
+	Checked      *bool      `db:"checked"`
+	Score        *int       `db:"score"`
+	Text         *string    `db:"text"`
+}
+
+// getChecklist возвращает чек-лист оффера, по которому заведен отчет, и уже сохраненные ответы
+func (r *repo) getChecklist(ctx context.Context, reportID uuid.UUID) ([]offerModel.ChecklistItem, []model.Answer, error) {
+	var rows []checklistRow
+	if err := r.db.SelectContext(ctx, &rows, queryGetChecklist, reportID); err != nil {
+		return nil, nil, fmt.Errorf("failed to get report checklist: %w", err)
+	}
+
+	items := make([]offerModel.ChecklistItem, 0, len(rows))
+	answers := make([]model.Answer, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, offerModel.ChecklistItem{
+			ID:       row.ID,
+			Type:     offerModel.ChecklistItemType(row.Type),
+			Title:    row.Title,
+			Required: row.Required,
+		})
+		if row.AnswerItemID != nil {
+			answers = append(answers, model.Answer{
+				ItemID:  row.ID,
+				Checked: row.Checked,
+				Score:   row.Score,
+				Text:    row.Text,
+			})
+		}
+	}
+	return items, answers, nil
+}
+
+func (r *repo) GetChecklist(ctx context.Context, reportID uuid.UUID) ([]offerModel.ChecklistItem, error) {
+	items, _, err := r.getChecklist(ctx, reportID)
+	return items, err
+}
+
+// replaceAnswers перезаписывает ответы отчета, как и фотографии в Upsert
+func replaceAnswers(ctx context.Context, tx sqlx.ExecerContext, reportID uuid.UUID, answers []model.Answer) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM report_answer WHERE report_id = $1`, reportID); err != nil {
+		return fmt.Errorf("failed to delete answers: %w", err)
+	}
+
+	if len(answers) == 0 {
+		return nil
+	}
+
+	sql := sq.Insert("report_answer").Columns("report_id", "item_id", "checked", "score", "text")
+	for _, answer := range answers {
+		sql = sql.Values(reportID, answer.ItemID, answer.Checked, answer.Score, answer.Text)
+	}
+	query, args, err := sql.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to insert answers: %w", err)
+	}
+	return nil
+}
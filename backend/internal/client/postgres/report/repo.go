@@ -5,26 +5,29 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
-	"log"
 	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	offerModel "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/offer"
 	model "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/report"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/pkg"
 )
 
 type Repo interface {
 	Create(ctx context.Context, createData model.Report) error
 	GetByID(ctx context.Context, id uuid.UUID) (model.Report, bool, error)
-	GetByUserID(ctx context.Context, userID uuid.UUID, limit, offset int64) ([]model.Report, error)
+	// GetByUserID - отчеты пользователя от новых к старым, after - курсор вместо offset
+	GetByUserID(ctx context.Context, userID uuid.UUID, limit, offset uint64, after pkg.Opt[model.Cursor]) ([]model.Report, error)
 	Get(ctx context.Context, limit, offset int64) ([]model.Report, error)
 	Count(ctx context.Context) (int64, error)
 	CountByUserId(ctx context.Context, userId uuid.UUID) (int64, error)
 	Upsert(ctx context.Context, report model.Report) error
 	GetImagesByReportID(ctx context.Context, reportID uuid.UUID) ([]model.Image, error)
+	// GetChecklist возвращает чек-лист оффера, по которому заведен отчет
+	GetChecklist(ctx context.Context, reportID uuid.UUID) ([]offerModel.ChecklistItem, error)
 	UpdateStatus(ctx context.Context, report model.Report) error
-	UpdatePromocode(ctx context.Context, report model.Report) error
 	GetByApplicationId(ctx context.Context, applicationId uuid.UUID) (uuid.UUID, uuid.UUID, error)
 	GetByFilter(ctx context.Context, filter model.Filter) ([]model.Report, error)
 	GetCountByFilter(ctx context.Context, filter model.Filter) (int, error)
@@ -38,6 +41,13 @@ func NewRepo(db *sqlx.DB) Repo {
 	return &repo{db: db}
 }
 
+// promocodeColumn - последний не отозванный промокод, выданный за отчет
+const promocodeColumn = `COALESCE((
+				SELECT pc.code FROM promocode pc
+				WHERE pc.report_id = r.id AND pc.status <> 'revoked'
+				ORDER BY pc.issued_at DESC LIMIT 1
+			), '') AS promocode`
+
 const queryCreate = `
 	INSERT INTO report (id, application_id, expiration_at, status, text)
 	VALUES ($1, $2, $3, $4, $5)
@@ -59,6 +69,7 @@ type getRow struct {
 	Task          string     `db:"task"`
 	CheckInAt     time.Time  `db:"check_in_at"`
 	CheckOutAt    time.Time  `db:"check_out_at"`
+	CreatedAt     time.Time  `db:"created_at"`
 }
 
 func (r *repo) Create(ctx context.Context, createData model.Report) error {
@@ -87,9 +98,10 @@ const queryGetByID = `
             r.expiration_at,
             r.status,
             r.text,
-			r.promocode,
+			` + promocodeColumn + `,
             p.id as "image_id",
             p.s3_link as "image_link",
+            p.item_id as "image_item_id",
             a.user_id as "user_id",
 			h.name as "hotel_name",
 			l.name as "location_name",
@@ -118,6 +130,7 @@ func (r *repo) GetByID(ctx context.Context, id uuid.UUID) (model.Report, bool, e
 		Promocode     string     `db:"promocode"`
 		ImageID       *uuid.UUID `db:"image_id"`
 		ImageLink     *string    `db:"image_link"`
+		ImageItemID   *uuid.UUID `db:"image_item_id"`
 		HotelName     string     `db:"hotel_name"`
 		LocationName  string     `db:"location_name"`
 		RoomName      string     `db:"room_name"`
@@ -158,109 +171,84 @@ func (r *repo) GetByID(ctx context.Context, id uuid.UUID) (model.Report, bool, e
 	for _, row := range rows {
 		if row.ImageID != nil && row.ImageLink != nil {
 			report.Images = append(report.Images, model.Image{
-				ID:   *row.ImageID,
-				Link: *row.ImageLink,
+				ID:     *row.ImageID,
+				Link:   *row.ImageLink,
+				ItemID: row.ImageItemID,
 			})
 		}
 	}
 
+	report.Checklist, report.Answers, err = r.getChecklist(ctx, id)
+	if err != nil {
+		return model.Report{}, false, err
+	}
+
 	return report, true, nil
 }
 
-const queryGetByUserID = `
-        SELECT 
-            r.id,
-            r.application_id,
-            r.expiration_at,
-            r.status,
-            r.text,
-			r.promocode,
-            p.id as "image_id",
-            p.s3_link as "image_link",
-			h.name as "hotel_name",
-			l.name as "location_name",
-			o.task as "task",
-			o.check_in_at,
-			o.check_out_at,
-			m.name as "room_name"
-        FROM report r
-        LEFT JOIN photo p ON r.id = p.report_id
-        LEFT JOIN application a ON a.id = r.application_id
-		INNER JOIN offer o ON o.id = a.offer_id
-		INNER JOIN hotel h ON h.id = o.hotel_id
-		INNER JOIN location l ON l.id = h.location_id   
-		INNER JOIN room m ON m.id = o.room_id
-        WHERE a.user_id = $1
-        ORDER BY r.expiration_at DESC
-        LIMIT $2 OFFSET $3
-    `
-
-func (r *repo) GetByUserID(ctx context.Context, userID uuid.UUID, limit, offset int64) ([]model.Report, error) {
-	var rows []struct {
-		ID            uuid.UUID  `db:"id"`
-		ApplicationID uuid.UUID  `db:"application_id"`
-		ExpirationAt  time.Time  `db:"expiration_at"`
-		UserID        uuid.UUID  `db:"user_id"`
-		Status        string     `db:"status"`
-		Text          string     `db:"text"`
-		Promocode     string     `db:"promocode"`
-		ImageID       *uuid.UUID `db:"image_id"`
-		ImageLink     *string    `db:"image_link"`
-		HotelName     string     `db:"hotel_name"`
-		LocationName  string     `db:"location_name"`
-		RoomName      string     `db:"room_name"`
-		Task          string     `db:"task"`
-		CheckInAt     time.Time  `db:"check_in_at"`
-		CheckOutAt    time.Time  `db:"check_out_at"`
-	}
-
-	err := sqlx.SelectContext(ctx, r.db, &rows, queryGetByUserID, userID, limit, offset)
-	if err != nil {
-		return nil, err
-	}
+func (r *repo) GetByUserID(
+	ctx context.Context,
+	userID uuid.UUID,
+	limit, offset uint64,
+	after pkg.Opt[model.Cursor],
+) ([]model.Report, error) {
+	ids := sq.Select("r.id").
+		From("report r").
+		Join("application a ON a.id = r.application_id").
+		Where(sq.Eq{"a.user_id": userID}).
+		OrderBy("r.created_at DESC", "r.id DESC").
+		Limit(limit)
 
-	if len(rows) == 0 {
-		return []model.Report{}, nil
+	if cursor, ok := after.Get(); ok {
+		ids = ids.Where("(r.created_at, r.id) < (?, ?)", cursor.CreatedAt, cursor.ID)
+	} else {
+		ids = ids.Offset(offset)
 	}
 
-	// Группируем строки по отчетам
-	reportsMap := make(map[uuid.UUID]*model.Report)
+	return r.selectPage(ctx, ids, "r.created_at DESC", "r.id DESC")
+}
 
-	for _, row := range rows {
-		if _, exists := reportsMap[row.ID]; !exists {
-			reportsMap[row.ID] = &model.Report{
-				ID:            row.ID,
-				ApplicationID: row.ApplicationID,
-				UserID:        userID,
-				ExpirationAt:  row.ExpirationAt,
-				Status:        row.Status,
-				Text:          row.Text,
-				Promocode:     row.Promocode,
-				LocationName:  row.LocationName,
-				HotelName:     row.HotelName,
-				RoomName:      row.RoomName,
-				Task:          row.Task,
-				Images:        make([]model.Image, 0),
-			}
-		}
+// selectPage загружает отчеты из ids вместе с фото. Страница отбирается по отчетам, а не по строкам
+// с фото, иначе отчет с несколькими фото занимает несколько мест в LIMIT
+func (r *repo) selectPage(ctx context.Context, ids sq.SelectBuilder, orderBy ...string) ([]model.Report, error) {
+	sql := sq.Select(
+		"r.id",
+		"a.user_id",
+		"r.application_id",
+		"r.expiration_at",
+		"r.status",
+		"r.text",
+		"r.created_at",
+		promocodeColumn,
+		"p.id as image_id",
+		"p.s3_link as image_link",
+		"h.name as hotel_name",
+		"l.name as location_name",
+		"o.task as task",
+		"o.check_in_at",
+		"o.check_out_at",
+		"m.name as room_name").
+		From("report r").
+		LeftJoin("photo p ON r.id = p.report_id").
+		Join("application a ON a.id = r.application_id").
+		Join("offer o ON o.id = a.offer_id").
+		Join("hotel h ON h.id = o.hotel_id").
+		Join("location l ON l.id = h.location_id").
+		Join("room m ON m.id = o.room_id").
+		Where(sq.Expr("r.id IN (?)", ids)).
+		OrderBy(append(orderBy, "p.id")...)
 
-		// Добавляем фото, если оно есть
-		if row.ImageID != nil && row.ImageLink != nil {
-			report := reportsMap[row.ID]
-			report.Images = append(report.Images, model.Image{
-				ID:   *row.ImageID,
-				Link: *row.ImageLink,
-			})
-		}
+	query, args, err := sql.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, err
 	}
 
-	// Конвертируем map в slice
-	reports := make([]model.Report, 0, len(reportsMap))
-	for _, report := range reportsMap {
-		reports = append(reports, *report)
+	var rows []getRow
+	if err := r.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, err
 	}
 
-	return reports, nil
+	return convertGetDtoToModel(rows), nil
 }
 
 const queryGet = `
@@ -271,7 +259,7 @@ const queryGet = `
             r.expiration_at,
             r.status,
             r.text,
-			r.promocode,
+			` + promocodeColumn + `,
             p.id as "image_id",
             p.s3_link as "image_link",
 			h.name as "hotel_name",
@@ -343,8 +331,8 @@ const reportUpsertQuery = `
     `
 const deletePhotosQuery = `DELETE FROM photo WHERE report_id = $1`
 const insertPhotoQuery = `
-            INSERT INTO photo (id, report_id, s3_link)
-            VALUES (:id, :report_id, :s3_link)
+            INSERT INTO photo (id, report_id, s3_link, item_id)
+            VALUES (:id, :report_id, :s3_link, :item_id)
         `
 
 func (r *repo) Upsert(ctx context.Context, report model.Report) error {
@@ -374,6 +362,11 @@ func (r *repo) Upsert(ctx context.Context, report model.Report) error {
 		return err
 	}
 
+	err = replaceAnswers(ctx, tx, report.ID, report.Answers)
+	if err != nil {
+		return err
+	}
+
 	// Пакетная вставка новых фотографий
 	if len(report.Images) > 0 {
 		photos := make([]map[string]interface{}, len(report.Images))
@@ -382,6 +375,7 @@ func (r *repo) Upsert(ctx context.Context, report model.Report) error {
 				"id":        image.ID,
 				"report_id": report.ID,
 				"s3_link":   image.Link,
+				"item_id":   image.ItemID,
 			}
 		}
 
@@ -443,22 +437,6 @@ func (r *repo) UpdateStatus(ctx context.Context, report model.Report) error {
 	return nil
 }
 
-const reportUpdatePromocodeQuery = `
-        UPDATE report SET promocode = $1 WHERE id = $2
-    `
-
-func (r *repo) UpdatePromocode(ctx context.Context, report model.Report) error {
-	_, err := r.db.ExecContext(ctx, reportUpdatePromocodeQuery,
-		report.Promocode,
-		report.ID,
-	)
-
-	if err != nil {
-		return err
-	}
-	return nil
-}
-
 const reportGetByApplicationIdQuery = `
         SELECT r.id, a.user_id 
 		FROM report r
@@ -481,52 +459,23 @@ func (r *repo) GetByApplicationId(ctx context.Context, applicationId uuid.UUID)
 }
 
 func (r *repo) GetByFilter(ctx context.Context, filter model.Filter) ([]model.Report, error) {
-	sql := sq.Select(
-		"r.id",
-		"a.user_id",
-		"r.application_id",
-		"r.expiration_at",
-		"r.status",
-		"r.text",
-		"p.id as image_id",
-		"p.s3_link as image_link",
-		"h.name as hotel_name",
-		"l.name as location_name",
-		"o.task as task",
-		"o.check_in_at",
-		"o.check_out_at",
-		"m.name as room_name").
+	ids := sq.Select("r.id").
 		From("report r").
-		LeftJoin("photo p ON r.id = p.report_id").
-		LeftJoin("application a ON a.id = r.application_id").
+		Join("application a ON a.id = r.application_id").
 		Join("offer o ON o.id = a.offer_id").
 		Join("hotel h ON h.id = o.hotel_id").
-		Join("location l ON l.id = h.location_id").
-		Join("room m ON m.id = o.room_id").
-		OrderBy("r.expiration_at DESC, p.id")
+		OrderBy("r.expiration_at DESC", "r.id")
 	if status, ok := filter.Status.Get(); ok {
-		sql = sql.Where(sq.Eq{"r.status": status})
+		ids = ids.Where(sq.Eq{"r.status": status})
 	}
 	if hotelID, ok := filter.HotelID.Get(); ok {
-		sql = sql.Where(sq.Eq{"o.hotel_id": hotelID})
+		ids = ids.Where(sq.Eq{"o.hotel_id": hotelID})
 	}
 	if locationID, ok := filter.LocationID.Get(); ok {
-		sql = sql.Where(sq.Eq{"h.location_id": locationID})
-	}
-	query, args, err := sql.Limit(filter.Limit).Offset(filter.Offset).PlaceholderFormat(sq.Dollar).ToSql()
-	log.Println(query)
-	if err != nil {
-		return nil, err
-	}
-	var rows []getRow
-	err = r.db.SelectContext(ctx, &rows, query, args...)
-	if err != nil {
-		return nil, err
+		ids = ids.Where(sq.Eq{"h.location_id": locationID})
 	}
-	if len(rows) == 0 {
-		return []model.Report{}, nil
-	}
-	return convertGetDtoToModel(rows), nil
+
+	return r.selectPage(ctx, ids.Limit(filter.Limit).Offset(filter.Offset), "r.expiration_at DESC", "r.id")
 }
 
 func (r *repo) GetCountByFilter(ctx context.Context, filter model.Filter) (int, error) {
@@ -560,12 +509,16 @@ func (r *repo) GetCountByFilter(ctx context.Context, filter model.Filter) (int,
 }
 
 func convertGetDtoToModel(rows []getRow) []model.Report {
-	// Группируем строки по отчетам
-	reportsMap := make(map[uuid.UUID]*model.Report)
+	// Группируем строки по отчетам, сохраняя порядок выборки
+	reports := make([]model.Report, 0, len(rows))
+	indexes := make(map[uuid.UUID]int)
 
 	for _, row := range rows {
-		if _, exists := reportsMap[row.ID]; !exists {
-			reportsMap[row.ID] = &model.Report{
+		i, exists := indexes[row.ID]
+		if !exists {
+			i = len(reports)
+			indexes[row.ID] = i
+			reports = append(reports, model.Report{
 				ID:            row.ID,
 				ApplicationID: row.ApplicationID,
 				UserID:        row.UserID,
@@ -577,24 +530,19 @@ func convertGetDtoToModel(rows []getRow) []model.Report {
 				HotelName:     row.HotelName,
 				RoomName:      row.RoomName,
 				Task:          row.Task,
+				CreatedAt:     row.CreatedAt,
 				Images:        make([]model.Image, 0),
-			}
+			})
 		}
 
 		// Добавляем фото, если оно есть
 		if row.ImageID != nil && row.ImageLink != nil {
-			report := reportsMap[row.ID]
-			report.Images = append(report.Images, model.Image{
+			reports[i].Images = append(reports[i].Images, model.Image{
 				ID:   *row.ImageID,
 				Link: *row.ImageLink,
 			})
 		}
 	}
 
-	// Конвертируем map в slice
-	reports := make([]model.Report, 0, len(reportsMap))
-	for _, report := range reportsMap {
-		reports = append(reports, *report)
-	}
 	return reports
 }
@@ -0,0 +1,11 @@
+package room
+
+import "errors"
+
+var (
+	ErrNotFound  = errors.New("room not found")
+	ErrNameTaken = errors.New("room with this name already exists")
+	// ErrInUse - номер используется в офферах в работе или активных шаблонах офферов
+	ErrInUse         = errors.New("room has active offers or offer templates")
+	ErrHotelNotFound = errors.New("hotel not found")
+)
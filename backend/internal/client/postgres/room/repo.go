@@ -2,16 +2,26 @@ package room
 
 import (
 	"context"
+	sql2 "database/sql"
+	"errors"
+	"fmt"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jmoiron/sqlx"
+	offerModel "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/offer"
 	model "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/room"
 )
 
 type Repo interface {
 	GetAll(ctx context.Context) ([]model.Room, error)
-	Create(ctx context.Context, name string) (uuid.UUID, error)
+	GetByFilter(ctx context.Context, filter model.Filter) ([]model.Room, error)
+	GetByID(ctx context.Context, id uuid.UUID) (model.Room, error)
+	Create(ctx context.Context, create model.Create) (uuid.UUID, error)
+	Rename(ctx context.Context, id uuid.UUID, name string) error
+	// Delete помечает номер удаленным, если он не используется в офферах в работе
+	Delete(ctx context.Context, id uuid.UUID) error
 }
 
 type repo struct {
@@ -24,12 +34,33 @@ func NewRepo(sqlClient *sqlx.DB) Repo {
 	}
 }
 
+const (
+	pgUniqueViolation     = "23505"
+	pgForeignKeyViolation = "23503"
+)
+
+var baseGetSql = sq.Select("id", "name", "hotel_id").From("room").Where("deleted_at IS NULL")
+
 func (r *repo) GetAll(ctx context.Context) (rooms []model.Room, err error) {
-	query, _, err := sq.Select("id", "name").From("room").ToSql()
+	return r.GetByFilter(ctx, model.Filter{})
+}
+
+func (r *repo) GetByFilter(ctx context.Context, filter model.Filter) (rooms []model.Room, err error) {
+	sql := baseGetSql
+	if hotelID, ok := filter.HotelID.Get(); ok {
+		sql = sql.Where(sq.Or{sq.Eq{"hotel_id": hotelID}, sq.Eq{"hotel_id": nil}})
+	}
+	if cursor, ok := filter.After.Get(); ok {
+		sql = sql.Where("(name, id) > (?, ?)", cursor.Name, cursor.ID)
+	}
+	if filter.Limit > 0 {
+		sql = sql.Limit(filter.Limit)
+	}
+	query, args, err := sql.OrderBy("name", "id").PlaceholderFormat(sq.Dollar).ToSql()
 	if err != nil {
 		return nil, err
 	}
-	err = r.sqlClient.SelectContext(ctx, &rooms, query)
+	err = r.sqlClient.SelectContext(ctx, &rooms, query, args...)
 	if err != nil {
 		//TODO обработка похитрее
 		return nil, err
@@ -37,23 +68,119 @@ func (r *repo) GetAll(ctx context.Context) (rooms []model.Room, err error) {
 	return rooms, nil
 }
 
-func (r *repo) Create(ctx context.Context, name string) (uuid.UUID, error) {
+func (r *repo) GetByID(ctx context.Context, id uuid.UUID) (model.Room, error) {
+	query, args, err := baseGetSql.Where(sq.Eq{"id": id}).PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return model.Room{}, err
+	}
+
+	var room model.Room
+	err = r.sqlClient.GetContext(ctx, &room, query, args...)
+	if errors.Is(err, sql2.ErrNoRows) {
+		return model.Room{}, ErrNotFound
+	}
+	if err != nil {
+		return model.Room{}, fmt.Errorf("failed to get room: %w", err)
+	}
+	return room, nil
+}
+
+func (r *repo) Create(ctx context.Context, create model.Create) (uuid.UUID, error) {
 	id := uuid.New()
+
+	var hotelID *uuid.UUID
+	if value, ok := create.HotelID.Get(); ok {
+		hotelID = &value
+	}
+
 	query, args, err := sq.Insert("room").Columns(
 		"id",
 		"name",
+		"hotel_id",
 	).Values(
 		id,
-		name,
-	).ToSql()
+		create.Name,
+		hotelID,
+	).PlaceholderFormat(sq.Dollar).ToSql()
 	if err != nil {
-		//TODO обработка похитрее
 		return uuid.Nil, err
 	}
-	err = r.sqlClient.QueryRowContext(ctx, query, args...).Scan()
+	_, err = r.sqlClient.ExecContext(ctx, query, args...)
 	if err != nil {
-		//TODO обработка похитрее
-		return uuid.Nil, err
+		return uuid.Nil, convertError(err)
 	}
 	return id, nil
 }
+
+func (r *repo) Rename(ctx context.Context, id uuid.UUID, name string) error {
+	res, err := r.sqlClient.ExecContext(ctx,
+		`UPDATE room SET name = $2 WHERE id = $1 AND deleted_at IS NULL`, id, name)
+	if err != nil {
+		return convertError(err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *repo) Delete(ctx context.Context, id uuid.UUID) error {
+	tx, err := r.sqlClient.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// FOR UPDATE конфликтует с FOR SHARE, которую берет на номер создание и правка оффера
+	var locked uuid.UUID
+	err = tx.GetContext(ctx, &locked, `SELECT id FROM room WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`, id)
+	if errors.Is(err, sql2.ErrNoRows) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to lock room: %w", err)
+	}
+
+	offers := sq.Select("1").From("offer").Where(sq.Eq{"room_id": id, "status": offerModel.ActiveStatuses})
+	templates := sq.Select("1").From("offer_template").Where(sq.Eq{"room_id": id}).Where("active")
+	query, args, err := sq.Select().
+		Column(sq.Expr("EXISTS (?) OR EXISTS (?)", offers, templates)).
+		PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return err
+	}
+
+	var inUse bool
+	if err := tx.QueryRowContext(ctx, query, args...).Scan(&inUse); err != nil {
+		return fmt.Errorf("failed to check active offers: %w", err)
+	}
+	if inUse {
+		return ErrInUse
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE room SET deleted_at = NOW() WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("failed to delete room: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit room delete: %w", err)
+	}
+	return nil
+}
+
+func convertError(err error) error {
+	var pgErr *pgconn.PgError
+	switch {
+	case errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation:
+		return ErrNameTaken
+	case errors.As(err, &pgErr) && pgErr.Code == pgForeignKeyViolation:
+		return ErrHotelNotFound
+	default:
+		return err
+	}
+}
@@ -0,0 +1,10 @@
+package session
+
+import "errors"
+
+var (
+	ErrSessionNotFound = errors.New("refresh session not found")
+	ErrSessionRevoked  = errors.New("refresh session revoked")
+	ErrSessionExpired  = errors.New("refresh session expired")
+	ErrSessionReused   = errors.New("refresh session already rotated")
+)
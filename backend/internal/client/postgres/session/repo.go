@@ -0,0 +1,292 @@
+package session
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	model "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/session"
+)
+
+type Repo interface {
+	// CreateSession сохраняет новую сессию пользователя вместе с первой refresh-сессией ее семьи
+	CreateSession(ctx context.Context, session model.Session, refresh model.RefreshSession) error
+	// Rotate помечает сессию oldID использованной и выдает вместо нее новую в той же семье.
+	// Повторное предъявление уже ротированной сессии отзывает всю семью и возвращает ErrSessionReused.
+	Rotate(ctx context.Context, oldID, newID uuid.UUID, expiresAt time.Time) (model.RefreshSession, error)
+	// CheckActive проверяет, что сессия не отозвана и не истекла, и обновляет время последней активности
+	CheckActive(ctx context.Context, sessionID, userID uuid.UUID) (bool, error)
+	GetActiveSessions(ctx context.Context, userID uuid.UUID) ([]model.Session, error)
+	RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error
+	RevokeAllSessions(ctx context.Context, userID uuid.UUID) (int64, error)
+	// RevokeOtherSessions завершает все сессии пользователя, кроме currentID
+	RevokeOtherSessions(ctx context.Context, userID, currentID uuid.UUID) (int64, error)
+}
+
+type repo struct {
+	db *sqlx.DB
+}
+
+func NewRepo(db *sqlx.DB) Repo {
+	return &repo{db: db}
+}
+
+type sessionDTO struct {
+	ID        uuid.UUID  `db:"id"`
+	FamilyID  uuid.UUID  `db:"family_id"`
+	UserID    uuid.UUID  `db:"user_id"`
+	ExpiresAt time.Time  `db:"expires_at"`
+	CreatedAt time.Time  `db:"created_at"`
+	RotatedAt *time.Time `db:"rotated_at"`
+	RevokedAt *time.Time `db:"revoked_at"`
+}
+
+func (d *sessionDTO) toModel() model.RefreshSession {
+	return model.RefreshSession{
+		ID:        d.ID,
+		FamilyID:  d.FamilyID,
+		UserID:    d.UserID,
+		ExpiresAt: d.ExpiresAt,
+		CreatedAt: d.CreatedAt,
+		RotatedAt: d.RotatedAt,
+		RevokedAt: d.RevokedAt,
+	}
+}
+
+const queryCreate = `
+	INSERT INTO refresh_session (id, family_id, user_id, expires_at)
+	VALUES ($1, $2, $3, $4)
+`
+
+const queryCreateSession = `
+	INSERT INTO user_session (id, user_id, user_agent, ip, expires_at)
+	VALUES ($1, $2, $3, $4, $5)
+`
+
+func (r *repo) CreateSession(ctx context.Context, session model.Session, refresh model.RefreshSession) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, queryCreateSession, session.ID, session.UserID, session.UserAgent, session.IP, session.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to create user session: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, queryCreate, refresh.ID, refresh.FamilyID, refresh.UserID, refresh.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to create refresh session: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit session creation: %w", err)
+	}
+
+	return nil
+}
+
+const queryGetForUpdate = `
+	SELECT id, family_id, user_id, expires_at, created_at, rotated_at, revoked_at
+	FROM refresh_session
+	WHERE id = $1
+	FOR UPDATE
+`
+
+const queryMarkRotated = `UPDATE refresh_session SET rotated_at = NOW() WHERE id = $1`
+
+const queryRevokeFamily = `
+	WITH s AS (
+		UPDATE user_session SET revoked_at = NOW()
+		WHERE id = $1 AND revoked_at IS NULL
+	)
+	UPDATE refresh_session SET revoked_at = NOW()
+	WHERE family_id = $1 AND revoked_at IS NULL
+`
+
+const queryProlongSession = `
+	UPDATE user_session SET expires_at = $2, last_seen_at = NOW()
+	WHERE id = $1
+`
+
+func (r *repo) Rotate(ctx context.Context, oldID, newID uuid.UUID, expiresAt time.Time) (model.RefreshSession, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return model.RefreshSession{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var old sessionDTO
+	if err := tx.GetContext(ctx, &old, queryGetForUpdate, oldID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.RefreshSession{}, ErrSessionNotFound
+		}
+		return model.RefreshSession{}, fmt.Errorf("failed to get refresh session: %w", err)
+	}
+
+	switch {
+	case old.RevokedAt != nil:
+		return model.RefreshSession{}, ErrSessionRevoked
+	case old.RotatedAt != nil:
+		// Токен уже был обменян - значит его кто-то украл, гасим всю цепочку
+		if _, err := tx.ExecContext(ctx, queryRevokeFamily, old.FamilyID); err != nil {
+			return model.RefreshSession{}, fmt.Errorf("failed to revoke session family: %w", err)
+		}
+		if err := tx.Commit(); err != nil {
+			return model.RefreshSession{}, fmt.Errorf("failed to commit session family revoke: %w", err)
+		}
+		return model.RefreshSession{}, ErrSessionReused
+	case old.ExpiresAt.Before(time.Now()):
+		return model.RefreshSession{}, ErrSessionExpired
+	}
+
+	if _, err := tx.ExecContext(ctx, queryMarkRotated, old.ID); err != nil {
+		return model.RefreshSession{}, fmt.Errorf("failed to mark refresh session rotated: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, queryProlongSession, old.FamilyID, expiresAt); err != nil {
+		return model.RefreshSession{}, fmt.Errorf("failed to prolong user session: %w", err)
+	}
+
+	next := model.RefreshSession{
+		ID:        newID,
+		FamilyID:  old.FamilyID,
+		UserID:    old.UserID,
+		ExpiresAt: expiresAt,
+	}
+
+	if _, err := tx.ExecContext(ctx, queryCreate, next.ID, next.FamilyID, next.UserID, next.ExpiresAt); err != nil {
+		return model.RefreshSession{}, fmt.Errorf("failed to create rotated refresh session: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return model.RefreshSession{}, fmt.Errorf("failed to commit refresh session rotation: %w", err)
+	}
+
+	return next, nil
+}
+
+// Время активности пишем не чаще раза в минуту, чтобы не делать UPDATE на каждый запрос
+const queryCheckActive = `
+	WITH s AS (
+		SELECT id FROM user_session
+		WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL AND expires_at > NOW()
+	), touched AS (
+		UPDATE user_session SET last_seen_at = NOW()
+		WHERE id IN (SELECT id FROM s) AND last_seen_at < NOW() - INTERVAL '1 minute'
+	)
+	SELECT EXISTS(SELECT 1 FROM s)
+`
+
+func (r *repo) CheckActive(ctx context.Context, sessionID, userID uuid.UUID) (bool, error) {
+	var active bool
+
+	if err := r.db.GetContext(ctx, &active, queryCheckActive, sessionID, userID); err != nil {
+		return false, fmt.Errorf("failed to check user session: %w", err)
+	}
+
+	return active, nil
+}
+
+type userSessionDTO struct {
+	ID         uuid.UUID  `db:"id"`
+	UserID     uuid.UUID  `db:"user_id"`
+	UserAgent  string     `db:"user_agent"`
+	IP         string     `db:"ip"`
+	ExpiresAt  time.Time  `db:"expires_at"`
+	CreatedAt  time.Time  `db:"created_at"`
+	LastSeenAt time.Time  `db:"last_seen_at"`
+	RevokedAt  *time.Time `db:"revoked_at"`
+}
+
+func (d *userSessionDTO) toModel() model.Session {
+	return model.Session{
+		ID:         d.ID,
+		UserID:     d.UserID,
+		UserAgent:  d.UserAgent,
+		IP:         d.IP,
+		ExpiresAt:  d.ExpiresAt,
+		CreatedAt:  d.CreatedAt,
+		LastSeenAt: d.LastSeenAt,
+		RevokedAt:  d.RevokedAt,
+	}
+}
+
+const queryGetActiveSessions = `
+	SELECT id, user_id, user_agent, ip, expires_at, created_at, last_seen_at, revoked_at
+	FROM user_session
+	WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > NOW()
+	ORDER BY last_seen_at DESC
+`
+
+func (r *repo) GetActiveSessions(ctx context.Context, userID uuid.UUID) ([]model.Session, error) {
+	var dtos []userSessionDTO
+
+	if err := r.db.SelectContext(ctx, &dtos, queryGetActiveSessions, userID); err != nil {
+		return nil, fmt.Errorf("failed to get user sessions: %w", err)
+	}
+
+	sessions := make([]model.Session, 0, len(dtos))
+	for _, d := range dtos {
+		sessions = append(sessions, d.toModel())
+	}
+
+	return sessions, nil
+}
+
+const queryRevokeSession = `
+	WITH s AS (
+		UPDATE user_session SET revoked_at = NOW()
+		WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL
+		RETURNING id
+	), refresh AS (
+		UPDATE refresh_session SET revoked_at = NOW()
+		WHERE family_id IN (SELECT id FROM s) AND revoked_at IS NULL
+	)
+	SELECT COUNT(*) FROM s
+`
+
+func (r *repo) RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error {
+	var revoked int64
+
+	if err := r.db.GetContext(ctx, &revoked, queryRevokeSession, sessionID, userID); err != nil {
+		return fmt.Errorf("failed to revoke user session: %w", err)
+	}
+
+	if revoked == 0 {
+		return ErrSessionNotFound
+	}
+
+	return nil
+}
+
+const queryRevokeOtherSessions = `
+	WITH s AS (
+		UPDATE user_session SET revoked_at = NOW()
+		WHERE user_id = $1 AND id <> $2 AND revoked_at IS NULL
+		RETURNING id
+	), refresh AS (
+		UPDATE refresh_session SET revoked_at = NOW()
+		WHERE user_id = $1 AND family_id <> $2 AND revoked_at IS NULL
+	)
+	SELECT COUNT(*) FROM s
+`
+
+func (r *repo) RevokeAllSessions(ctx context.Context, userID uuid.UUID) (int64, error) {
+	return r.RevokeOtherSessions(ctx, userID, uuid.Nil)
+}
+
+func (r *repo) RevokeOtherSessions(ctx context.Context, userID, currentID uuid.UUID) (int64, error) {
+	var revoked int64
+
+	if err := r.db.GetContext(ctx, &revoked, queryRevokeOtherSessions, userID, currentID); err != nil {
+		return 0, fmt.Errorf("failed to revoke user sessions: %w", err)
+	}
+
+	return revoked, nil
+}
@@ -9,6 +9,7 @@ type UserDTO struct {
 	Id            uuid.UUID `db:"id"`
 	OstrovokLogin string    `db:"ostrovok_login"`
 	IsAdmin       bool      `db:"is_admin"`
+	Status        string    `db:"status"`
 	Rating        int       `db:"rating"`
 }
 
@@ -17,6 +18,7 @@ func (d *UserDTO) ToUserModel() *model.User {
 		ID:            d.Id,
 		OstrovokLogin: d.OstrovokLogin,
 		IsAdmin:       d.IsAdmin,
+		Status:        model.Status(d.Status),
 		Rating:        d.Rating,
 	}
 }
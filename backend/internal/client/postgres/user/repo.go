@@ -20,8 +20,17 @@ type Repo interface {
 	GetUserById(ctx context.Context, userId uuid.UUID) (*model.User, error)
 	GetUserByReportId(ctx context.Context, reportId uuid.UUID) (*model.User, error)
 	UpdateRating(ctx context.Context, userId uuid.UUID, rating int) error
+	UpdatePasswordHash(ctx context.Context, userId uuid.UUID, passwordHash string) error
+	GetPasswordHash(ctx context.Context, userId uuid.UUID) (string, error)
 }
 
+// isAdminColumn вычисляет is_admin по ролям пользователя, колонка "user".is_admin больше не используется
+const isAdminColumn = `EXISTS(
+	SELECT 1 FROM user_role ur
+	INNER JOIN role ro ON ro.id = ur.role_id
+	WHERE ur.user_id = u.id AND ro.name = 'admin'
+) AS is_admin`
+
 type repo struct {
 	sqlClient *sqlx.DB
 }
@@ -33,7 +42,7 @@ func NewUserRepo(sqlClient *sqlx.DB) Repo {
 }
 
 func (r *repo) FindUserByLogin(ctx context.Context, login string) (*model.User, string, error) {
-	query := `SELECT id, ostrovok_login, password_hash, is_admin, rating FROM "user" WHERE ostrovok_login = $1`
+	query := `SELECT u.id, u.ostrovok_login, u.password_hash, ` + isAdminColumn + `, u.status, u.rating FROM "user" u WHERE u.ostrovok_login = $1`
 
 	var user model.User
 	var passwordHash string
@@ -43,12 +52,13 @@ func (r *repo) FindUserByLogin(ctx context.Context, login string) (*model.User,
 		&user.OstrovokLogin,
 		&passwordHash,
 		&user.IsAdmin,
+		&user.Status,
 		&user.Rating,
 	)
 
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, "", errors.New("user not found")
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, "", ErrUserNotFound
 		}
 		return nil, "", err
 	}
@@ -57,10 +67,28 @@ func (r *repo) FindUserByLogin(ctx context.Context, login string) (*model.User,
 }
 
 func (r *repo) CreateUser(ctx context.Context, user *model.User, passwordHash string) error {
-	query := `INSERT INTO "user" (id, ostrovok_login, password_hash, is_admin) VALUES ($1, $2, $3, $4)`
+	tx, err := r.sqlClient.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `INSERT INTO "user" (id, ostrovok_login, password_hash, status) VALUES ($1, $2, $3, $4)`
+
+	if _, err = tx.ExecContext(ctx, query, user.ID, user.OstrovokLogin, passwordHash, user.Status); err != nil {
+		return err
+	}
+
+	roleQuery := `
+		INSERT INTO user_role (user_id, role_id)
+		SELECT $1, r.id FROM role r WHERE r.name = ANY($2)
+	`
 
-	_, err := r.sqlClient.ExecContext(ctx, query, user.ID, user.OstrovokLogin, passwordHash, user.IsAdmin)
-	return err
+	if _, err = tx.ExecContext(ctx, roleQuery, user.ID, user.Roles); err != nil {
+		return fmt.Errorf("failed to assign user roles: %w", err)
+	}
+
+	return tx.Commit()
 }
 
 func (r *repo) UserExists(ctx context.Context, login string) (bool, error) {
@@ -72,7 +100,7 @@ func (r *repo) UserExists(ctx context.Context, login string) (bool, error) {
 }
 
 func (r *repo) GetUserById(ctx context.Context, userId uuid.UUID) (*model.User, error) {
-	query := `SELECT id, ostrovok_login, is_admin, rating FROM "user" WHERE id = $1`
+	query := `SELECT u.id, u.ostrovok_login, ` + isAdminColumn + `, u.status, u.rating FROM "user" u WHERE u.id = $1`
 
 	var user UserDTO
 
@@ -91,7 +119,7 @@ func (r *repo) GetUserById(ctx context.Context, userId uuid.UUID) (*model.User,
 
 func (r *repo) GetUserByReportId(ctx context.Context, reportId uuid.UUID) (*model.User, error) {
 	query := `
-		SELECT u.id, u.ostrovok_login, u.is_admin, u.rating 
+		SELECT u.id, u.ostrovok_login, ` + isAdminColumn + `, u.status, u.rating
 		FROM "user" u 
 		INNER JOIN application a ON a.user_id = u.id 
 		INNER JOIN report r ON r.application_id = a.id
@@ -135,3 +163,39 @@ func (r *repo) UpdateRating(ctx context.Context, userId uuid.UUID, rating int) e
 
 	return nil
 }
+
+func (r *repo) UpdatePasswordHash(ctx context.Context, userId uuid.UUID, passwordHash string) error {
+	query := `UPDATE "user" SET password_hash = $1 WHERE id = $2`
+
+	result, err := r.sqlClient.ExecContext(ctx, query, passwordHash, userId)
+	if err != nil {
+		return fmt.Errorf("failed to update password hash: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return ErrUserNotFound
+	}
+
+	return nil
+}
+
+func (r *repo) GetPasswordHash(ctx context.Context, userId uuid.UUID) (string, error) {
+	query := `SELECT password_hash FROM "user" WHERE id = $1`
+
+	var passwordHash string
+
+	if err := r.sqlClient.GetContext(ctx, &passwordHash, query, userId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", ErrUserNotFound
+		}
+
+		return "", fmt.Errorf("failed to get password hash: %w", err)
+	}
+
+	return passwordHash, nil
+}
@@ -0,0 +1,23 @@
+package verification
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrCodeNotFound       = errors.New("verification code not found")
+	ErrCodeExpired        = errors.New("verification code expired")
+	ErrCodeMismatch       = errors.New("verification code mismatch")
+	ErrCodeAttemptsExceed = errors.New("verification code attempts exceeded")
+)
+
+// ResendLimitError - новый код пока отправлять нельзя
+type ResendLimitError struct {
+	RetryAt time.Time
+}
+
+func (e *ResendLimitError) Error() string {
+	return fmt.Sprintf("verification code resend limit, retry at %s", e.RetryAt.Format(time.RFC3339))
+}
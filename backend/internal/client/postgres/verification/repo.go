@@ -0,0 +1,249 @@
+package verification
+
+import (
+	"context"
+	"crypto/subtle"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	model "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/user"
+)
+
+// Limits ограничивают отправку кодов. Отправки и неудачные попытки копятся за Window
+// и не сбрасываются новым кодом, иначе перебор кода обходится повторной отправкой
+type Limits struct {
+	Cooldown    time.Duration
+	MaxSends    int
+	MaxAttempts int
+	Window      time.Duration
+}
+
+type Repo interface {
+	// Save заменяет действующий код пользователя новым.
+	// Если лимиты исчерпаны, возвращает ResendLimitError
+	Save(ctx context.Context, userID uuid.UUID, codeHash string, expiresAt time.Time, limits Limits) error
+	// Restart начинает незавершенную регистрацию заново: сохраняет новый код по тем же лимитам,
+	// что и Save, и только после этого меняет пароль и завершает все сессии пользователя
+	Restart(ctx context.Context, userID uuid.UUID, codeHash string, expiresAt time.Time, limits Limits, passwordHash string) error
+	// Confirm проверяет код и в той же транзакции активирует пользователя и выдает ему роль.
+	// Каждая неудачная попытка учитывается, после maxAttempts за окно коды перестают приниматься.
+	Confirm(ctx context.Context, userID uuid.UUID, codeHash string, maxAttempts int, role string) error
+}
+
+type repo struct {
+	db *sqlx.DB
+}
+
+func NewRepo(db *sqlx.DB) Repo {
+	return &repo{db: db}
+}
+
+const queryInsert = `
+	INSERT INTO email_verification (user_id, code_hash, expires_at)
+	VALUES ($1, $2, $3)
+	ON CONFLICT (user_id) DO NOTHING
+`
+
+const queryGetSendsForUpdate = `
+	SELECT attempts, sends, COALESCE(created_at, window_started_at) AS created_at, window_started_at
+	FROM email_verification
+	WHERE user_id = $1
+	FOR UPDATE
+`
+
+const queryResend = `
+	UPDATE email_verification SET
+		code_hash = $2,
+		expires_at = $3,
+		attempts = $4,
+		sends = $5 + 1,
+		window_started_at = $6,
+		created_at = NOW()
+	WHERE user_id = $1
+`
+
+const queryUpdatePassword = `UPDATE "user" SET password_hash = $2 WHERE id = $1`
+
+const queryRevokeSessions = `
+	WITH s AS (
+		UPDATE user_session SET revoked_at = NOW()
+		WHERE user_id = $1 AND revoked_at IS NULL
+	)
+	UPDATE refresh_session SET revoked_at = NOW()
+	WHERE user_id = $1 AND revoked_at IS NULL
+`
+
+func (r *repo) Save(ctx context.Context, userID uuid.UUID, codeHash string, expiresAt time.Time, limits Limits) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := saveCode(ctx, tx, userID, codeHash, expiresAt, limits); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit verification code: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repo) Restart(
+	ctx context.Context,
+	userID uuid.UUID,
+	codeHash string,
+	expiresAt time.Time,
+	limits Limits,
+	passwordHash string,
+) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Лимит проверяется до смены пароля, иначе даже отклоненный запрос сбрасывал бы чужую регистрацию
+	if err := saveCode(ctx, tx, userID, codeHash, expiresAt, limits); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, queryUpdatePassword, userID, passwordHash); err != nil {
+		return fmt.Errorf("failed to update password hash: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, queryRevokeSessions, userID); err != nil {
+		return fmt.Errorf("failed to revoke pending sessions: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit verification code: %w", err)
+	}
+
+	return nil
+}
+
+func saveCode(ctx context.Context, tx *sqlx.Tx, userID uuid.UUID, codeHash string, expiresAt time.Time, limits Limits) error {
+	res, err := tx.ExecContext(ctx, queryInsert, userID, codeHash, expiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to save verification code: %w", err)
+	}
+
+	inserted, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if inserted == 0 {
+		var state struct {
+			Attempts        int       `db:"attempts"`
+			Sends           int       `db:"sends"`
+			CreatedAt       time.Time `db:"created_at"`
+			WindowStartedAt time.Time `db:"window_started_at"`
+		}
+
+		if err := tx.GetContext(ctx, &state, queryGetSendsForUpdate, userID); err != nil {
+			return fmt.Errorf("failed to get verification code: %w", err)
+		}
+
+		now := time.Now()
+		if !now.Before(state.WindowStartedAt.Add(limits.Window)) {
+			state.Attempts, state.Sends, state.WindowStartedAt = 0, 0, now
+		}
+
+		switch {
+		case state.Sends >= limits.MaxSends || state.Attempts >= limits.MaxAttempts:
+			return &ResendLimitError{RetryAt: state.WindowStartedAt.Add(limits.Window)}
+		case now.Before(state.CreatedAt.Add(limits.Cooldown)):
+			return &ResendLimitError{RetryAt: state.CreatedAt.Add(limits.Cooldown)}
+		}
+
+		_, err := tx.ExecContext(ctx, queryResend, userID, codeHash, expiresAt, state.Attempts, state.Sends, state.WindowStartedAt)
+		if err != nil {
+			return fmt.Errorf("failed to save verification code: %w", err)
+		}
+	}
+
+	return nil
+}
+
+const queryGetForUpdate = `
+	SELECT code_hash, attempts, expires_at
+	FROM email_verification
+	WHERE user_id = $1
+	FOR UPDATE
+`
+
+const queryIncAttempts = `UPDATE email_verification SET attempts = attempts + 1 WHERE user_id = $1`
+
+const queryDelete = `DELETE FROM email_verification WHERE user_id = $1`
+
+const queryActivate = `UPDATE "user" SET status = $2 WHERE id = $1`
+
+const queryAssignRole = `
+	INSERT INTO user_role (user_id, role_id)
+	SELECT $1, r.id FROM role r WHERE r.name = $2
+	ON CONFLICT DO NOTHING
+`
+
+func (r *repo) Confirm(ctx context.Context, userID uuid.UUID, codeHash string, maxAttempts int, role string) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var code struct {
+		CodeHash  string    `db:"code_hash"`
+		Attempts  int       `db:"attempts"`
+		ExpiresAt time.Time `db:"expires_at"`
+	}
+
+	if err := tx.GetContext(ctx, &code, queryGetForUpdate, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrCodeNotFound
+		}
+		return fmt.Errorf("failed to get verification code: %w", err)
+	}
+
+	switch {
+	case code.ExpiresAt.Before(time.Now()):
+		return ErrCodeExpired
+	case code.Attempts >= maxAttempts:
+		return ErrCodeAttemptsExceed
+	}
+
+	if subtle.ConstantTimeCompare([]byte(code.CodeHash), []byte(codeHash)) != 1 {
+		if _, err := tx.ExecContext(ctx, queryIncAttempts, userID); err != nil {
+			return fmt.Errorf("failed to count verification attempt: %w", err)
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("failed to commit verification attempt: %w", err)
+		}
+		return ErrCodeMismatch
+	}
+
+	if _, err := tx.ExecContext(ctx, queryDelete, userID); err != nil {
+		return fmt.Errorf("failed to delete verification code: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, queryActivate, userID, model.StatusActive); err != nil {
+		return fmt.Errorf("failed to activate user: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, queryAssignRole, userID, role); err != nil {
+		return fmt.Errorf("failed to assign role: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit verification: %w", err)
+	}
+
+	return nil
+}
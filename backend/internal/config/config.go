@@ -3,20 +3,27 @@ package config
 import (
 	"log"
 	"os"
+	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
 )
 
 type Config struct {
-	LoggerConfig   `yaml:"logger" env-required:"true"`
-	RestConfig     `yaml:"rest" env-required:"true"`
-	PostgresConfig `yaml:"postgres" env-required:"true"`
-	MinioConfig    `yaml:"minio" env-required:"true"`
+	LoggerConfig        `yaml:"logger" env-required:"true"`
+	RestConfig          `yaml:"rest" env-required:"true"`
+	PostgresConfig      `yaml:"postgres" env-required:"true"`
+	MinioConfig         `yaml:"minio" env-required:"true"`
+	NotifierConfig      `yaml:"notifier"`
+	JWTConfig           `yaml:"jwt" env-required:"true"`
+	OstrovokConfig      `yaml:"ostrovok"`
+	OfferTemplateConfig `yaml:"offer-template"`
 }
 
 type RestConfig struct {
 	Port        int    `yaml:"port" env-required:"true"`
 	AllowOrigin string `yaml:"allow_origin" env-required:"true"`
+	// TrustedProxies - CIDR прокси, которым можно верить в X-Forwarded-For. Пусто - IP клиента берется из соединения
+	TrustedProxies []string `yaml:"trusted_proxies"`
 }
 
 type PostgresConfig struct {
@@ -40,6 +47,58 @@ type MinioConfig struct {
 	PublicEndpoint string `yaml:"public-endpoint" env-required:"true"`
 }
 
+type NotifierConfig struct {
+	// Type - smtp, file или log. По умолчанию письма только пишутся в лог
+	Type     string     `yaml:"type" env-default:"log"`
+	FilePath string     `yaml:"file-path" env-default:"notifications.log"`
+	SMTP     SMTPConfig `yaml:"smtp"`
+	// ResetURL - ссылка на страницу сброса пароля, %s заменяется на токен
+	ResetURL string `yaml:"reset-url" env-default:"http://localhost:8080/reset-password?token=%s"`
+}
+
+type SMTPConfig struct {
+	Host     string `yaml:"host"`
+	Port     int    `yaml:"port" env-default:"587"`
+	Username string `yaml:"username" env:"SMTP_USERNAME"`
+	Password string `yaml:"password" env:"SMTP_PASSWORD"`
+	From     string `yaml:"from"`
+}
+
+type JWTConfig struct {
+	// SigningKeyID - kid ключа, которым подписываются новые токены
+	SigningKeyID string         `yaml:"signing-key-id" env:"JWT_SIGNING_KEY_ID" env-required:"true"`
+	Keys         []JWTKeyConfig `yaml:"keys" env-required:"true"`
+}
+
+// JWTKeyConfig - Ed25519 ключ в PEM. Для ключа, который только проверяет ранее выданные токены,
+// достаточно публичной части
+type JWTKeyConfig struct {
+	ID             string `yaml:"id"`
+	PrivateKeyFile string `yaml:"private-key-file"`
+	PublicKeyFile  string `yaml:"public-key-file"`
+}
+
+type OstrovokConfig struct {
+	// Mode - http или fake. fake отвечает тестовыми пользователями без обращения к сети
+	Mode               string        `yaml:"mode" env:"OSTROVOK_MODE" env-default:"fake"`
+	BaseURL            string        `yaml:"base-url" env:"OSTROVOK_BASE_URL"`
+	APIKey             string        `yaml:"api-key" env:"OSTROVOK_API_KEY"`
+	Timeout            time.Duration `yaml:"timeout" env-default:"3s"`
+	MaxRetries         int           `yaml:"max-retries" env-default:"3"`
+	RetryBaseDelay     time.Duration `yaml:"retry-base-delay" env-default:"100ms"`
+	RetryMaxDelay      time.Duration `yaml:"retry-max-delay" env-default:"2s"`
+	BreakerFailures    int           `yaml:"breaker-failures" env-default:"5"`
+	BreakerOpenTimeout time.Duration `yaml:"breaker-open-timeout" env-default:"30s"`
+	// CacheSize - сколько профилей держать в кэше, 0 отключает кэш
+	CacheSize int           `yaml:"cache-size" env-default:"1000"`
+	CacheTTL  time.Duration `yaml:"cache-ttl" env-default:"5m"`
+}
+
+type OfferTemplateConfig struct {
+	// Lookahead - на сколько вперед создавать офферы по шаблонам
+	Lookahead time.Duration `yaml:"lookahead" env-default:"720h"`
+}
+
 func MustLoadConfig() *Config {
 	configPath := os.Getenv("CONFIG_PATH")
 
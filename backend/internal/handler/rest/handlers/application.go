@@ -4,7 +4,6 @@ import (
 	"errors"
 	"log"
 	"net/http"
-	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -21,6 +20,7 @@ type ApplicationHandler interface {
 	CreateApplication(ctx *gin.Context)
 	GetApplications(ctx *gin.Context)
 	GetApplicationById(ctx *gin.Context)
+	WithdrawApplication(ctx *gin.Context)
 	GetUserAppLimitInfo(ctx *gin.Context)
 	GetAppsByFilter(ctx *gin.Context)
 }
@@ -37,7 +37,7 @@ func NewApplicationHandler(useCase application.ApplicationUseCase) ApplicationHa
 
 // Add godoc
 // @Summary Create application
-// @Description Creates application for given offer
+// @Description Creates application for given offer. If there are no free places, application is waitlisted
 // @Tags Application
 // @Accept json
 // @Param input body docs.CreateApplicationRequest true "Data for creating offer"
@@ -47,6 +47,7 @@ func NewApplicationHandler(useCase application.ApplicationUseCase) ApplicationHa
 // @Failure 400 {string} string "Invalid data for creating offer"
 // @Failure 401 "Unauthorized"
 // @Failure 403 "Only available for reviewer"
+// @Failure 409 {string} string "Offer is not accepting applications"
 // @Failure 500 "Internal server error"
 // @Router /application/ [post]
 func (h *applicationHandler) CreateApplication(ctx *gin.Context) {
@@ -74,7 +75,7 @@ func (h *applicationHandler) CreateApplication(ctx *gin.Context) {
 		ctx.String(http.StatusBadRequest, "invalid user_id")
 	}
 
-	appId, err := h.useCase.CreateApplication(ctx.Request.Context(), userId, offerId)
+	app, err := h.useCase.CreateApplication(ctx.Request.Context(), userId, offerId)
 
 	if err != nil {
 		log.Println("failed to create app", err)
@@ -82,10 +83,10 @@ func (h *applicationHandler) CreateApplication(ctx *gin.Context) {
 		switch {
 		case errors.Is(err, applicationRepo.ErrOfferNotExist):
 			ctx.String(http.StatusBadRequest, "offer does not exist")
+		case errors.Is(err, applicationRepo.ErrOfferNotOpen):
+			ctx.String(http.StatusConflict, "offer is not accepting applications")
 		case errors.Is(err, applicationRepo.ErrUserNotExist):
 			ctx.String(http.StatusBadRequest, "user does not exist")
-		case errors.Is(err, applicationRepo.ErrParticipantsLimit):
-			ctx.String(http.StatusBadRequest, "out of places")
 		case errors.Is(err, applicationRepo.ErrAppLimit):
 			ctx.String(http.StatusBadRequest, "reach limit of app")
 		default:
@@ -96,7 +97,8 @@ func (h *applicationHandler) CreateApplication(ctx *gin.Context) {
 	}
 
 	resp := &docs.CreateApplicationResponse{
-		ApplicationId: appId.String(),
+		ApplicationId: app.Id.String(),
+		Status:        string(app.Status),
 	}
 
 	ctx.JSON(http.StatusCreated, resp)
@@ -106,33 +108,20 @@ func (h *applicationHandler) CreateApplication(ctx *gin.Context) {
 // @Summary GetForPage applications
 // @Description GetForPage all applications with pagination
 // @Tags Application
-// @Param pageNum query int true "Number of page"
-// @Param pageSize query int true "Size of page"
+// @Param pageNum query int false "Number of page, from 0"
+// @Param cursor query string false "next_cursor from previous page, empty for the first page. Can not be used with pageNum"
+// @Param pageSize query int false "Size of page, 20 by default, at most 100"
 // @Produce json
 // @Security BearerAuth
-// @Success 200 {object} docs.GetApplicationsResponse "Page of applications"
+// @Success 200 {object} docs.GetApplicationsResponse "Page of applications, newest first"
 // @Failure 400 {string} string "Invalid data for getting applications"
 // @Failure 401 "Unauthorized"
 // @Failure 403 "Only available for reviewer"
-// @Failure 404 "Page with given number not found"
 // @Failure 500 "Internal server error"
 // @Router /application/ [get]
 func (h *applicationHandler) GetApplications(ctx *gin.Context) {
-	pageNumStr := ctx.Query("pageNum")
-	pageNum, err := strconv.Atoi(pageNumStr)
-
-	if pageNumStr == "" || err != nil {
-		log.Println("Invalid pageNum: ", pageNumStr)
-		ctx.String(http.StatusBadRequest, "invalid pageNum")
-		return
-	}
-
-	pageSizeStr := ctx.Query("pageSize")
-	pageSize, err := strconv.Atoi(pageSizeStr)
-
-	if pageSizeStr == "" || err != nil {
-		log.Println("Invalid pageSize: ", pageSizeStr)
-		ctx.String(http.StatusBadRequest, "invalid pageSize")
+	page, ok := parsePage(ctx)
+	if !ok {
 		return
 	}
 
@@ -144,14 +133,14 @@ func (h *applicationHandler) GetApplications(ctx *gin.Context) {
 		return
 	}
 
-	apps, count, err := h.useCase.GetApplications(ctx.Request.Context(), userId, pageNum, pageSize)
+	apps, err := h.useCase.GetApplications(ctx.Request.Context(), userId, page)
 
 	if err != nil {
 		log.Println("failed to get all applications", err)
 
 		switch {
-		case errors.Is(err, applicationRepo.ErrPageNotFound):
-			ctx.Status(http.StatusNotFound)
+		case errors.Is(err, pkg.ErrInvalidCursor):
+			ctx.String(http.StatusBadRequest, err.Error())
 		default:
 			ctx.Status(http.StatusInternalServerError)
 		}
@@ -159,15 +148,15 @@ func (h *applicationHandler) GetApplications(ctx *gin.Context) {
 		return
 	}
 
-	appsResp := make([]*docs.ApplicationResponse, 0, len(apps))
+	appsResp := make([]*docs.ApplicationResponse, 0, len(apps.Items))
 
-	for _, app := range apps {
+	for _, app := range apps.Items {
 		appsResp = append(appsResp, docs.ApplicationModelToResponse(app))
 	}
 
 	resp := &docs.GetApplicationsResponse{
 		Applications: appsResp,
-		PagesCount:   count,
+		PageResponse: docs.NewPageResponse(apps),
 	}
 
 	ctx.JSON(http.StatusOK, resp)
@@ -227,6 +216,62 @@ func (h *applicationHandler) GetApplicationById(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, resp)
 }
 
+// Add godoc
+// @Summary Withdraw application
+// @Description Withdraws own application before the draw. The freed place goes to the first waitlisted application
+// @Tags Application
+// @Param id path string true "Id of application"
+// @Security BearerAuth
+// @Success 204 "Application withdrawn"
+// @Failure 400 {string} string "Invalid application id"
+// @Failure 401 "Unauthorized"
+// @Failure 403 "Application does not belong to user"
+// @Failure 404 "Application with given id not found"
+// @Failure 409 {string} string "Offer is not accepting applications or application can not be withdrawn"
+// @Failure 500 "Internal server error"
+// @Router /application/{id}/withdraw [patch]
+func (h *applicationHandler) WithdrawApplication(ctx *gin.Context) {
+	idStr := ctx.Param("id")
+	id, err := uuid.Parse(idStr)
+
+	if idStr == "" || err != nil {
+		log.Println("invalid application id", idStr)
+		ctx.String(http.StatusBadRequest, "invalid application id")
+		return
+	}
+
+	userId, err := auth.GetUserId(ctx)
+
+	if err != nil {
+		log.Println("invalid user_id")
+		ctx.String(http.StatusBadRequest, "invalid user_id")
+		return
+	}
+
+	err = h.useCase.Withdraw(ctx.Request.Context(), userId, id)
+
+	if err != nil {
+		log.Println("failed to withdraw application", err)
+
+		switch {
+		case errors.Is(err, applicationRepo.ErrApplicationNotFound):
+			ctx.Status(http.StatusNotFound)
+		case errors.Is(err, application.ErrNotOwner):
+			ctx.Status(http.StatusForbidden)
+		case errors.Is(err, applicationRepo.ErrOfferNotOpen):
+			ctx.String(http.StatusConflict, "offer is not accepting applications")
+		case errors.Is(err, applicationRepo.ErrNotWithdrawable):
+			ctx.String(http.StatusConflict, "application can not be withdrawn")
+		default:
+			ctx.Status(http.StatusInternalServerError)
+		}
+
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
 // GetUserAppLimitInfo
 // Add godoc
 // @Summary GetUserAppLimitInfo
@@ -271,35 +316,22 @@ func (h *applicationHandler) GetUserAppLimitInfo(ctx *gin.Context) {
 // @Param hotelId query string false "Id of required hotel"
 // @Param roomId query string false "Id of required room"
 // @Param status query string false "status of app"
-// @Param pageNum query int true "Number of page"
-// @Param pageSize query int true "Size of page"
+// @Param pageNum query int false "Number of page, from 0"
+// @Param pageSize query int false "Size of page, 20 by default, at most 100"
 // @Produce json
 // @Security BearerAuth
 // @Success 200 {object} docs.GetApplicationsResponse "Page of applications by filter"
 // @Failure 400 {string} string "Invalid data for getting applications"
 // @Failure 401 "Unauthorized"
 // @Failure 403 "Only available for reviewer"
-// @Failure 404 "Page with given number not found"
 // @Failure 500 "Internal server error"
 // @Router /application/search [get]
 func (h *applicationHandler) GetAppsByFilter(ctx *gin.Context) {
-	pageNumStr := ctx.Query("pageNum")
-	pageNum, err := strconv.Atoi(pageNumStr)
-
-	if pageNumStr == "" || err != nil {
-		log.Println("Invalid pageNum: ", pageNumStr)
-		ctx.String(http.StatusBadRequest, "invalid pageNum")
+	page, ok := parsePageNum(ctx)
+	if !ok {
 		return
 	}
 
-	pageSizeStr := ctx.Query("pageSize")
-	pageSize, err := strconv.Atoi(pageSizeStr)
-
-	if pageSizeStr == "" || err != nil {
-		log.Println("Invalid pageSize: ", pageSizeStr)
-		ctx.String(http.StatusBadRequest, "invalid pageSize")
-		return
-	}
 	cityIdStr := ctx.Query("cityId")
 	var cityIdOpt pkg.Opt[uuid.UUID]
 	if cityIdStr != "" {
@@ -350,13 +382,14 @@ func (h *applicationHandler) GetAppsByFilter(ctx *gin.Context) {
 		HotelID:    hotelIdOpt,
 		RoomID:     roomIdOpt,
 		Status:     statusOpt,
-		Limit:      uint64(pageSize),
-		Offset:     uint64(pageNum * pageSize),
+		Limit:      page.Size,
+		Offset:     page.Offset(),
 	}
 	apps, count, err := h.useCase.GetByFilter(ctx, filter)
 	if err != nil {
 		log.Println("failed to get applications by filter", err)
 		ctx.Status(http.StatusInternalServerError)
+		return
 	}
 	appsResp := make([]*docs.ApplicationResponse, len(apps))
 
@@ -366,7 +399,7 @@ func (h *applicationHandler) GetAppsByFilter(ctx *gin.Context) {
 
 	resp := &docs.GetApplicationsResponse{
 		Applications: appsResp,
-		PagesCount:   count,
+		PageResponse: docs.PageResponse{PagesCount: count},
 	}
 
 	ctx.JSON(http.StatusOK, resp)
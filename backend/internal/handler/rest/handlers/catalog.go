@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/docs"
+	model "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/catalog"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/usecase/catalog"
+)
+
+// maxCatalogFeedSize - ограничение на размер загружаемого фида
+const maxCatalogFeedSize = 32 << 20
+
+type CatalogHandler interface {
+	ImportCatalog(ctx *gin.Context)
+}
+
+type catalogHandler struct {
+	useCase catalog.UseCase
+}
+
+func NewCatalogHandler(useCase catalog.UseCase) CatalogHandler {
+	return &catalogHandler{
+		useCase: useCase,
+	}
+}
+
+// ImportCatalog
+// Add godoc
+// @Summary Import catalog
+// @Description Upserts locations, hotels and room types from Ostrovok feed by external id
+// @Tags Catalog
+// @Accept multipart/form-data
+// @Param file formData file true "Feed in JSON lines or CSV"
+// @Param format query string false "jsonl or csv, by default taken from file extension"
+// @Param dryRun query bool false "Only report what would be changed"
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} docs.CatalogImportResponse "Import report"
+// @Failure 400 {string} string "Invalid feed"
+// @Failure 401 "Unauthorized"
+// @Failure 403 "Only available with catalog:write permission"
+// @Failure 500 "Internal server error"
+// @Router /catalog/import [post]
+func (h *catalogHandler) ImportCatalog(ctx *gin.Context) {
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxCatalogFeedSize)
+
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		ctx.String(http.StatusBadRequest, "file is required")
+		return
+	}
+
+	format := model.Format(ctx.Query("format"))
+	if format == "" {
+		format = catalog.FormatFromFileName(fileHeader.Filename)
+	}
+
+	dryRun := false
+	if dryRunStr := ctx.Query("dryRun"); dryRunStr != "" {
+		dryRun, err = strconv.ParseBool(dryRunStr)
+		if err != nil {
+			ctx.String(http.StatusBadRequest, "invalid dryRun")
+			return
+		}
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		log.Println("Err to open catalog feed: ", err.Error())
+		ctx.String(http.StatusBadRequest, "invalid file")
+		return
+	}
+	defer file.Close()
+
+	result, err := h.useCase.Import(ctx.Request.Context(), file, format, dryRun)
+	if err != nil {
+		log.Println("Err to import catalog: ", err.Error())
+		switch {
+		case errors.Is(err, catalog.ErrUnknownFormat):
+			ctx.String(http.StatusBadRequest, "unknown feed format, use jsonl or csv")
+		case errors.Is(err, catalog.ErrInvalidFeed):
+			ctx.String(http.StatusBadRequest, err.Error())
+		default:
+			ctx.Status(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, docs.CatalogImportResultToResponse(result))
+}
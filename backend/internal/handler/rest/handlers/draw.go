@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/docs"
+	drawRepo "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/draw"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/usecase/draw"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/pkg"
+)
+
+type DrawHandler interface {
+	GetOfferDraws(ctx *gin.Context)
+	GetDrawById(ctx *gin.Context)
+	ReplayDraw(ctx *gin.Context)
+}
+
+type drawHandler struct {
+	useCase draw.UseCase
+}
+
+func NewDrawHandler(useCase draw.UseCase) DrawHandler {
+	return &drawHandler{
+		useCase: useCase,
+	}
+}
+
+// GetOfferDraws
+// Add godoc
+// @Summary Get draws of offer
+// @Description Returns audit records of offer draws: candidates, ratings, weights, seed and winners
+// @Tags Draw
+// @Produce json
+// @Param id path string true "Offer ID"
+// @Security BearerAuth
+// @Success 200 {object} docs.GetDrawsResponse "Draws of offer, oldest first"
+// @Failure 400 {string} string "Invalid offer id"
+// @Failure 401 "Unauthorized"
+// @Failure 403 "Only available with draw:audit permission"
+// @Failure 500 "Internal server error"
+// @Router /offer/{id}/draws [get]
+func (h *drawHandler) GetOfferDraws(ctx *gin.Context) {
+	offerID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.String(http.StatusBadRequest, "invalid offer id")
+		return
+	}
+
+	draws, err := h.useCase.GetByOfferID(ctx.Request.Context(), offerID)
+	if err != nil {
+		log.Println("Err to get offer draws: ", err.Error())
+		ctx.Status(http.StatusInternalServerError)
+		return
+	}
+
+	resp := &docs.GetDrawsResponse{Draws: make([]*docs.DrawResponse, 0, len(draws))}
+	for _, d := range draws {
+		resp.Draws = append(resp.Draws, docs.DrawToResponse(d))
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// GetDrawById
+// Add godoc
+// @Summary Get draw by id
+// @Tags Draw
+// @Produce json
+// @Param id path string true "Draw ID"
+// @Security BearerAuth
+// @Success 200 {object} docs.DrawResponse "Draw"
+// @Failure 400 {string} string "Invalid draw id"
+// @Failure 401 "Unauthorized"
+// @Failure 403 "Only available with draw:audit permission"
+// @Failure 404 {string} string "Draw not found"
+// @Failure 500 "Internal server error"
+// @Router /draw/{id} [get]
+func (h *drawHandler) GetDrawById(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.String(http.StatusBadRequest, "invalid draw id")
+		return
+	}
+
+	d, err := h.useCase.GetByID(ctx.Request.Context(), id)
+	if err != nil {
+		log.Println("Err to get draw: ", err.Error())
+		writeDrawError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, docs.DrawToResponse(d))
+}
+
+// ReplayDraw
+// Add godoc
+// @Summary Replay draw
+// @Description Repeats draw with stored seed, candidates and algorithm version and checks that winners are the same
+// @Tags Draw
+// @Produce json
+// @Param id path string true "Draw ID"
+// @Security BearerAuth
+// @Success 200 {object} docs.ReplayDrawResponse "Replay result"
+// @Failure 400 {string} string "Invalid draw id"
+// @Failure 401 "Unauthorized"
+// @Failure 403 "Only available with draw:audit permission"
+// @Failure 404 {string} string "Draw not found"
+// @Failure 422 {string} string "Draw was made by unknown algorithm version"
+// @Failure 500 "Internal server error"
+// @Router /draw/{id}/replay [post]
+func (h *drawHandler) ReplayDraw(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.String(http.StatusBadRequest, "invalid draw id")
+		return
+	}
+
+	replay, err := h.useCase.Replay(ctx.Request.Context(), id)
+	if err != nil {
+		log.Println("Err to replay draw: ", err.Error())
+		writeDrawError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, docs.ReplayToResponse(replay))
+}
+
+func writeDrawError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, drawRepo.ErrNotFound):
+		ctx.String(http.StatusNotFound, err.Error())
+	case errors.Is(err, pkg.ErrUnknownDrawAlgorithm):
+		ctx.String(http.StatusUnprocessableEntity, err.Error())
+	default:
+		ctx.Status(http.StatusInternalServerError)
+	}
+}
@@ -7,21 +7,25 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/jmoiron/sqlx"
 	"github.com/minio/minio-go/v7"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/ostrovok"
 )
 
 type HealthHandler interface {
 	Health(ctx *gin.Context)
+	OstrovokCacheStats(ctx *gin.Context)
 }
 
 type healthHandler struct {
-	sqlClient   *sqlx.DB
-	minioClient *minio.Client
+	sqlClient      *sqlx.DB
+	minioClient    *minio.Client
+	ostrovokClient ostrovok.Client
 }
 
-func NewHealthHandler(sqlClient *sqlx.DB, minioClient *minio.Client) HealthHandler {
+func NewHealthHandler(sqlClient *sqlx.DB, minioClient *minio.Client, ostrovokClient ostrovok.Client) HealthHandler {
 	return &healthHandler{
-		sqlClient:   sqlClient,
-		minioClient: minioClient,
+		sqlClient:      sqlClient,
+		minioClient:    minioClient,
+		ostrovokClient: ostrovokClient,
 	}
 }
 
@@ -38,3 +42,14 @@ func (h *healthHandler) Health(ctx *gin.Context) {
 	}
 	ctx.String(http.StatusOK, "ok")
 }
+
+// OstrovokCacheStats отдает счетчики кэша профилей Островка
+func (h *healthHandler) OstrovokCacheStats(ctx *gin.Context) {
+	cached, ok := h.ostrovokClient.(ostrovok.CachedClient)
+	if !ok {
+		ctx.String(http.StatusNotFound, "ostrovok cache is disabled")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, cached.Stats())
+}
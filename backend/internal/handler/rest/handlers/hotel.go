@@ -2,19 +2,27 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"log"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/docs"
+	hotelRepo "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/hotel"
 	model "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/hotel"
 	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/usecase/hotel"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/pkg"
 )
 
 type HotelHandler interface {
 	CreateHotel(ctx *gin.Context)
 	GetHotels(ctx *gin.Context)
+	GetHotelById(ctx *gin.Context)
+	UpdateHotel(ctx *gin.Context)
+	DeleteHotel(ctx *gin.Context)
+	AddHotelImages(ctx *gin.Context)
+	DeleteHotelImage(ctx *gin.Context)
 }
 
 type hotelHandler struct {
@@ -40,6 +48,7 @@ func NewHotelHandler(useCase hotel.UseCase) HotelHandler {
 // @Failure 400 {string} string "Invalid data for creating hotel"
 // @Failure 401 "Unauthorized"
 // @Failure 403 "Only available for admin"
+// @Failure 409 {string} string "Hotel with this name already exists"
 // @Failure 500 "Internal server error"
 // @Router /hotel/ [post]
 func (h *hotelHandler) CreateHotel(ginCtx *gin.Context) {
@@ -59,11 +68,34 @@ func (h *hotelHandler) CreateHotel(ginCtx *gin.Context) {
 		return
 	}
 
-	create := model.Create{Name: request.Name, LocationID: locationID}
+	create := model.Create{
+		Name:        request.Name,
+		LocationID:  locationID,
+		Address:     request.Address,
+		Description: request.Description,
+		Contacts: model.Contacts{
+			Phone:   request.Phone,
+			Email:   request.Email,
+			Website: request.Website,
+		},
+	}
+
+	switch {
+	case request.Latitude != nil && request.Longitude != nil:
+		create.Point = pkg.NewWithValue(model.Point{Latitude: *request.Latitude, Longitude: *request.Longitude})
+	case request.Latitude != nil || request.Longitude != nil:
+		ginCtx.String(http.StatusBadRequest, "latitude and longitude must be set together")
+		return
+	}
+
+	if request.Stars != nil {
+		create.Stars = pkg.NewWithValue(*request.Stars)
+	}
+
 	id, err := h.useCase.Create(ctx, create)
 	if err != nil {
-		log.Println("Err to create location: ", err.Error())
-		ginCtx.String(http.StatusBadRequest, err.Error())
+		log.Println("Err to create hotel: ", err.Error())
+		writeHotelError(ginCtx, err)
 		return
 	}
 	resp := &docs.CreateOfferResponse{
@@ -76,33 +108,293 @@ func (h *hotelHandler) CreateHotel(ginCtx *gin.Context) {
 // GetHotels
 // Add godoc
 // @Summary Get hotels
-// @Description GetHotels all hotels
+// @Description GetHotels all hotels ordered by name
 // @Tags Hotel
+// @Param cursor query string false "next_cursor from previous page, empty for the first page. Without cursor the whole list is returned"
+// @Param pageSize query int false "Size of page, 20 by default, at most 100"
 // @Produce json
 // @Security BearerAuth
 // @Success 200 {object} docs.GetHotelsResponse "Page of hotels"
 // @Failure 400 {string} string "Invalid data for getting hotels"
 // @Failure 401 "Unauthorized"
 // @Failure 403 "Only available for admin"
-// @Failure 404 "Page with given number not found"
 // @Failure 500 "Internal server error"
 // @Router /hotel/ [get]
 func (h *hotelHandler) GetHotels(ginCtx *gin.Context) {
+	page, ok := parseCursorPage(ginCtx)
+	if !ok {
+		return
+	}
+
 	ctx := context.Background()
-	ucHotels, err := h.useCase.GetAll(ctx)
+	ucPage, err := h.useCase.GetPage(ctx, page)
 	if err != nil {
 		log.Println("Err to get hotels: ", err.Error())
 		ginCtx.String(http.StatusBadRequest, err.Error())
 		return
 	}
-	apiHotels := make([]*docs.HotelResponse, len(ucHotels))
-	for i, ucHotel := range ucHotels {
-		apiHotels[i] = &docs.HotelResponse{
-			Id:           ucHotel.ID.String(),
-			Name:         ucHotel.Name,
-			LocationId:   ucHotel.LocationID.String(),
-			LocationName: ucHotel.LocationName,
+	apiHotels := make([]*docs.HotelResponse, len(ucPage.Items))
+	for i, ucHotel := range ucPage.Items {
+		apiHotels[i] = convertUcHotelToApi(ucHotel)
+	}
+	ginCtx.JSON(http.StatusOK, docs.GetHotelsResponse{Hotels: apiHotels, PageResponse: docs.NewPageResponse(ucPage)})
+}
+
+// GetHotelById
+// Add godoc
+// @Summary Get hotel by id
+// @Description Returns hotel with contacts and cover images
+// @Tags Hotel
+// @Param id path string true "Hotel id"
+// @Produce json
+// @Success 200 {object} docs.HotelResponse "Hotel"
+// @Failure 400 {string} string "Invalid hotel id"
+// @Failure 404 {string} string "Hotel not found"
+// @Failure 500 "Internal server error"
+// @Router /hotel/{id} [get]
+func (h *hotelHandler) GetHotelById(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.String(http.StatusBadRequest, "invalid hotel id")
+		return
+	}
+
+	ucHotel, err := h.useCase.GetByID(ctx.Request.Context(), id)
+	if err != nil {
+		log.Println("Err to get hotel: ", err.Error())
+		writeHotelError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convertUcHotelToApi(ucHotel))
+}
+
+// UpdateHotel
+// Add godoc
+// @Summary Update hotel
+// @Description Changes only given fields of hotel
+// @Tags Hotel
+// @Accept json
+// @Param id path string true "Hotel id"
+// @Param input body docs.UpdateHotelRequest true "Fields to change"
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} docs.HotelResponse "Updated hotel"
+// @Failure 400 {string} string "Invalid data for updating hotel"
+// @Failure 401 "Unauthorized"
+// @Failure 403 "Only available with catalog:write permission"
+// @Failure 404 {string} string "Hotel not found"
+// @Failure 409 {string} string "Hotel with this name already exists"
+// @Failure 500 "Internal server error"
+// @Router /hotel/{id} [patch]
+func (h *hotelHandler) UpdateHotel(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.String(http.StatusBadRequest, "invalid hotel id")
+		return
+	}
+
+	var request docs.UpdateHotelRequest
+	if err := ctx.BindJSON(&request); err != nil {
+		log.Println("Invalid body")
+		ctx.String(http.StatusBadRequest, "invalid body")
+		return
+	}
+
+	update := model.Update{ID: id}
+
+	if request.Name != nil {
+		update.Name = pkg.NewWithValue(*request.Name)
+	}
+	if request.LocationID != nil {
+		locationID, err := uuid.Parse(*request.LocationID)
+		if err != nil {
+			ctx.String(http.StatusBadRequest, "invalid location_id")
+			return
 		}
+		update.LocationID = pkg.NewWithValue(locationID)
+	}
+	if request.Address != nil {
+		update.Address = pkg.NewWithValue(*request.Address)
+	}
+
+	switch {
+	case request.Latitude != nil && request.Longitude != nil:
+		update.Point = pkg.NewWithValue(model.Point{Latitude: *request.Latitude, Longitude: *request.Longitude})
+	case request.Latitude != nil || request.Longitude != nil:
+		ctx.String(http.StatusBadRequest, "latitude and longitude must be set together")
+		return
+	}
+
+	if request.Stars != nil {
+		update.Stars = pkg.NewWithValue(*request.Stars)
+	}
+	if request.Description != nil {
+		update.Description = pkg.NewWithValue(*request.Description)
+	}
+	if request.Phone != nil {
+		update.Phone = pkg.NewWithValue(*request.Phone)
+	}
+	if request.Email != nil {
+		update.Email = pkg.NewWithValue(*request.Email)
+	}
+	if request.Website != nil {
+		update.Website = pkg.NewWithValue(*request.Website)
+	}
+
+	updated, err := h.useCase.Update(ctx.Request.Context(), update)
+	if err != nil {
+		log.Println("Err to update hotel: ", err.Error())
+		writeHotelError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convertUcHotelToApi(updated))
+}
+
+// DeleteHotel
+// Add godoc
+// @Summary Delete hotel
+// @Description Marks hotel and its own rooms as deleted. Hotel with offers in progress or active templates can not be deleted
+// @Tags Hotel
+// @Param id path string true "Hotel id"
+// @Security BearerAuth
+// @Success 204 "Hotel deleted"
+// @Failure 400 {string} string "Invalid hotel id"
+// @Failure 401 "Unauthorized"
+// @Failure 403 "Only available with catalog:write permission"
+// @Failure 404 {string} string "Hotel not found"
+// @Failure 409 {string} string "Hotel has active offers"
+// @Failure 500 "Internal server error"
+// @Router /hotel/{id} [delete]
+func (h *hotelHandler) DeleteHotel(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.String(http.StatusBadRequest, "invalid hotel id")
+		return
+	}
+
+	if err := h.useCase.Delete(ctx.Request.Context(), id); err != nil {
+		log.Println("Err to delete hotel: ", err.Error())
+		writeHotelError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// AddHotelImages
+// Add godoc
+// @Summary Upload hotel cover images
+// @Description Appends images to the end of hotel cover list
+// @Tags Hotel
+// @Accept multipart/form-data
+// @Param id path string true "Hotel id"
+// @Param images formData file true "Images"
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} docs.HotelResponse "Hotel with images"
+// @Failure 400 {string} string "Invalid hotel id or no images"
+// @Failure 401 "Unauthorized"
+// @Failure 403 "Only available with catalog:write permission"
+// @Failure 404 {string} string "Hotel not found"
+// @Failure 500 "Internal server error"
+// @Router /hotel/{id}/images [post]
+func (h *hotelHandler) AddHotelImages(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.String(http.StatusBadRequest, "invalid hotel id")
+		return
+	}
+
+	form, err := ctx.MultipartForm()
+	if err != nil {
+		ctx.String(http.StatusBadRequest, "invalid form data")
+		return
+	}
+
+	updated, err := h.useCase.AddImages(ctx.Request.Context(), id, form.File["images"])
+	if err != nil {
+		log.Println("Err to add hotel images: ", err.Error())
+		writeHotelError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convertUcHotelToApi(updated))
+}
+
+// DeleteHotelImage
+// Add godoc
+// @Summary Delete hotel cover image
+// @Tags Hotel
+// @Param id path string true "Hotel id"
+// @Param imageId path string true "Image id"
+// @Security BearerAuth
+// @Success 204 "Image deleted"
+// @Failure 400 {string} string "Invalid id"
+// @Failure 401 "Unauthorized"
+// @Failure 403 "Only available with catalog:write permission"
+// @Failure 404 {string} string "Image not found"
+// @Failure 500 "Internal server error"
+// @Router /hotel/{id}/images/{imageId} [delete]
+func (h *hotelHandler) DeleteHotelImage(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.String(http.StatusBadRequest, "invalid hotel id")
+		return
+	}
+
+	imageID, err := uuid.Parse(ctx.Param("imageId"))
+	if err != nil {
+		ctx.String(http.StatusBadRequest, "invalid image id")
+		return
+	}
+
+	if err := h.useCase.DeleteImage(ctx.Request.Context(), id, imageID); err != nil {
+		log.Println("Err to delete hotel image: ", err.Error())
+		writeHotelError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func writeHotelError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, hotelRepo.ErrNotFound), errors.Is(err, hotelRepo.ErrImageNotFound):
+		ctx.String(http.StatusNotFound, err.Error())
+	case errors.Is(err, hotelRepo.ErrNameTaken), errors.Is(err, hotelRepo.ErrInUse):
+		ctx.String(http.StatusConflict, err.Error())
+	case errors.Is(err, hotelRepo.ErrLocationNotFound),
+		errors.Is(err, hotel.ErrInvalidCoordinates),
+		errors.Is(err, hotel.ErrInvalidStars),
+		errors.Is(err, hotel.ErrNoImages):
+		ctx.String(http.StatusBadRequest, err.Error())
+	default:
+		ctx.Status(http.StatusInternalServerError)
+	}
+}
+
+func convertUcHotelToApi(ucHotel model.Hotel) *docs.HotelResponse {
+	resp := &docs.HotelResponse{
+		Id:           ucHotel.ID.String(),
+		Name:         ucHotel.Name,
+		LocationId:   ucHotel.LocationID.String(),
+		LocationName: ucHotel.LocationName,
+		Address:      ucHotel.Address,
+		Latitude:     ucHotel.Latitude,
+		Longitude:    ucHotel.Longitude,
+		Stars:        ucHotel.Stars,
+		Description:  ucHotel.Description,
+		Phone:        ucHotel.Phone,
+		Email:        ucHotel.Email,
+		Website:      ucHotel.Website,
+	}
+	for _, image := range ucHotel.Images {
+		resp.Images = append(resp.Images, &docs.HotelImageResponse{
+			Id:   image.ID.String(),
+			Link: image.Link,
+		})
 	}
-	ginCtx.JSON(http.StatusOK, docs.GetHotelsResponse{Hotels: apiHotels})
+	return resp
 }
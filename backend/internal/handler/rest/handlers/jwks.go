@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/pkg"
+)
+
+type JWKSHandler interface {
+	GetJWKS(ctx *gin.Context)
+}
+
+type jwksHandler struct {
+	keys *pkg.JWTKeySet
+}
+
+func NewJWKSHandler(keys *pkg.JWTKeySet) JWKSHandler {
+	return &jwksHandler{
+		keys: keys,
+	}
+}
+
+// GetJWKS отдает публичные ключи, которыми другие сервисы Островка могут проверять наши токены
+func (h *jwksHandler) GetJWKS(ctx *gin.Context) {
+	ctx.Header("Cache-Control", "public, max-age=300")
+	ctx.JSON(http.StatusOK, h.keys.JWKS())
+}
@@ -2,17 +2,23 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"log"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/docs"
+	locationRepo "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/location"
 	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/usecase/location"
 )
 
 type LocationHandler interface {
 	CreateLocation(ctx *gin.Context)
 	GetLocations(ctx *gin.Context)
+	GetLocationById(ctx *gin.Context)
+	UpdateLocation(ctx *gin.Context)
+	DeleteLocation(ctx *gin.Context)
 }
 
 type locationHandler struct {
@@ -38,6 +44,7 @@ func NewLocationHandler(useCase location.UseCase) LocationHandler {
 // @Failure 400 {string} string "Invalid data for creating location"
 // @Failure 401 "Unauthorized"
 // @Failure 403 "Only available for admin"
+// @Failure 409 {string} string "Location with this name already exists"
 // @Failure 500 "Internal server error"
 // @Router /location/ [post]
 func (h *locationHandler) CreateLocation(ginCtx *gin.Context) {
@@ -52,7 +59,7 @@ func (h *locationHandler) CreateLocation(ginCtx *gin.Context) {
 	id, err := h.useCase.Create(ctx, request.Name)
 	if err != nil {
 		log.Println("Err to create location: ", err.Error())
-		ginCtx.String(http.StatusBadRequest, err.Error())
+		writeLocationError(ginCtx, err)
 		return
 	}
 	resp := &docs.CreateOfferResponse{
@@ -65,27 +72,33 @@ func (h *locationHandler) CreateLocation(ginCtx *gin.Context) {
 // GetLocations
 // Add godoc
 // @Summary Get locations
-// @Description GetLocations all locations
+// @Description GetLocations all locations ordered by name
 // @Tags Location
+// @Param cursor query string false "next_cursor from previous page, empty for the first page. Without cursor the whole list is returned"
+// @Param pageSize query int false "Size of page, 20 by default, at most 100"
 // @Produce json
 // @Security BearerAuth
 // @Success 200 {object} docs.GetLocationsResponse "Page of locations"
 // @Failure 400 {string} string "Invalid data for getting locations"
 // @Failure 401 "Unauthorized"
 // @Failure 403 "Only available for admin"
-// @Failure 404 "Page with given number not found"
 // @Failure 500 "Internal server error"
 // @Router /location/ [get]
 func (h *locationHandler) GetLocations(ginCtx *gin.Context) {
+	page, ok := parseCursorPage(ginCtx)
+	if !ok {
+		return
+	}
+
 	ctx := context.Background()
-	ucLocations, err := h.useCase.GetAll(ctx)
+	ucPage, err := h.useCase.GetPage(ctx, page)
 	if err != nil {
 		log.Println("Err to get locations: ", err.Error())
 		ginCtx.String(http.StatusBadRequest, err.Error())
 		return
 	}
-	apiLocations := make([]*docs.LocationResponse, len(ucLocations))
-	for i, ucLocation := range ucLocations {
+	apiLocations := make([]*docs.LocationResponse, len(ucPage.Items))
+	for i, ucLocation := range ucPage.Items {
 		apiLocations[i] = &docs.LocationResponse{
 			Id:   ucLocation.ID.String(),
 			Name: ucLocation.Name,
@@ -93,8 +106,120 @@ func (h *locationHandler) GetLocations(ginCtx *gin.Context) {
 	}
 
 	resp := &docs.GetLocationsResponse{
-		Locations: apiLocations,
+		Locations:    apiLocations,
+		PageResponse: docs.NewPageResponse(ucPage),
 	}
 
 	ginCtx.JSON(http.StatusOK, resp)
 }
+
+// GetLocationById
+// Add godoc
+// @Summary Get location by id
+// @Tags Location
+// @Param id path string true "Location id"
+// @Produce json
+// @Success 200 {object} docs.LocationResponse "Location"
+// @Failure 400 {string} string "Invalid location id"
+// @Failure 404 {string} string "Location not found"
+// @Failure 500 "Internal server error"
+// @Router /location/{id} [get]
+func (h *locationHandler) GetLocationById(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.String(http.StatusBadRequest, "invalid location id")
+		return
+	}
+
+	ucLocation, err := h.useCase.GetByID(ctx.Request.Context(), id)
+	if err != nil {
+		log.Println("Err to get location: ", err.Error())
+		writeLocationError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &docs.LocationResponse{Id: ucLocation.ID.String(), Name: ucLocation.Name})
+}
+
+// UpdateLocation
+// Add godoc
+// @Summary Rename location
+// @Tags Location
+// @Accept json
+// @Param id path string true "Location id"
+// @Param input body docs.UpdateLocationRequest true "New location name"
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} docs.LocationResponse "Updated location"
+// @Failure 400 {string} string "Invalid data for updating location"
+// @Failure 401 "Unauthorized"
+// @Failure 403 "Only available with catalog:write permission"
+// @Failure 404 {string} string "Location not found"
+// @Failure 409 {string} string "Location with this name already exists"
+// @Failure 500 "Internal server error"
+// @Router /location/{id} [patch]
+func (h *locationHandler) UpdateLocation(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.String(http.StatusBadRequest, "invalid location id")
+		return
+	}
+
+	var request docs.UpdateLocationRequest
+	if err := ctx.BindJSON(&request); err != nil {
+		log.Println("Invalid body")
+		ctx.String(http.StatusBadRequest, "invalid body")
+		return
+	}
+
+	updated, err := h.useCase.Rename(ctx.Request.Context(), id, request.Name)
+	if err != nil {
+		log.Println("Err to update location: ", err.Error())
+		writeLocationError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &docs.LocationResponse{Id: updated.ID.String(), Name: updated.Name})
+}
+
+// DeleteLocation
+// Add godoc
+// @Summary Delete location
+// @Description Marks location as deleted. Location with hotels can not be deleted
+// @Tags Location
+// @Param id path string true "Location id"
+// @Security BearerAuth
+// @Success 204 "Location deleted"
+// @Failure 400 {string} string "Invalid location id"
+// @Failure 401 "Unauthorized"
+// @Failure 403 "Only available with catalog:write permission"
+// @Failure 404 {string} string "Location not found"
+// @Failure 409 {string} string "Location has hotels"
+// @Failure 500 "Internal server error"
+// @Router /location/{id} [delete]
+func (h *locationHandler) DeleteLocation(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.String(http.StatusBadRequest, "invalid location id")
+		return
+	}
+
+	if err := h.useCase.Delete(ctx.Request.Context(), id); err != nil {
+		log.Println("Err to delete location: ", err.Error())
+		writeLocationError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func writeLocationError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, locationRepo.ErrNotFound):
+		ctx.String(http.StatusNotFound, err.Error())
+	case errors.Is(err, locationRepo.ErrNameTaken), errors.Is(err, locationRepo.ErrHasHotels):
+		ctx.String(http.StatusConflict, err.Error())
+	default:
+		ctx.Status(http.StatusInternalServerError)
+	}
+}
@@ -1,12 +1,17 @@
 package handlers
 
 import (
+	"errors"
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	offerRepo "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/offer"
+	hotelModel "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/hotel"
 	model "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/offer"
 	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/usecase/offer"
 	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/pkg"
@@ -20,6 +25,10 @@ type OfferHandler interface {
 	GetOfferById(ctx *gin.Context)
 	FindOffers(ctx *gin.Context)
 	UpdateOffer(ctx *gin.Context)
+	PublishOffer(ctx *gin.Context)
+	CancelOffer(ctx *gin.Context)
+	ImportOffers(ctx *gin.Context)
+	GetOffersMap(ctx *gin.Context)
 }
 
 type offerHandler struct {
@@ -85,9 +94,16 @@ func (h *offerHandler) CreateOffer(ctx *gin.Context) {
 		ExpirationAT:      request.ExpirationAt,
 		Task:              request.Task,
 		ParticipantsLimit: request.ParticipantsLimit,
+		WinnersCount:      request.WinnersCount,
 		CheckIn:           request.CheckIn,
 		CheckOut:          request.CheckOut,
 		RoomID:            roomId,
+		Status:            model.StatusPublished,
+		Checklist:         docs.ChecklistFromRequest(request.Checklist),
+	}
+
+	if request.Draft {
+		create.Status = model.StatusDraft
 	}
 
 	id, err := h.useCase.Create(ctx, create)
@@ -109,8 +125,8 @@ func (h *offerHandler) CreateOffer(ctx *gin.Context) {
 // @Summary GetForPage offers
 // @Description GetForPage all offers with pagination
 // @Tags Offer
-// @Param pageNum query int true "Number of page"
-// @Param pageSize query int true "Size of page"
+// @Param pageNum query int false "Number of page, from 0"
+// @Param pageSize query int false "Size of page, 20 by default, at most 100"
 // @Produce json
 // @Security BearerAuth
 // @Success 200 {object} docs.GetOffersResponse "Page of offers"
@@ -121,28 +137,12 @@ func (h *offerHandler) CreateOffer(ctx *gin.Context) {
 // @Failure 500 "Internal server error"
 // @Router /offer/ [get]
 func (h *offerHandler) GetOffers(ctx *gin.Context) {
-	pageNumStr := ctx.Query("pageNum")
-	pageNum, err := strconv.ParseUint(pageNumStr, 10, 0)
-
-	if pageNumStr == "" || err != nil {
-		log.Println("Invalid pageNum: ", pageNumStr)
-		ctx.String(http.StatusBadRequest, "invalid pageNum")
+	page, ok := parsePageNum(ctx)
+	if !ok {
 		return
 	}
 
-	pageSizeStr := ctx.Query("pageSize")
-	pageSize, err := strconv.ParseUint(pageSizeStr, 10, 0)
-
-	if err != nil {
-		log.Println("Invalid pageSize: ", pageSizeStr)
-		ctx.String(http.StatusBadRequest, "invalid pageSize")
-		return
-	}
-	pageSettings := model.PageSettings{
-		Limit:  pageSize,
-		Offset: pageNum * pageSize,
-	}
-	ucOffers, pagesCount, err := h.useCase.GetForPage(ctx, pageSettings)
+	ucPage, err := h.useCase.GetForPage(ctx, page)
 
 	if err != nil {
 		log.Println("Err to get offers for page: ", err.Error())
@@ -151,8 +151,8 @@ func (h *offerHandler) GetOffers(ctx *gin.Context) {
 	}
 
 	resp := &docs.GetOffersResponse{
-		Offers:     convertUcOffersToApi(ucOffers),
-		PagesCount: pagesCount,
+		Offers:       convertUcOffersToApi(ucPage.Items),
+		PageResponse: docs.NewPageResponse(ucPage),
 	}
 
 	ctx.JSON(http.StatusOK, resp)
@@ -191,16 +191,29 @@ func (h *offerHandler) GetOfferById(ctx *gin.Context) {
 
 	resp := convertUcOfferToApi(ucOffer)
 
+	ctx.Header("ETag", offerETag(ucOffer.Version))
 	ctx.JSON(http.StatusOK, resp)
 }
 
 // Add godoc
 // @Summary Find offers
-// @Description Find offers with given search params
+// @Description Find offers with given search params. Dates are RFC3339 or YYYY-MM-DD, checkOut date includes the whole day
 // @Tags Offer
-// @Param cityId query string true "Id of required city"
-// @Param pageNum query int true "Number of page"
-// @Param pageSize query int true "Size of page"
+// @Param cityId query string false "Id of required city"
+// @Param hotelId query string false "Id of hotel"
+// @Param roomId query string false "Id of room type"
+// @Param checkIn query string false "Stay starts not earlier than this date"
+// @Param checkOut query string false "Stay ends not later than this date"
+// @Param q query string false "Full-text search in task"
+// @Param onlyOpen query bool false "Only offers open for applications: published, not expired and not full"
+// @Param lat query number false "Latitude of search point, hotels without coordinates are skipped"
+// @Param lon query number false "Longitude of search point"
+// @Param radiusKm query number false "Max distance from search point in km, requires lat and lon"
+// @Param sort query string false "expiration, check_in, remaining_slots or distance (requires lat and lon)"
+// @Param order query string false "asc (default) or desc"
+// @Param pageNum query int false "Number of page, from 0"
+// @Param cursor query string false "next_cursor from previous page, empty for the first page. Can not be used with pageNum"
+// @Param pageSize query int false "Size of page, 20 by default, at most 100"
 // @Produce json
 // @Security BearerAuth
 // @Success 200 {object} docs.GetOffersResponse "Page of offers found"
@@ -211,54 +224,109 @@ func (h *offerHandler) GetOfferById(ctx *gin.Context) {
 // @Failure 500 "Internal server error"
 // @Router /offer/search [get]
 func (h *offerHandler) FindOffers(ctx *gin.Context) {
-	pageNumStr := ctx.Query("pageNum")
-	pageNum, err := strconv.ParseUint(pageNumStr, 10, 0)
-
-	if pageNumStr == "" || err != nil {
-		log.Println("Invalid pageNum: ", pageNumStr)
-		ctx.String(http.StatusBadRequest, "invalid pageNum")
+	page, ok := parsePage(ctx)
+	if !ok {
 		return
 	}
 
-	pageSizeStr := ctx.Query("pageSize")
-	pageSize, err := strconv.ParseUint(pageSizeStr, 10, 0)
+	filter := model.Filter{
+		Statuses: model.VisibleStatuses,
+	}
 
-	if err != nil {
-		log.Println("Invalid pageSize: ", pageSizeStr)
-		ctx.String(http.StatusBadRequest, "invalid pageSize")
-		return
+	for param, target := range map[string]*pkg.Opt[uuid.UUID]{
+		"cityId":  &filter.LocationID,
+		"hotelId": &filter.HotelID,
+		"roomId":  &filter.RoomID,
+	} {
+		value := ctx.Query(param)
+		if value == "" {
+			continue
+		}
+		id, err := uuid.Parse(value)
+		if err != nil {
+			log.Println("Fail to parse id: ", err.Error())
+			ctx.String(http.StatusBadRequest, "invalid "+param)
+			return
+		}
+		*target = pkg.NewWithValue(id)
 	}
 
-	cityIdStr := ctx.Query("cityId")
+	if value := ctx.Query("checkIn"); value != "" {
+		checkIn, _, err := parseSearchDate(value)
+		if err != nil {
+			ctx.String(http.StatusBadRequest, "invalid checkIn")
+			return
+		}
+		filter.CheckInFrom = pkg.NewWithValue(checkIn)
+	}
 
-	if cityIdStr == "" {
-		log.Println("Invalid cityId: ", cityIdStr)
-		ctx.String(http.StatusBadRequest, "invalid cityId")
-		return
+	if value := ctx.Query("checkOut"); value != "" {
+		checkOut, dateOnly, err := parseSearchDate(value)
+		if err != nil {
+			ctx.String(http.StatusBadRequest, "invalid checkOut")
+			return
+		}
+		// Выезд в указанный день в любое время подходит
+		if dateOnly {
+			checkOut = checkOut.AddDate(0, 0, 1).Add(-time.Microsecond)
+		}
+		filter.CheckOutTo = pkg.NewWithValue(checkOut)
 	}
-	cityId, err := uuid.Parse(cityIdStr)
-	if err != nil {
-		log.Println("Fail to parse id: ", err.Error())
-		ctx.String(http.StatusBadRequest, err.Error())
+
+	if q := strings.TrimSpace(ctx.Query("q")); q != "" {
+		filter.Query = pkg.NewWithValue(q)
+	}
+
+	if value := ctx.Query("onlyOpen"); value != "" {
+		onlyOpen, err := strconv.ParseBool(value)
+		if err != nil {
+			ctx.String(http.StatusBadRequest, "invalid onlyOpen")
+			return
+		}
+		filter.OnlyOpen = onlyOpen
+	}
+
+	if !parseGeoQuery(ctx, &filter) {
 		return
 	}
 
-	filter := model.Filter{
-		LocationID: pkg.NewWithValue(cityId),
-		Limit:      pageSize,
-		Offset:     pageNum * pageSize,
+	if value := ctx.Query("sort"); value != "" {
+		filter.Sort = model.Sort(value)
+		if !filter.Sort.Valid() {
+			ctx.String(http.StatusBadRequest, "invalid sort, use expiration, check_in, remaining_slots or distance")
+			return
+		}
+		if _, near := filter.Near.Get(); filter.Sort == model.SortDistance && !near {
+			ctx.String(http.StatusBadRequest, "sort by distance requires lat and lon")
+			return
+		}
+	}
+
+	switch ctx.Query("order") {
+	case "", "asc":
+	case "desc":
+		filter.Desc = true
+	default:
+		ctx.String(http.StatusBadRequest, "invalid order, use asc or desc")
+		return
 	}
-	ucOffers, pagesCount, err := h.useCase.GetByFilter(ctx, filter)
+
+	ucPage, err := h.useCase.GetByFilter(ctx, filter, page)
 
 	if err != nil {
 		log.Println("Err to find offers by filter: ", err.Error())
-		ctx.String(http.StatusBadRequest, err.Error())
+		if errors.Is(err, pkg.ErrInvalidCursor) || errors.Is(err, pkg.ErrInvalidPageSize) ||
+			errors.Is(err, pkg.ErrInvalidPageNum) {
+			ctx.String(http.StatusBadRequest, err.Error())
+			return
+		}
+		ctx.Status(http.StatusInternalServerError)
 		return
 	}
 
 	resp := &docs.GetOffersResponse{
-		Offers:     convertUcOffersToApi(ucOffers),
-		PagesCount: pagesCount,
+		Offers:       convertUcOffersToApi(ucPage.Items),
+		PageResponse: docs.NewPageResponse(ucPage),
 	}
 
 	ctx.JSON(http.StatusOK, resp)
@@ -266,18 +334,23 @@ func (h *offerHandler) FindOffers(ctx *gin.Context) {
 
 // Add godoc
 // @Summary Update offer
-// @Description Update offer with given id and data
+// @Description Update offer with given id and data. Only fields that are set are changed.
+// @Description Version of offer (ETag of GET /offer/{id}) must be passed in If-Match header or in body
 // @Tags Offer
 // @Accept json
 // @Param id path string true "Id of offer to update"
+// @Param If-Match header string false "ETag of offer"
 // @Param input body docs.UpdateOfferRequest true "Data for updating offer"
 // @Produce json
 // @Security BearerAuth
-// @Success 200 "Successfully updated offer"
-// @Failure 400 {string} string "Invalid data for finding offers"
+// @Success 200 {object} docs.OfferResponse "Updated offer"
+// @Failure 400 {string} string "Invalid data for updating offer"
 // @Failure 401 "Unauthorized"
 // @Failure 403 "Only available for admin"
-// @Failure 404 "Offer with given id not found"
+// @Failure 404 {string} string "Offer with given id not found"
+// @Failure 409 {string} string "Draw has already started or offer for this hotel, room and dates already exists"
+// @Failure 412 {string} string "Offer has been changed by someone else"
+// @Failure 428 {string} string "Version of offer is required"
 // @Failure 500 "Internal server error"
 // @Router /offer/{id} [patch]
 func (h *offerHandler) UpdateOffer(ctx *gin.Context) {
@@ -297,48 +370,216 @@ func (h *offerHandler) UpdateOffer(ctx *gin.Context) {
 		ctx.String(http.StatusBadRequest, "invalid offer id")
 		return
 	}
-	var (
-		count int
-		edit  model.Edit
-	)
-	edit.OfferID = id
+
+	edit := model.Edit{
+		OfferID: id,
+		Version: request.Version,
+	}
+
+	if ifMatch := ctx.GetHeader("If-Match"); ifMatch != "" {
+		edit.Version, err = parseOfferETag(ifMatch)
+		if err != nil {
+			ctx.String(http.StatusBadRequest, "invalid If-Match")
+			return
+		}
+	}
+
+	if edit.Version == 0 {
+		ctx.String(http.StatusPreconditionRequired, "offer version is required")
+		return
+	}
+
 	if request.Task != "" {
 		edit.Task = pkg.NewWithValue(request.Task)
-		count++
 	}
 	if request.RoomID != "" {
 		roomID, err := uuid.Parse(request.RoomID)
 		if err != nil {
-			log.Println("invalid room id", idStr)
+			log.Println("invalid room id", request.RoomID)
 			ctx.String(http.StatusBadRequest, "invalid room id")
 			return
 		}
 		edit.RoomID = pkg.NewWithValue(roomID)
-		count++
 	}
 	if request.HotelID != "" {
 		hotelID, err := uuid.Parse(request.HotelID)
 		if err != nil {
-			log.Println("invalid hotel id", idStr)
+			log.Println("invalid hotel id", request.HotelID)
 			ctx.String(http.StatusBadRequest, "invalid hotel id")
 			return
 		}
-		edit.RoomID = pkg.NewWithValue(hotelID)
-		count++
+		edit.HotelID = pkg.NewWithValue(hotelID)
+	}
+	if !request.CheckIn.IsZero() {
+		edit.CheckIn = pkg.NewWithValue(request.CheckIn)
+	}
+	if !request.CheckOut.IsZero() {
+		edit.CheckOut = pkg.NewWithValue(request.CheckOut)
+	}
+	if !request.ExpirationAT.IsZero() {
+		edit.ExpirationAT = pkg.NewWithValue(request.ExpirationAT)
+	}
+	if request.ParticipantsLimit != nil {
+		edit.ParticipantsLimit = pkg.NewWithValue(*request.ParticipantsLimit)
+	}
+	if request.WinnersCount != nil {
+		edit.WinnersCount = pkg.NewWithValue(*request.WinnersCount)
+	}
+	if request.Checklist != nil {
+		edit.Checklist = pkg.NewWithValue(docs.ChecklistFromRequest(request.Checklist))
 	}
-	//TODO Validation
-	edit.CheckIn = pkg.NewWithValue(request.CheckIn)
-	edit.CheckOut = pkg.NewWithValue(request.CheckOut)
-	edit.ExpirationAT = pkg.NewWithValue(request.ExpirationAT)
 
-	err = h.useCase.Edit(ctx, edit)
+	updated, err := h.useCase.Edit(ctx.Request.Context(), edit)
 	if err != nil {
 		log.Println("Err to update offer by id: ", err.Error())
-		ctx.String(http.StatusBadRequest, err.Error())
+		switch {
+		case errors.Is(err, offer.ErrWrongNumOffers):
+			ctx.String(http.StatusNotFound, "offer not found")
+		case errors.Is(err, offer.ErrOfferLocked):
+			ctx.String(http.StatusConflict, err.Error())
+		case errors.Is(err, offerRepo.ErrStatusChanged):
+			ctx.String(http.StatusPreconditionFailed, "offer has been changed, reload it and try again")
+		case errors.Is(err, offerRepo.ErrOfferExists):
+			ctx.String(http.StatusConflict, err.Error())
+		case errors.Is(err, offer.ErrExpirationAfterCheckIn),
+			errors.Is(err, offer.ErrCheckOutBeforeCheckIn),
+			errors.Is(err, offer.ErrInvalidParticipantsLimit),
+			errors.Is(err, offer.ErrInvalidWinnersCount),
+			errors.Is(err, offer.ErrInvalidChecklist),
+			errors.Is(err, offer.ErrHotelNotFound),
+			errors.Is(err, offer.ErrRoomNotFound),
+			errors.Is(err, offer.ErrRoomNotInHotel),
+			errors.Is(err, offerRepo.ErrHotelOrRoomNotFound):
+			ctx.String(http.StatusBadRequest, err.Error())
+		default:
+			ctx.Status(http.StatusInternalServerError)
+		}
 		return
 	}
 
-	ctx.Status(http.StatusOK)
+	ctx.Header("ETag", offerETag(updated.Version))
+	ctx.JSON(http.StatusOK, convertUcOfferToApi(updated))
+}
+
+// parseGeoQuery читает точку и радиус поиска. При ошибке отвечает 400 и возвращает false
+func parseGeoQuery(ctx *gin.Context, filter *model.Filter) bool {
+	latStr, lonStr, radiusStr := ctx.Query("lat"), ctx.Query("lon"), ctx.Query("radiusKm")
+	if latStr == "" && lonStr == "" {
+		if radiusStr != "" {
+			ctx.String(http.StatusBadRequest, "radiusKm requires lat and lon")
+			return false
+		}
+		return true
+	}
+
+	lat, latErr := strconv.ParseFloat(latStr, 64)
+	lon, lonErr := strconv.ParseFloat(lonStr, 64)
+	point := hotelModel.Point{Latitude: lat, Longitude: lon}
+	if latErr != nil || lonErr != nil || !point.Valid() {
+		ctx.String(http.StatusBadRequest, "invalid lat or lon")
+		return false
+	}
+	filter.Near = pkg.NewWithValue(point)
+
+	if radiusStr != "" {
+		radius, err := strconv.ParseFloat(radiusStr, 64)
+		if err != nil || radius <= 0 {
+			ctx.String(http.StatusBadRequest, "invalid radiusKm")
+			return false
+		}
+		filter.RadiusKm = pkg.NewWithValue(radius)
+	}
+
+	return true
+}
+
+// parseSearchDate принимает RFC3339 или дату без времени, второе значение - была ли передана только дата
+func parseSearchDate(value string) (time.Time, bool, error) {
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		return t, true, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	return t, false, err
+}
+
+func offerETag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+func parseOfferETag(etag string) (int, error) {
+	etag = strings.TrimPrefix(strings.TrimSpace(etag), "W/")
+	return strconv.Atoi(strings.Trim(etag, `"`))
+}
+
+// Add godoc
+// @Summary Publish offer
+// @Description Publishes draft offer so reviewers can apply to it
+// @Tags Offer
+// @Param id path string true "Id of offer to publish"
+// @Security BearerAuth
+// @Success 204 "Offer published"
+// @Failure 400 {string} string "Invalid offer id"
+// @Failure 401 "Unauthorized"
+// @Failure 403 "Only available for admin"
+// @Failure 404 {string} string "Offer with given id not found"
+// @Failure 409 {string} string "Offer can not be published from its current status"
+// @Failure 500 "Internal server error"
+// @Router /offer/{id}/publish [patch]
+func (h *offerHandler) PublishOffer(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.String(http.StatusBadRequest, "invalid offer id")
+		return
+	}
+
+	if err := h.useCase.Publish(ctx.Request.Context(), id); err != nil {
+		log.Println("Err to publish offer: ", err.Error())
+		writeOfferStatusError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// Add godoc
+// @Summary Cancel offer
+// @Description Cancels offer, declines pending applications and notifies their authors
+// @Tags Offer
+// @Param id path string true "Id of offer to cancel"
+// @Security BearerAuth
+// @Success 204 "Offer cancelled"
+// @Failure 400 {string} string "Invalid offer id"
+// @Failure 401 "Unauthorized"
+// @Failure 403 "Only available for admin"
+// @Failure 404 {string} string "Offer with given id not found"
+// @Failure 409 {string} string "Offer can not be cancelled from its current status, e.g. after draw"
+// @Failure 500 "Internal server error"
+// @Router /offer/{id}/cancel [patch]
+func (h *offerHandler) CancelOffer(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.String(http.StatusBadRequest, "invalid offer id")
+		return
+	}
+
+	if err := h.useCase.Cancel(ctx.Request.Context(), id); err != nil {
+		log.Println("Err to cancel offer: ", err.Error())
+		writeOfferStatusError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func writeOfferStatusError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, offer.ErrWrongNumOffers):
+		ctx.String(http.StatusNotFound, "offer not found")
+	case errors.Is(err, offer.ErrInvalidTransition), errors.Is(err, offerRepo.ErrStatusChanged):
+		ctx.String(http.StatusConflict, err.Error())
+	default:
+		ctx.Status(http.StatusInternalServerError)
+	}
 }
 
 func convertUcOffersToApi(ucOffers []model.Offer) []*docs.OfferResponse {
@@ -357,10 +598,143 @@ func convertUcOfferToApi(ucOffer model.Offer) *docs.OfferResponse {
 		RoomName:          ucOffer.RoomName,
 		HotelID:           ucOffer.HotelID.String(),
 		HotelName:         ucOffer.HotelName,
+		HotelAddress:      ucOffer.HotelAddress,
+		HotelLatitude:     ucOffer.HotelLatitude,
+		HotelLongitude:    ucOffer.HotelLongitude,
+		DistanceKm:        ucOffer.DistanceKm,
 		CheckIn:           ucOffer.CheckIn,
 		CheckOut:          ucOffer.CheckOut,
 		ExpirationAt:      ucOffer.ExpirationAt,
 		ParticipantsLimit: ucOffer.ParticipantsLimit,
 		ParticipantsCount: ucOffer.ParticipantsCount,
+		WinnersCount:      ucOffer.WinnersCount,
+		AwardedCount:      ucOffer.AwardedCount,
+		Status:            string(ucOffer.Status),
+		Version:           ucOffer.Version,
+		Checklist:         docs.ChecklistToResponse(ucOffer.Checklist),
 	}
 }
+
+// maxOffersFileSize - ограничение на размер файла с офферами
+const maxOffersFileSize = 16 << 20
+
+// ImportOffers
+// Add godoc
+// @Summary Import offers
+// @Description Creates offers from CSV or XLSX file with header hotel,room,task,check_in,check_out,expiration_at,participants_limit
+// @Description and optional winners_count,draft. Hotel and room are matched by id or name.
+// @Description Offers are created all at once: if any row is invalid, nothing is created and errors are returned for every row
+// @Tags Offer
+// @Accept multipart/form-data
+// @Param file formData file true "Offers in CSV or XLSX"
+// @Param format query string false "csv or xlsx, by default taken from file extension"
+// @Param dryRun query bool false "Only validate rows, do not create offers"
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} docs.OfferImportResponse "Dry run passed"
+// @Success 201 {object} docs.OfferImportResponse "Offers created"
+// @Failure 400 {string} string "Invalid file"
+// @Failure 401 "Unauthorized"
+// @Failure 403 "Only available with offer:write permission"
+// @Failure 422 {object} docs.OfferImportResponse "Some rows are invalid, nothing created"
+// @Failure 500 "Internal server error"
+// @Router /offer/import [post]
+func (h *offerHandler) ImportOffers(ctx *gin.Context) {
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxOffersFileSize)
+
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		ctx.String(http.StatusBadRequest, "file is required")
+		return
+	}
+
+	format := model.ImportFormat(ctx.Query("format"))
+	if format == "" {
+		format = offer.ImportFormatFromFileName(fileHeader.Filename)
+	}
+
+	dryRun := false
+	if dryRunStr := ctx.Query("dryRun"); dryRunStr != "" {
+		dryRun, err = strconv.ParseBool(dryRunStr)
+		if err != nil {
+			ctx.String(http.StatusBadRequest, "invalid dryRun")
+			return
+		}
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		log.Println("Err to open offers file: ", err.Error())
+		ctx.String(http.StatusBadRequest, "invalid file")
+		return
+	}
+	defer file.Close()
+
+	result, err := h.useCase.Import(ctx.Request.Context(), file, fileHeader.Size, format, dryRun)
+	if err != nil {
+		log.Println("Err to import offers: ", err.Error())
+		switch {
+		case errors.Is(err, offer.ErrUnknownImportFormat):
+			ctx.String(http.StatusBadRequest, "unknown file format, use csv or xlsx")
+		case errors.Is(err, offer.ErrInvalidImportFile):
+			ctx.String(http.StatusBadRequest, err.Error())
+		default:
+			ctx.Status(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	status := http.StatusCreated
+	switch {
+	case len(result.Errors) > 0:
+		status = http.StatusUnprocessableEntity
+	case dryRun:
+		status = http.StatusOK
+	}
+
+	ctx.JSON(status, docs.OfferImportResultToResponse(result))
+}
+
+// GetOffersMap
+// Add godoc
+// @Summary Offers map
+// @Description Open offers of hotels with coordinates as GeoJSON FeatureCollection, one point per offer
+// @Tags Offer
+// @Param cityId query string false "Id of city"
+// @Param lat query number false "Latitude of search point"
+// @Param lon query number false "Longitude of search point"
+// @Param radiusKm query number false "Max distance from search point in km, requires lat and lon"
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} docs.OffersGeoJSON "Offers"
+// @Failure 400 {string} string "Invalid search params"
+// @Failure 401 "Unauthorized"
+// @Failure 403 "Only available for reviewer"
+// @Failure 500 "Internal server error"
+// @Router /offer/map [get]
+func (h *offerHandler) GetOffersMap(ctx *gin.Context) {
+	var filter model.Filter
+
+	if cityIdStr := ctx.Query("cityId"); cityIdStr != "" {
+		cityId, err := uuid.Parse(cityIdStr)
+		if err != nil {
+			ctx.String(http.StatusBadRequest, "invalid cityId")
+			return
+		}
+		filter.LocationID = pkg.NewWithValue(cityId)
+	}
+
+	if !parseGeoQuery(ctx, &filter) {
+		return
+	}
+
+	offers, err := h.useCase.GetForMap(ctx.Request.Context(), filter)
+	if err != nil {
+		log.Println("Err to get offers for map: ", err.Error())
+		ctx.Status(http.StatusInternalServerError)
+		return
+	}
+
+	ctx.Header("Content-Type", "application/geo+json")
+	ctx.JSON(http.StatusOK, docs.OffersToGeoJSON(offers))
+}
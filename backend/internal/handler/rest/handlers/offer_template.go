@@ -0,0 +1,223 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/docs"
+	offerTemplateRepo "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/offertemplate"
+	model "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/offertemplate"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/usecase/offer"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/usecase/offertemplate"
+)
+
+type OfferTemplateHandler interface {
+	CreateOfferTemplate(ctx *gin.Context)
+	GetOfferTemplates(ctx *gin.Context)
+	GetOfferTemplateById(ctx *gin.Context)
+	ActivateOfferTemplate(ctx *gin.Context)
+	DeactivateOfferTemplate(ctx *gin.Context)
+}
+
+type offerTemplateHandler struct {
+	useCase offertemplate.UseCase
+}
+
+func NewOfferTemplateHandler(useCase offertemplate.UseCase) OfferTemplateHandler {
+	return &offerTemplateHandler{
+		useCase: useCase,
+	}
+}
+
+// CreateOfferTemplate
+// Add godoc
+// @Summary Create offer template
+// @Description Creates recurring offer. Offers are created by scheduler ahead of time and published on their publish date
+// @Tags Offer template
+// @Accept json
+// @Produce json
+// @Param input body docs.CreateOfferTemplateRequest true "Template data"
+// @Security BearerAuth
+// @Success 201 {object} docs.CreateOfferTemplateResponse "Template created"
+// @Failure 400 {string} string "Invalid data for creating template"
+// @Failure 401 "Unauthorized"
+// @Failure 403 "Only available with offer:write permission"
+// @Failure 500 "Internal server error"
+// @Router /offer-template/ [post]
+func (h *offerTemplateHandler) CreateOfferTemplate(ctx *gin.Context) {
+	var request docs.CreateOfferTemplateRequest
+	if err := ctx.BindJSON(&request); err != nil {
+		ctx.String(http.StatusBadRequest, "invalid body")
+		return
+	}
+
+	hotelId, err := uuid.Parse(request.HotelId)
+	if err != nil {
+		ctx.String(http.StatusBadRequest, "invalid hotel id")
+		return
+	}
+
+	roomId, err := uuid.Parse(request.RoomId)
+	if err != nil {
+		ctx.String(http.StatusBadRequest, "invalid room id")
+		return
+	}
+
+	id, err := h.useCase.Create(ctx.Request.Context(), model.Create{
+		HotelID:           hotelId,
+		RoomID:            roomId,
+		Task:              request.Task,
+		ParticipantsLimit: request.ParticipantsLimit,
+		WinnersCount:      request.WinnersCount,
+		Period:            model.Period(request.Period),
+		StartsAt:          request.StartsAt,
+		CheckInAfterDays:  request.CheckInAfterDays,
+		Nights:            request.Nights,
+		ApplicationDays:   request.ApplicationDays,
+	})
+	if err != nil {
+		log.Println("Err to create offer template: ", err.Error())
+		switch {
+		case errors.Is(err, offertemplate.ErrInvalidPeriod),
+			errors.Is(err, offertemplate.ErrInvalidParticipantsLimit),
+			errors.Is(err, offertemplate.ErrInvalidWinnersCount),
+			errors.Is(err, offertemplate.ErrInvalidDays),
+			errors.Is(err, offertemplate.ErrApplicationAfterCheckIn),
+			isTemplateRoomError(err):
+			ctx.String(http.StatusBadRequest, err.Error())
+		default:
+			ctx.Status(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, &docs.CreateOfferTemplateResponse{Id: id.String()})
+}
+
+// GetOfferTemplates
+// Add godoc
+// @Summary Get offer templates
+// @Description Returns all offer templates
+// @Tags Offer template
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} docs.GetOfferTemplatesResponse "Templates"
+// @Failure 401 "Unauthorized"
+// @Failure 403 "Only available with offer:write permission"
+// @Failure 500 "Internal server error"
+// @Router /offer-template/ [get]
+func (h *offerTemplateHandler) GetOfferTemplates(ctx *gin.Context) {
+	templates, err := h.useCase.GetAll(ctx.Request.Context())
+	if err != nil {
+		log.Println("Err to get offer templates: ", err.Error())
+		ctx.Status(http.StatusInternalServerError)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, docs.OfferTemplatesToResponse(templates))
+}
+
+// GetOfferTemplateById
+// Add godoc
+// @Summary Get offer template
+// @Tags Offer template
+// @Produce json
+// @Param id path string true "Template ID"
+// @Security BearerAuth
+// @Success 200 {object} docs.OfferTemplateResponse "Template"
+// @Failure 400 {string} string "Invalid template id"
+// @Failure 401 "Unauthorized"
+// @Failure 403 "Only available with offer:write permission"
+// @Failure 404 {string} string "Template not found"
+// @Failure 500 "Internal server error"
+// @Router /offer-template/{id} [get]
+func (h *offerTemplateHandler) GetOfferTemplateById(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.String(http.StatusBadRequest, "invalid template id")
+		return
+	}
+
+	template, err := h.useCase.GetByID(ctx.Request.Context(), id)
+	if err != nil {
+		log.Println("Err to get offer template: ", err.Error())
+		h.writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, docs.OfferTemplateModelToResponse(&template))
+}
+
+// ActivateOfferTemplate
+// Add godoc
+// @Summary Activate offer template
+// @Description Resumes creating offers by template. Occurrences whose application period has already ended are skipped
+// @Tags Offer template
+// @Param id path string true "Template ID"
+// @Security BearerAuth
+// @Success 204 "Template activated"
+// @Failure 400 {string} string "Invalid template id, or template hotel or room was deleted"
+// @Failure 401 "Unauthorized"
+// @Failure 403 "Only available with offer:write permission"
+// @Failure 404 {string} string "Template not found"
+// @Failure 500 "Internal server error"
+// @Router /offer-template/{id}/activate [patch]
+func (h *offerTemplateHandler) ActivateOfferTemplate(ctx *gin.Context) {
+	h.setActive(ctx, true)
+}
+
+// DeactivateOfferTemplate
+// Add godoc
+// @Summary Deactivate offer template
+// @Description Stops creating offers by template. Already created offers are not changed
+// @Tags Offer template
+// @Param id path string true "Template ID"
+// @Security BearerAuth
+// @Success 204 "Template deactivated"
+// @Failure 400 {string} string "Invalid template id"
+// @Failure 401 "Unauthorized"
+// @Failure 403 "Only available with offer:write permission"
+// @Failure 404 {string} string "Template not found"
+// @Failure 500 "Internal server error"
+// @Router /offer-template/{id}/deactivate [patch]
+func (h *offerTemplateHandler) DeactivateOfferTemplate(ctx *gin.Context) {
+	h.setActive(ctx, false)
+}
+
+func (h *offerTemplateHandler) setActive(ctx *gin.Context, active bool) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.String(http.StatusBadRequest, "invalid template id")
+		return
+	}
+
+	if err := h.useCase.SetActive(ctx.Request.Context(), id, active); err != nil {
+		log.Println("Err to update offer template: ", err.Error())
+		h.writeError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (h *offerTemplateHandler) writeError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, offerTemplateRepo.ErrTemplateNotFound):
+		ctx.String(http.StatusNotFound, "offer template not found")
+	case isTemplateRoomError(err):
+		ctx.String(http.StatusBadRequest, err.Error())
+	default:
+		ctx.Status(http.StatusInternalServerError)
+	}
+}
+
+// isTemplateRoomError - отель шаблона удален или номер ему не подходит
+func isTemplateRoomError(err error) bool {
+	return errors.Is(err, offer.ErrHotelNotFound) ||
+		errors.Is(err, offer.ErrRoomNotFound) ||
+		errors.Is(err, offer.ErrRoomNotInHotel) ||
+		errors.Is(err, offerTemplateRepo.ErrHotelOrRoomNotFound)
+}
@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/pkg"
+)
+
+// parsePage читает pageSize и pageNum или cursor. Без pageSize берется размер по умолчанию.
+// При ошибке отвечает 400 и возвращает false
+func parsePage(ctx *gin.Context) (pkg.PageRequest, bool) {
+	page := pkg.PageRequest{Size: pkg.DefaultPageSize}
+
+	if value := ctx.Query("pageSize"); value != "" {
+		size, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			log.Println("Invalid pageSize: ", value)
+			ctx.String(http.StatusBadRequest, "invalid pageSize")
+			return pkg.PageRequest{}, false
+		}
+		page.Size = size
+	}
+
+	pageNum := ctx.Query("pageNum")
+	cursor, hasCursor := ctx.GetQuery("cursor")
+
+	switch {
+	case hasCursor && pageNum != "":
+		ctx.String(http.StatusBadRequest, "use either pageNum or cursor")
+		return pkg.PageRequest{}, false
+	case hasCursor:
+		page.Cursor = pkg.NewWithValue(cursor)
+	case pageNum != "":
+		num, err := strconv.ParseUint(pageNum, 10, 64)
+		if err != nil {
+			log.Println("Invalid pageNum: ", pageNum)
+			ctx.String(http.StatusBadRequest, "invalid pageNum")
+			return pkg.PageRequest{}, false
+		}
+		page.Num = num
+	}
+
+	if err := page.Validate(); err != nil {
+		ctx.String(http.StatusBadRequest, err.Error())
+		return pkg.PageRequest{}, false
+	}
+
+	return page, true
+}
+
+// parsePageNum - для админских таблиц, которые листаются только по номерам страниц
+func parsePageNum(ctx *gin.Context) (pkg.PageRequest, bool) {
+	if _, ok := ctx.GetQuery("cursor"); ok {
+		ctx.String(http.StatusBadRequest, "cursor is not supported here, use pageNum")
+		return pkg.PageRequest{}, false
+	}
+	return parsePage(ctx)
+}
+
+// parseCursorPage - для справочников, которые листаются только курсором. Без cursor отдается весь список
+func parseCursorPage(ctx *gin.Context) (pkg.PageRequest, bool) {
+	if ctx.Query("pageNum") != "" {
+		ctx.String(http.StatusBadRequest, "pageNum is not supported here, use cursor")
+		return pkg.PageRequest{}, false
+	}
+	return parsePage(ctx)
+}
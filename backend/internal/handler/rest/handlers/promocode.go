@@ -0,0 +1,201 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/docs"
+	promocodeRepo "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/promocode"
+	model "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/promocode"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/usecase/promocode"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/pkg"
+)
+
+type PromocodeHandler interface {
+	GetPromocodes(ctx *gin.Context)
+	RevokePromocode(ctx *gin.Context)
+	ReissuePromocode(ctx *gin.Context)
+	RedeemPromocode(ctx *gin.Context)
+}
+
+type promocodeHandler struct {
+	useCase promocode.UseCase
+}
+
+func NewPromocodeHandler(useCase promocode.UseCase) PromocodeHandler {
+	return &promocodeHandler{
+		useCase: useCase,
+	}
+}
+
+// GetPromocodes
+// Add godoc
+// @Summary Get promocodes
+// @Description Returns page of promocodes filtered by user and status
+// @Tags Promocode
+// @Produce json
+// @Param userId query string false "Owner of promocode"
+// @Param status query string false "issued, redeemed, expired or revoked"
+// @Param pageNum query int false "Number of page, from 0"
+// @Param pageSize query int false "Size of page, 20 by default, at most 100"
+// @Security BearerAuth
+// @Success 200 {object} docs.GetPromocodesResponse "Page of promocodes"
+// @Failure 400 {string} string "Invalid filter"
+// @Failure 401 "Unauthorized"
+// @Failure 403 "Only available with promocode:manage permission"
+// @Failure 500 "Internal server error"
+// @Router /promocode/ [get]
+func (h *promocodeHandler) GetPromocodes(ctx *gin.Context) {
+	page, ok := parsePageNum(ctx)
+	if !ok {
+		return
+	}
+
+	filter := promocodeRepo.Filter{
+		Limit:  page.Size,
+		Offset: page.Offset(),
+	}
+
+	if userIdStr := ctx.Query("userId"); userIdStr != "" {
+		userId, err := uuid.Parse(userIdStr)
+		if err != nil {
+			ctx.String(http.StatusBadRequest, "invalid userId")
+			return
+		}
+		filter.UserID = pkg.NewWithValue(userId)
+	}
+
+	if status := model.Status(ctx.Query("status")); status != "" {
+		switch status {
+		case model.StatusIssued, model.StatusRedeemed, model.StatusExpired, model.StatusRevoked:
+			filter.Status = pkg.NewWithValue(status)
+		default:
+			ctx.String(http.StatusBadRequest, "invalid status")
+			return
+		}
+	}
+
+	promocodes, pages, err := h.useCase.GetByFilter(ctx.Request.Context(), filter)
+	if err != nil {
+		log.Println("Err to get promocodes: ", err.Error())
+		ctx.Status(http.StatusInternalServerError)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &docs.GetPromocodesResponse{
+		Promocodes:   docs.PromocodesToResponse(promocodes),
+		PageResponse: docs.PageResponse{PagesCount: pages},
+	})
+}
+
+// RevokePromocode
+// Add godoc
+// @Summary Revoke promocode
+// @Description Revokes issued promocode so it can not be redeemed
+// @Tags Promocode
+// @Param id path string true "Promocode ID"
+// @Security BearerAuth
+// @Success 204 "Promocode revoked"
+// @Failure 400 {string} string "Invalid promocode id"
+// @Failure 401 "Unauthorized"
+// @Failure 403 "Only available with promocode:manage permission"
+// @Failure 404 {string} string "Promocode not found"
+// @Failure 409 {string} string "Promocode is already redeemed, expired or revoked"
+// @Failure 500 "Internal server error"
+// @Router /promocode/{id}/revoke [patch]
+func (h *promocodeHandler) RevokePromocode(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.String(http.StatusBadRequest, "invalid promocode id")
+		return
+	}
+
+	if err := h.useCase.Revoke(ctx.Request.Context(), id); err != nil {
+		log.Println("Err to revoke promocode: ", err.Error())
+		h.writeError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// ReissuePromocode
+// Add godoc
+// @Summary Reissue promocode
+// @Description Issues new code with the same value instead of given one. Old code is revoked
+// @Tags Promocode
+// @Produce json
+// @Param id path string true "Promocode ID"
+// @Security BearerAuth
+// @Success 201 {object} docs.PromocodeResponse "New promocode"
+// @Failure 400 {string} string "Invalid promocode id"
+// @Failure 401 "Unauthorized"
+// @Failure 403 "Only available with promocode:manage permission"
+// @Failure 404 {string} string "Promocode not found"
+// @Failure 409 {string} string "Promocode is already redeemed or replaced"
+// @Failure 500 "Internal server error"
+// @Router /promocode/{id}/reissue [post]
+func (h *promocodeHandler) ReissuePromocode(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.String(http.StatusBadRequest, "invalid promocode id")
+		return
+	}
+
+	p, err := h.useCase.Reissue(ctx.Request.Context(), id)
+	if err != nil {
+		log.Println("Err to reissue promocode: ", err.Error())
+		h.writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, docs.PromocodeModelToResponse(&p))
+}
+
+// RedeemPromocode
+// Add godoc
+// @Summary Redeem promocode
+// @Description Marks issued promocode as redeemed
+// @Tags Promocode
+// @Accept json
+// @Produce json
+// @Param input body docs.RedeemPromocodeRequest true "Code to redeem"
+// @Security BearerAuth
+// @Success 200 {object} docs.PromocodeResponse "Redeemed promocode"
+// @Failure 400 {string} string "Invalid data for redeeming"
+// @Failure 401 "Unauthorized"
+// @Failure 403 "Only available with promocode:manage permission"
+// @Failure 404 {string} string "Promocode not found"
+// @Failure 409 {string} string "Promocode is already redeemed, expired or revoked"
+// @Failure 500 "Internal server error"
+// @Router /promocode/redeem [post]
+func (h *promocodeHandler) RedeemPromocode(ctx *gin.Context) {
+	var request docs.RedeemPromocodeRequest
+	if err := ctx.BindJSON(&request); err != nil {
+		ctx.String(http.StatusBadRequest, "invalid body")
+		return
+	}
+
+	p, err := h.useCase.Redeem(ctx.Request.Context(), request.Code)
+	if err != nil {
+		log.Println("Err to redeem promocode: ", err.Error())
+		h.writeError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, docs.PromocodeModelToResponse(&p))
+}
+
+func (h *promocodeHandler) writeError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, promocodeRepo.ErrPromocodeNotFound):
+		ctx.String(http.StatusNotFound, "promocode not found")
+	case errors.Is(err, promocodeRepo.ErrNotActive), errors.Is(err, promocodeRepo.ErrAlreadyIssued):
+		ctx.String(http.StatusConflict, "promocode is not active")
+	default:
+		ctx.Status(http.StatusInternalServerError)
+	}
+}
@@ -1,16 +1,20 @@
 package handlers
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
 	"mime/multipart"
 	"net/http"
-	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/docs"
 	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/handler/rest/middleware/auth"
+	offerModel "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/offer"
 	report2 "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/report"
 	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/usecase/report"
 	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/pkg"
@@ -27,6 +31,9 @@ type ReportHandler interface {
 	GetReportsByFilter(ctx *gin.Context)
 }
 
+// reportItemImagesPrefix - поле формы с фото к пункту чек-листа: images_<item_id>
+const reportItemImagesPrefix = "images_"
+
 type reportHandler struct {
 	uc report.Usecase
 }
@@ -39,42 +46,37 @@ func NewReportHandler(uc report.Usecase) ReportHandler {
 // @Summary GetForPage reports
 // @Description GetForPage all reports with pagination
 // @Tags Report
-// @Param pageNum query int true "Number of page"
-// @Param pageSize query int true "Size of page"
+// @Param pageNum query int false "Number of page, from 0"
+// @Param pageSize query int false "Size of page, 20 by default, at most 100"
 // @Produce json
 // @Security BearerAuth
 // @Success 200 {object} docs.GetReportsResponse "Page of reports"
 // @Failure 400 {string} string "Invalid data for getting reports"
 // @Failure 401 "Unauthorized"
 // @Failure 403 "Only available for admin"
-// @Failure 404 "Page with given number not found"
 // @Failure 500 "Internal server error"
 // @Router /report/ [get]
 func (h *reportHandler) GetReports(ctx *gin.Context) {
-	pageNumStr := ctx.Query("pageNum")
-	pageNum, err := strconv.Atoi(pageNumStr)
-
-	if pageNumStr == "" || err != nil {
-		log.Println("Invalid pageNum: ", pageNumStr)
-		ctx.String(http.StatusBadRequest, "invalid pageNum")
+	page, ok := parsePageNum(ctx)
+	if !ok {
 		return
 	}
 
-	pageSizeStr := ctx.Query("pageSize")
-	pageSize, err := strconv.Atoi(pageSizeStr)
+	reports, err := h.uc.Get(ctx, int64(page.Size), int64(page.Offset()))
 	if err != nil {
-		log.Println("Invalid pageSize: ", pageSizeStr)
-		ctx.String(http.StatusBadRequest, "invalid pageSize")
+		log.Println("Err to get reports: ", err)
+		ctx.String(http.StatusInternalServerError, "something went wrong")
 		return
 	}
 
-	limit := int64(pageSize)
-	offset := int64(pageNum) * int64(pageSize)
-
-	reports, err := h.uc.Get(ctx, limit, offset)
 	cnt, err := h.uc.Count(ctx)
+	if err != nil {
+		log.Println("Err to count reports: ", err)
+		ctx.String(http.StatusInternalServerError, "something went wrong")
+		return
+	}
 
-	resp := h.convertToReportsResp(reports, cnt, limit)
+	resp := h.convertToReportsResp(reports, docs.PageResponse{PagesCount: pkg.PagesCount(int(cnt), page.Size)})
 
 	ctx.JSON(http.StatusOK, resp)
 }
@@ -122,6 +124,7 @@ func (h *reportHandler) GetReportById(ctx *gin.Context) {
 		Status:       rep.Status,
 		Text:         rep.Text,
 		Images:       images,
+		Checklist:    h.convertToRespChecklist(rep.Checklist, rep.Answers),
 	}
 
 	ctx.JSON(http.StatusOK, resp)
@@ -131,32 +134,20 @@ func (h *reportHandler) GetReportById(ctx *gin.Context) {
 // @Summary GetForPage my reports
 // @Description GetForPage all reports of current user with pagination
 // @Tags Report
-// @Param pageNum query int true "Number of page"
-// @Param pageSize query int true "Size of page"
+// @Param pageNum query int false "Number of page, from 0"
+// @Param cursor query string false "next_cursor from previous page, empty for the first page. Can not be used with pageNum"
+// @Param pageSize query int false "Size of page, 20 by default, at most 100"
 // @Produce json
 // @Security BearerAuth
-// @Success 200 {object} docs.GetReportsResponse "Page of reports"
+// @Success 200 {object} docs.GetReportsResponse "Page of reports, newest first"
 // @Failure 400 {string} string "Invalid data for getting reports"
 // @Failure 401 "Unauthorized"
 // @Failure 403 "User is not reviewer"
-// @Failure 404 "Page with given number not found"
 // @Failure 500 "Internal server error"
 // @Router /report/my [get]
 func (h *reportHandler) GetMyReports(ctx *gin.Context) {
-	pageNumStr := ctx.Query("pageNum")
-	pageNum, err := strconv.Atoi(pageNumStr)
-
-	if pageNumStr == "" || err != nil {
-		log.Println("Invalid pageNum: ", pageNumStr)
-		ctx.String(http.StatusBadRequest, "invalid pageNum")
-		return
-	}
-
-	pageSizeStr := ctx.Query("pageSize")
-	pageSize, err := strconv.Atoi(pageSizeStr)
-	if err != nil {
-		log.Println("Invalid pageSize: ", pageSizeStr)
-		ctx.String(http.StatusBadRequest, "invalid pageSize")
+	page, ok := parsePage(ctx)
+	if !ok {
 		return
 	}
 
@@ -167,18 +158,18 @@ func (h *reportHandler) GetMyReports(ctx *gin.Context) {
 		return
 	}
 
-	limit := int64(pageSize)
-	offset := int64(pageNum) * int64(pageSize)
-
-	reports, err := h.uc.GetByUserID(ctx.Request.Context(), userId, limit, offset)
+	reports, err := h.uc.GetByUserID(ctx.Request.Context(), userId, page)
+	if errors.Is(err, pkg.ErrInvalidCursor) {
+		ctx.String(http.StatusBadRequest, err.Error())
+		return
+	}
 	if err != nil {
+		log.Println("Err to get user reports: ", err)
 		ctx.String(http.StatusInternalServerError, "something went wrong")
 		return
 	}
 
-	cnt, err := h.uc.CountByUserId(ctx.Request.Context(), userId)
-
-	resp := h.convertToReportsResp(reports, cnt, limit)
+	resp := h.convertToReportsResp(reports.Items, docs.NewPageResponse(reports))
 
 	ctx.JSON(http.StatusOK, resp)
 }
@@ -275,17 +266,20 @@ func (h *reportHandler) GetMyReportByApplicationId(ctx *gin.Context) {
 
 // Add godoc
 // @Summary Update report
-// @Description Updates report with given text and photos
+// @Description Updates report with given text, photos and checklist answers. Photos for checklist item are passed
+// @Description in field images_<item_id>. Report is not saved until all required checklist items are answered
 // @Tags Report
 // @Accept multipart/form-data
 // @Param id path string true "Id of report to update"
 // @Param text formData string true "Report text"
+// @Param answers formData string false "JSON array of docs.ReportAnswerRequest"
 // @Produce json
 // @Security BearerAuth
 // @Success 200 "Successfully update report"
 // @Failure 400 {string} string "Invalid data for updating report"
 // @Failure 401 "Unauthorized"
 // @Failure 403 "Only available for reviewer"
+// @Failure 422 {string} string "Checklist answers are invalid or required items are not answered"
 // @Failure 500 "Internal server error"
 // @Router /report/{id} [patch]
 func (h *reportHandler) UpdateReport(ctx *gin.Context) {
@@ -318,19 +312,32 @@ func (h *reportHandler) UpdateReport(ctx *gin.Context) {
 		return
 	}
 
+	answers, itemImages, err := parseReportAnswers(request.Answers, form.File)
+	if err != nil {
+		log.Println(err)
+		ctx.String(http.StatusBadRequest, err.Error())
+		return
+	}
+
 	log.Println(1)
 	log.Println(len(form.File["images"]))
 	log.Println(2)
 
 	if err := h.uc.Update(ctx, report2.Report{
-		ID:     id,
-		UserID: userId,
-		Text:   request.Text,
-		Status: "filled",
-		Images: nil,
-	}, form.File["images"]); err != nil {
+		ID:      id,
+		UserID:  userId,
+		Text:    request.Text,
+		Status:  "filled",
+		Images:  nil,
+		Answers: answers,
+	}, form.File["images"], itemImages); err != nil {
 		log.Println(err)
-		ctx.String(http.StatusInternalServerError, "something went wrong")
+		switch {
+		case errors.Is(err, report.ErrChecklistIncomplete), errors.Is(err, report.ErrInvalidAnswer):
+			ctx.String(http.StatusUnprocessableEntity, err.Error())
+		default:
+			ctx.String(http.StatusInternalServerError, "something went wrong")
+		}
 		return
 	}
 
@@ -392,35 +399,22 @@ func (h *reportHandler) ConfirmReport(ctx *gin.Context) {
 // @Param cityId query string false "cityId if report"
 // @Param hotelId query string false "hotelId of report"
 // @Param status query string false "Status of report"
-// @Param pageNum query int true "Number of page"
-// @Param pageSize query int true "Size of page"
+// @Param pageNum query int false "Number of page, from 0"
+// @Param pageSize query int false "Size of page, 20 by default, at most 100"
 // @Produce json
 // @Security BearerAuth
 // @Success 200 {object} docs.GetReportsResponse "Page of reports"
 // @Failure 400 {string} string "Invalid data for getting reports"
 // @Failure 401 "Unauthorized"
 // @Failure 403 "Only available for admin"
-// @Failure 404 "Page with given number not found"
 // @Failure 500 "Internal server error"
 // @Router /report/search [get]
 func (h *reportHandler) GetReportsByFilter(ctx *gin.Context) {
-	pageNumStr := ctx.Query("pageNum")
-	pageNum, err := strconv.Atoi(pageNumStr)
-
-	if pageNumStr == "" || err != nil {
-		log.Println("Invalid pageNum: ", pageNumStr)
-		ctx.String(http.StatusBadRequest, "invalid pageNum")
+	page, ok := parsePageNum(ctx)
+	if !ok {
 		return
 	}
 
-	pageSizeStr := ctx.Query("pageSize")
-	pageSize, err := strconv.Atoi(pageSizeStr)
-
-	if pageSizeStr == "" || err != nil {
-		log.Println("Invalid pageSize: ", pageSizeStr)
-		ctx.String(http.StatusBadRequest, "invalid pageSize")
-		return
-	}
 	cityIdStr := ctx.Query("cityId")
 	var cityIdOpt pkg.Opt[uuid.UUID]
 	if cityIdStr != "" {
@@ -456,16 +450,16 @@ func (h *reportHandler) GetReportsByFilter(ctx *gin.Context) {
 		Status:     statusOpt,
 		HotelID:    hotelIdOpt,
 		LocationID: cityIdOpt,
-		Limit:      uint64(pageSize),
-		Offset:     uint64(pageSize * pageNum),
+		Limit:      page.Size,
+		Offset:     page.Offset(),
 	}
-	reports, cnt, err := h.uc.GetByFilter(ctx, filter)
+	reports, pagesCount, err := h.uc.GetByFilter(ctx, filter)
 	if err != nil {
 		log.Println("Err to get reports by filter: ", err)
 		ctx.String(http.StatusBadRequest, "failed to get reports by filter")
 		return
 	}
-	resp := h.convertToReportsResp(reports, int64(cnt), int64(pageSize))
+	resp := h.convertToReportsResp(reports, docs.PageResponse{PagesCount: pagesCount})
 
 	ctx.JSON(http.StatusOK, resp)
 }
@@ -488,18 +482,17 @@ func (h *reportHandler) convertToRespImages(images []report2.Image) []*docs.Repo
 			Id:   image.ID.String(),
 			Link: image.Link,
 		}
+		if image.ItemID != nil {
+			res[i].ItemId = image.ItemID.String()
+		}
 	}
 	return res
 }
 
-func (h *reportHandler) convertToReportsResp(reports []report2.Report, cnt int64, limit int64) *docs.GetReportsResponse {
+func (h *reportHandler) convertToReportsResp(reports []report2.Report, page docs.PageResponse) *docs.GetReportsResponse {
 	resp := &docs.GetReportsResponse{
-		Reports:    make([]*docs.ReportResponse, len(reports)),
-		PagesCount: int(cnt / limit),
-	}
-
-	if cnt%limit != 0 {
-		resp.PagesCount++
+		Reports:      make([]*docs.ReportResponse, len(reports)),
+		PageResponse: page,
 	}
 
 	for i, r := range reports {
@@ -522,5 +515,66 @@ func (h *reportHandler) convertToReportResp(r report2.Report) *docs.ReportRespon
 		CheckOutAt:   r.CheckOutAt,
 		Task:         r.Task,
 		Images:       h.convertToRespImages(r.Images),
+		Checklist:    h.convertToRespChecklist(r.Checklist, r.Answers),
+	}
+}
+
+func (h *reportHandler) convertToRespChecklist(items []offerModel.ChecklistItem, answers []report2.Answer) []*docs.ReportChecklistItemResponse {
+	byItem := make(map[uuid.UUID]report2.Answer, len(answers))
+	for _, answer := range answers {
+		byItem[answer.ItemID] = answer
+	}
+
+	itemsResp := docs.ChecklistToResponse(items)
+	res := make([]*docs.ReportChecklistItemResponse, len(items))
+	for i, item := range items {
+		answer, ok := byItem[item.ID]
+		res[i] = &docs.ReportChecklistItemResponse{
+			ChecklistItemResponse: *itemsResp[i],
+			Answered:              ok,
+			Checked:               answer.Checked,
+			Score:                 answer.Score,
+			Text:                  answer.Text,
+		}
+	}
+	return res
+}
+
+// parseReportAnswers разбирает поле answers формы отчета и фото пунктов чек-листа из полей images_<item_id>
+func parseReportAnswers(raw string, files map[string][]*multipart.FileHeader) ([]report2.Answer, map[uuid.UUID][]*multipart.FileHeader, error) {
+	var answers []report2.Answer
+	if raw != "" {
+		var request []docs.ReportAnswerRequest
+		if err := json.Unmarshal([]byte(raw), &request); err != nil {
+			return nil, nil, fmt.Errorf("invalid answers: %w", err)
+		}
+		answers = make([]report2.Answer, 0, len(request))
+		for _, a := range request {
+			itemID, err := uuid.Parse(a.ItemId)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid answer item_id %q", a.ItemId)
+			}
+			answers = append(answers, report2.Answer{
+				ItemID:  itemID,
+				Checked: a.Checked,
+				Score:   a.Score,
+				Text:    a.Text,
+			})
+		}
 	}
+
+	itemImages := make(map[uuid.UUID][]*multipart.FileHeader)
+	for key, headers := range files {
+		idStr, ok := strings.CutPrefix(key, reportItemImagesPrefix)
+		if !ok {
+			continue
+		}
+		itemID, err := uuid.Parse(idStr)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid images field %q", key)
+		}
+		itemImages[itemID] = headers
+	}
+
+	return answers, itemImages, nil
 }
@@ -0,0 +1,190 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/docs"
+	rbacRepo "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/rbac"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/handler/rest/middleware/auth"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/usecase/rbac"
+)
+
+type RoleHandler interface {
+	GetRoles(ctx *gin.Context)
+	GetUserRoles(ctx *gin.Context)
+	AssignRole(ctx *gin.Context)
+	RevokeRole(ctx *gin.Context)
+}
+
+type roleHandler struct {
+	useCase rbac.UseCase
+}
+
+func NewRoleHandler(useCase rbac.UseCase) RoleHandler {
+	return &roleHandler{
+		useCase: useCase,
+	}
+}
+
+// GetRoles
+// Add godoc
+// @Summary Get roles
+// @Description Returns all roles with their permissions
+// @Tags Role
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} docs.GetRolesResponse "Roles"
+// @Failure 401 "Unauthorized"
+// @Failure 403 "Only available with user:manage_roles permission"
+// @Failure 500 "Internal server error"
+// @Router /role/ [get]
+func (h *roleHandler) GetRoles(ctx *gin.Context) {
+	roles, err := h.useCase.GetRoles(ctx.Request.Context())
+	if err != nil {
+		log.Println("Err to get roles: ", err.Error())
+		ctx.Status(http.StatusInternalServerError)
+		return
+	}
+
+	resp := &docs.GetRolesResponse{
+		Roles: make([]*docs.RoleResponse, 0, len(roles)),
+	}
+
+	for _, role := range roles {
+		permissions := make([]string, 0, len(role.Permissions))
+		for _, p := range role.Permissions {
+			permissions = append(permissions, string(p))
+		}
+
+		resp.Roles = append(resp.Roles, &docs.RoleResponse{
+			Id:          role.ID.String(),
+			Name:        role.Name,
+			Description: role.Description,
+			Permissions: permissions,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// GetUserRoles
+// Add godoc
+// @Summary Get user roles
+// @Description Returns roles of user with given id
+// @Tags Role
+// @Produce json
+// @Param id path string true "User ID"
+// @Security BearerAuth
+// @Success 200 {object} docs.UserRolesResponse "User roles"
+// @Failure 400 {string} string "Invalid user id"
+// @Failure 401 "Unauthorized"
+// @Failure 403 "Only available with user:manage_roles permission"
+// @Failure 500 "Internal server error"
+// @Router /role/user/{id} [get]
+func (h *roleHandler) GetUserRoles(ctx *gin.Context) {
+	userId, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.String(http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	roles, err := h.useCase.GetUserRoles(ctx.Request.Context(), userId)
+	if err != nil {
+		log.Println("Err to get user roles: ", err.Error())
+		ctx.Status(http.StatusInternalServerError)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &docs.UserRolesResponse{Roles: roles})
+}
+
+// AssignRole
+// Add godoc
+// @Summary Assign role
+// @Description Assigns role to user with given id
+// @Tags Role
+// @Accept json
+// @Param id path string true "User ID"
+// @Param input body docs.AssignRoleRequest true "Role to assign"
+// @Security BearerAuth
+// @Success 204 "Role assigned"
+// @Failure 400 {string} string "Invalid data for assigning role"
+// @Failure 401 "Unauthorized"
+// @Failure 403 "Only available with user:manage_roles permission"
+// @Failure 404 {string} string "User or role not found"
+// @Failure 500 "Internal server error"
+// @Router /role/user/{id} [post]
+func (h *roleHandler) AssignRole(ctx *gin.Context) {
+	userId, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.String(http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	var request docs.AssignRoleRequest
+	if err := ctx.BindJSON(&request); err != nil {
+		ctx.String(http.StatusBadRequest, "invalid body")
+		return
+	}
+
+	err = h.useCase.AssignRole(ctx.Request.Context(), userId, request.Role)
+
+	switch {
+	case errors.Is(err, rbacRepo.ErrRoleNotFound):
+		ctx.String(http.StatusNotFound, "role not found")
+	case errors.Is(err, rbacRepo.ErrUserNotFound):
+		ctx.String(http.StatusNotFound, "user not found")
+	case err != nil:
+		log.Println("Err to assign role: ", err.Error())
+		ctx.Status(http.StatusInternalServerError)
+	default:
+		ctx.Status(http.StatusNoContent)
+	}
+}
+
+// RevokeRole
+// Add godoc
+// @Summary Revoke role
+// @Description Revokes role from user with given id
+// @Tags Role
+// @Param id path string true "User ID"
+// @Param role path string true "Role name"
+// @Security BearerAuth
+// @Success 204 "Role revoked"
+// @Failure 400 {string} string "Invalid data for revoking role"
+// @Failure 401 "Unauthorized"
+// @Failure 403 "Only available with user:manage_roles permission"
+// @Failure 404 {string} string "User has no such role"
+// @Failure 500 "Internal server error"
+// @Router /role/user/{id}/{role} [delete]
+func (h *roleHandler) RevokeRole(ctx *gin.Context) {
+	actorId, err := auth.GetUserId(ctx)
+	if err != nil {
+		ctx.Status(http.StatusUnauthorized)
+		return
+	}
+
+	userId, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.String(http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	err = h.useCase.RevokeRole(ctx.Request.Context(), actorId, userId, ctx.Param("role"))
+
+	switch {
+	case errors.Is(err, rbac.ErrSelfRevoke):
+		ctx.String(http.StatusBadRequest, "can not revoke own admin role")
+	case errors.Is(err, rbacRepo.ErrRoleNotFound):
+		ctx.String(http.StatusNotFound, "user has no such role")
+	case err != nil:
+		log.Println("Err to revoke role: ", err.Error())
+		ctx.Status(http.StatusInternalServerError)
+	default:
+		ctx.Status(http.StatusNoContent)
+	}
+}
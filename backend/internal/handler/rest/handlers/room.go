@@ -2,12 +2,17 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"log"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/docs"
+	roomRepo "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/room"
+	model "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/room"
 	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/usecase/room"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/pkg"
 )
 
 type RoomHandler interface {
@@ -28,6 +33,9 @@ type RoomHandler interface {
 	// @Router /room/ [post]
 	CreateRoom(ctx *gin.Context)
 	GetRooms(ctx *gin.Context)
+	GetRoomById(ctx *gin.Context)
+	UpdateRoom(ctx *gin.Context)
+	DeleteRoom(ctx *gin.Context)
 }
 
 type roomHandler struct {
@@ -53,6 +61,7 @@ func NewRoomHandler(useCase room.UseCase) RoomHandler {
 // @Failure 400 {string} string "Invalid data for creating room"
 // @Failure 401 "Unauthorized"
 // @Failure 403 "Only available for admin"
+// @Failure 409 {string} string "Room with this name already exists"
 // @Failure 500 "Internal server error"
 // @Router /room/ [post]
 func (h *roomHandler) CreateRoom(ginCtx *gin.Context) {
@@ -64,10 +73,20 @@ func (h *roomHandler) CreateRoom(ginCtx *gin.Context) {
 		return
 	}
 
-	id, err := h.useCase.Create(ctx, request.Name)
+	create := model.Create{Name: request.Name}
+	if request.HotelID != "" {
+		hotelID, err := uuid.Parse(request.HotelID)
+		if err != nil {
+			ginCtx.String(http.StatusBadRequest, "invalid hotel_id")
+			return
+		}
+		create.HotelID = pkg.NewWithValue(hotelID)
+	}
+
+	id, err := h.useCase.Create(ctx, create)
 	if err != nil {
 		log.Println("Err to create room: ", err.Error())
-		ginCtx.String(http.StatusBadRequest, err.Error())
+		writeRoomError(ginCtx, err)
 		return
 	}
 	resp := &docs.CreateOfferResponse{
@@ -80,31 +99,170 @@ func (h *roomHandler) CreateRoom(ginCtx *gin.Context) {
 // GetRooms
 // Add godoc
 // @Summary Get rooms
-// @Description GetRooms all rooms
+// @Description GetRooms all rooms. With hotelId returns rooms of the hotel and shared room types
 // @Tags Room
+// @Param hotelId query string false "Hotel id"
+// @Param cursor query string false "next_cursor from previous page, empty for the first page. Without cursor the whole list is returned"
+// @Param pageSize query int false "Size of page, 20 by default, at most 100"
 // @Produce json
 // @Security BearerAuth
 // @Success 200 {object} docs.GetRoomsResponse "Page of rooms"
 // @Failure 400 {string} string "Invalid data for getting rooms"
 // @Failure 401 "Unauthorized"
 // @Failure 403 "Only available for admin"
-// @Failure 404 "Page with given number not found"
 // @Failure 500 "Internal server error"
 // @Router /room/ [get]
 func (h *roomHandler) GetRooms(ginCtx *gin.Context) {
+	page, ok := parseCursorPage(ginCtx)
+	if !ok {
+		return
+	}
+
 	ctx := context.Background()
-	ucRooms, err := h.useCase.GetAll(ctx)
+
+	var filter model.Filter
+	if hotelIDStr := ginCtx.Query("hotelId"); hotelIDStr != "" {
+		hotelID, err := uuid.Parse(hotelIDStr)
+		if err != nil {
+			ginCtx.String(http.StatusBadRequest, "invalid hotelId")
+			return
+		}
+		filter.HotelID = pkg.NewWithValue(hotelID)
+	}
+
+	ucPage, err := h.useCase.GetPage(ctx, filter, page)
 	if err != nil {
 		log.Println("Err to get rooms: ", err.Error())
-		ginCtx.String(http.StatusBadRequest, err.Error())
+		writeRoomError(ginCtx, err)
 		return
 	}
-	apiRooms := make([]*docs.RoomResponse, len(ucRooms))
-	for i, ucRoom := range ucRooms {
-		apiRooms[i] = &docs.RoomResponse{
-			Id:   ucRoom.ID.String(),
-			Name: ucRoom.Name,
-		}
+	apiRooms := make([]*docs.RoomResponse, len(ucPage.Items))
+	for i, ucRoom := range ucPage.Items {
+		apiRooms[i] = convertUcRoomToApi(ucRoom)
+	}
+	ginCtx.JSON(http.StatusOK, docs.GetRoomsResponse{Rooms: apiRooms, PageResponse: docs.NewPageResponse(ucPage)})
+}
+
+// GetRoomById
+// Add godoc
+// @Summary Get room by id
+// @Tags Room
+// @Param id path string true "Room id"
+// @Produce json
+// @Success 200 {object} docs.RoomResponse "Room"
+// @Failure 400 {string} string "Invalid room id"
+// @Failure 404 {string} string "Room not found"
+// @Failure 500 "Internal server error"
+// @Router /room/{id} [get]
+func (h *roomHandler) GetRoomById(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.String(http.StatusBadRequest, "invalid room id")
+		return
+	}
+
+	ucRoom, err := h.useCase.GetByID(ctx.Request.Context(), id)
+	if err != nil {
+		log.Println("Err to get room: ", err.Error())
+		writeRoomError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convertUcRoomToApi(ucRoom))
+}
+
+// UpdateRoom
+// Add godoc
+// @Summary Rename room
+// @Tags Room
+// @Accept json
+// @Param id path string true "Room id"
+// @Param input body docs.UpdateRoomRequest true "New room name"
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} docs.RoomResponse "Updated room"
+// @Failure 400 {string} string "Invalid data for updating room"
+// @Failure 401 "Unauthorized"
+// @Failure 403 "Only available with catalog:write permission"
+// @Failure 404 {string} string "Room not found"
+// @Failure 409 {string} string "Room with this name already exists"
+// @Failure 500 "Internal server error"
+// @Router /room/{id} [patch]
+func (h *roomHandler) UpdateRoom(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.String(http.StatusBadRequest, "invalid room id")
+		return
+	}
+
+	var request docs.UpdateRoomRequest
+	if err := ctx.BindJSON(&request); err != nil {
+		log.Println("Invalid body")
+		ctx.String(http.StatusBadRequest, "invalid body")
+		return
+	}
+
+	updated, err := h.useCase.Rename(ctx.Request.Context(), id, request.Name)
+	if err != nil {
+		log.Println("Err to update room: ", err.Error())
+		writeRoomError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convertUcRoomToApi(updated))
+}
+
+// DeleteRoom
+// Add godoc
+// @Summary Delete room
+// @Description Marks room as deleted. Room used in offers in progress or active templates can not be deleted
+// @Tags Room
+// @Param id path string true "Room id"
+// @Security BearerAuth
+// @Success 204 "Room deleted"
+// @Failure 400 {string} string "Invalid room id"
+// @Failure 401 "Unauthorized"
+// @Failure 403 "Only available with catalog:write permission"
+// @Failure 404 {string} string "Room not found"
+// @Failure 409 {string} string "Room has active offers"
+// @Failure 500 "Internal server error"
+// @Router /room/{id} [delete]
+func (h *roomHandler) DeleteRoom(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.String(http.StatusBadRequest, "invalid room id")
+		return
+	}
+
+	if err := h.useCase.Delete(ctx.Request.Context(), id); err != nil {
+		log.Println("Err to delete room: ", err.Error())
+		writeRoomError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func writeRoomError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, roomRepo.ErrNotFound):
+		ctx.String(http.StatusNotFound, err.Error())
+	case errors.Is(err, roomRepo.ErrNameTaken), errors.Is(err, roomRepo.ErrInUse):
+		ctx.String(http.StatusConflict, err.Error())
+	case errors.Is(err, roomRepo.ErrHotelNotFound), errors.Is(err, pkg.ErrInvalidCursor):
+		ctx.String(http.StatusBadRequest, err.Error())
+	default:
+		ctx.Status(http.StatusInternalServerError)
+	}
+}
+
+func convertUcRoomToApi(ucRoom model.Room) *docs.RoomResponse {
+	resp := &docs.RoomResponse{
+		Id:   ucRoom.ID.String(),
+		Name: ucRoom.Name,
+	}
+	if ucRoom.HotelID != nil {
+		resp.HotelId = ucRoom.HotelID.String()
 	}
-	ginCtx.JSON(http.StatusOK, docs.GetRoomsResponse{Rooms: apiRooms})
+	return resp
 }
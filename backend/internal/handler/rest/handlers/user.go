@@ -4,12 +4,17 @@ import (
 	"context"
 	"errors"
 	"log"
+	"math"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/docs"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/ostrovok"
 	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/handler/rest/middleware/auth"
 	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/handler/rest/validation"
+	sessionModel "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/session"
 	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/usecase/user"
 
 	repo "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/user"
@@ -20,6 +25,15 @@ type UserHandler interface {
 	SignUp(ctx *gin.Context)
 	Refresh(ctx *gin.Context)
 	GetMe(ctx *gin.Context)
+	LogOut(ctx *gin.Context)
+	GetSessions(ctx *gin.Context)
+	RevokeSession(ctx *gin.Context)
+	RevokeUserSessions(ctx *gin.Context)
+	ChangePassword(ctx *gin.Context)
+	RequestPasswordReset(ctx *gin.Context)
+	ResetPassword(ctx *gin.Context)
+	ConfirmSignUp(ctx *gin.Context)
+	ResendSignUpCode(ctx *gin.Context)
 }
 
 type userHandler struct {
@@ -41,8 +55,8 @@ func NewUserHandler(useCase user.UseCase) UserHandler {
 // @Produce json
 // @Success 200 {object} docs.AuthResponse "Auth data"
 // @Failure 400 {string} string "Invalid data for log in"
-// @Failure 401 "Wrong password"
-// @Failure 404 "User not found"
+// @Failure 401 {string} string "Invalid login or password"
+// @Failure 429 {string} string "Too many attempts, see Retry-After header"
 // @Failure 500 "Internal server error"
 // @Router /user/log-in [post]
 func (h *userHandler) LogIn(ginCtx *gin.Context) {
@@ -61,18 +75,19 @@ func (h *userHandler) LogIn(ginCtx *gin.Context) {
 		return
 	}
 
-	if err := validation.ValidatePassword(request.Password); err != nil {
-		log.Println("Invalid username: ", err.Error())
-		ginCtx.String(http.StatusBadRequest, err.Error())
-		return
-	}
-
-	resp, err := h.useCase.Login(ctx, &request)
+	resp, err := h.useCase.Login(ctx, &request, clientInfo(ginCtx))
 
 	if err != nil {
-		//TODO обработка похитрее
 		log.Println("Err to login: ", err.Error())
-		ginCtx.String(http.StatusBadRequest, err.Error())
+
+		switch {
+		case writeTooManyAttempts(ginCtx, err):
+		case errors.Is(err, user.ErrInvalidCredentials):
+			ginCtx.String(http.StatusUnauthorized, user.ErrInvalidCredentials.Error())
+		default:
+			ginCtx.Status(http.StatusInternalServerError)
+		}
+
 		return
 	}
 
@@ -81,7 +96,7 @@ func (h *userHandler) LogIn(ginCtx *gin.Context) {
 
 // Add godoc
 // @Summary Sign up
-// @Description Sign up with given name and password
+// @Description Sign up with given name and password. Account stays pending until code sent to Ostrovok email is confirmed
 // @Tags User
 // @Accept json
 // @Param input body docs.SignUpRequest true "Data for sign up"
@@ -89,7 +104,9 @@ func (h *userHandler) LogIn(ginCtx *gin.Context) {
 // @Success 201 {object} docs.AuthResponse "Auth data"
 // @Failure 400 {string} string "Invalid data for sign up"
 // @Failure 404 "User not found"
+// @Failure 429 {string} string "Verification code was sent recently"
 // @Failure 500 "Internal server error"
+// @Failure 503 {string} string "Ostrovok is unavailable"
 // @Router /user/sign-up [post]
 func (h *userHandler) SignUp(ginCtx *gin.Context) {
 	var request docs.SignUpRequest
@@ -119,12 +136,21 @@ func (h *userHandler) SignUp(ginCtx *gin.Context) {
 		return
 	}
 
-	resp, err := h.useCase.Register(ctx, &request)
+	resp, err := h.useCase.Register(ctx, &request, clientInfo(ginCtx))
 
 	if err != nil {
-		//TODO обработка похитрее
 		log.Println("Err from useCAse password: ", err.Error())
-		ginCtx.String(http.StatusBadRequest, err.Error())
+
+		switch {
+		case writeTooManyAttempts(ginCtx, err):
+		case errors.Is(err, ostrovok.ErrUnavailable):
+			ginCtx.String(http.StatusServiceUnavailable, "ostrovok is temporarily unavailable")
+		case errors.Is(err, ostrovok.ErrUserNotExists):
+			ginCtx.String(http.StatusNotFound, err.Error())
+		default:
+			ginCtx.String(http.StatusBadRequest, err.Error())
+		}
+
 		return
 	}
 
@@ -140,6 +166,7 @@ func (h *userHandler) SignUp(ginCtx *gin.Context) {
 // @Produce json
 // @Success 200 {object} docs.AuthResponse "Auth data"
 // @Failure 400 {string} string "Invalid data for refresh"
+// @Failure 401 "Refresh token is invalid, expired or already used"
 // @Failure 500 "Internal server error"
 // @Router /user/refresh [post]
 func (h *userHandler) Refresh(ctx *gin.Context) {
@@ -151,7 +178,20 @@ func (h *userHandler) Refresh(ctx *gin.Context) {
 		return
 	}
 
-	resp := &docs.AuthResponse{}
+	resp, err := h.useCase.Refresh(ctx.Request.Context(), &request)
+
+	if err != nil {
+		log.Println("failed to refresh tokens", err)
+
+		switch {
+		case errors.Is(err, user.ErrInvalidToken), errors.Is(err, user.ErrRefreshTokenReused):
+			ctx.Status(http.StatusUnauthorized)
+		default:
+			ctx.Status(http.StatusInternalServerError)
+		}
+
+		return
+	}
 
 	ctx.JSON(http.StatusOK, resp)
 }
@@ -196,3 +236,357 @@ func (h *userHandler) GetMe(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, resp)
 }
+
+// Add godoc
+// @Summary Log out
+// @Description Ends current session, its access and refresh tokens stop working immediately
+// @Tags User
+// @Security BearerAuth
+// @Success 204 "Logged out"
+// @Failure 401 "Unauthorized"
+// @Failure 500 "Internal server error"
+// @Router /user/log-out [post]
+func (h *userHandler) LogOut(ctx *gin.Context) {
+	userId, err := auth.GetUserId(ctx)
+	if err != nil {
+		ctx.Status(http.StatusUnauthorized)
+		return
+	}
+
+	sessionId, err := auth.GetSessionId(ctx)
+	if err != nil {
+		ctx.Status(http.StatusUnauthorized)
+		return
+	}
+
+	err = h.useCase.RevokeSession(ctx.Request.Context(), userId, sessionId)
+
+	switch {
+	case errors.Is(err, user.ErrSessionNotFound):
+		ctx.Status(http.StatusUnauthorized)
+	case err != nil:
+		log.Println("failed to log out", err)
+		ctx.Status(http.StatusInternalServerError)
+	default:
+		ctx.Status(http.StatusNoContent)
+	}
+}
+
+// Add godoc
+// @Summary Get sessions
+// @Description Get active sessions of current user
+// @Tags User
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} docs.GetSessionsResponse "Active sessions"
+// @Failure 401 "Unauthorized"
+// @Failure 500 "Internal server error"
+// @Router /user/sessions [get]
+func (h *userHandler) GetSessions(ctx *gin.Context) {
+	userId, err := auth.GetUserId(ctx)
+	if err != nil {
+		ctx.Status(http.StatusUnauthorized)
+		return
+	}
+
+	currentId, err := auth.GetSessionId(ctx)
+	if err != nil {
+		ctx.Status(http.StatusUnauthorized)
+		return
+	}
+
+	sessions, err := h.useCase.GetSessions(ctx.Request.Context(), userId)
+	if err != nil {
+		log.Println("failed to get sessions", err)
+		ctx.Status(http.StatusInternalServerError)
+		return
+	}
+
+	resp := &docs.GetSessionsResponse{
+		Sessions: make([]*docs.SessionResponse, 0, len(sessions)),
+	}
+
+	for _, s := range sessions {
+		resp.Sessions = append(resp.Sessions, &docs.SessionResponse{
+			Id:         s.ID.String(),
+			UserAgent:  s.UserAgent,
+			Ip:         s.IP,
+			CreatedAt:  s.CreatedAt,
+			LastSeenAt: s.LastSeenAt,
+			Current:    s.ID == currentId,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// Add godoc
+// @Summary Revoke session
+// @Description Ends one of current user's sessions
+// @Tags User
+// @Param id path string true "Session ID"
+// @Security BearerAuth
+// @Success 204 "Session revoked"
+// @Failure 400 {string} string "Invalid session id"
+// @Failure 401 "Unauthorized"
+// @Failure 404 "Session not found"
+// @Failure 500 "Internal server error"
+// @Router /user/sessions/{id} [delete]
+func (h *userHandler) RevokeSession(ctx *gin.Context) {
+	userId, err := auth.GetUserId(ctx)
+	if err != nil {
+		ctx.Status(http.StatusUnauthorized)
+		return
+	}
+
+	sessionId, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.String(http.StatusBadRequest, "invalid session id")
+		return
+	}
+
+	err = h.useCase.RevokeSession(ctx.Request.Context(), userId, sessionId)
+
+	switch {
+	case errors.Is(err, user.ErrSessionNotFound):
+		ctx.Status(http.StatusNotFound)
+	case err != nil:
+		log.Println("failed to revoke session", err)
+		ctx.Status(http.StatusInternalServerError)
+	default:
+		ctx.Status(http.StatusNoContent)
+	}
+}
+
+// Add godoc
+// @Summary Revoke user sessions
+// @Description Ends all sessions of user with given id
+// @Tags User
+// @Produce json
+// @Param id path string true "User ID"
+// @Security BearerAuth
+// @Success 200 {object} docs.RevokeSessionsResponse "Number of revoked sessions"
+// @Failure 400 {string} string "Invalid user id"
+// @Failure 401 "Unauthorized"
+// @Failure 403 "Only available with user:manage_sessions permission"
+// @Failure 500 "Internal server error"
+// @Router /user/{id}/sessions [delete]
+func (h *userHandler) RevokeUserSessions(ctx *gin.Context) {
+	userId, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.String(http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	revoked, err := h.useCase.RevokeAllSessions(ctx.Request.Context(), userId)
+	if err != nil {
+		log.Println("failed to revoke user sessions", err)
+		ctx.Status(http.StatusInternalServerError)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &docs.RevokeSessionsResponse{Revoked: revoked})
+}
+
+// Add godoc
+// @Summary Change password
+// @Description Changes password of current user, all other sessions are ended
+// @Tags User
+// @Accept json
+// @Param input body docs.ChangePasswordRequest true "Old and new passwords"
+// @Security BearerAuth
+// @Success 204 "Password changed"
+// @Failure 400 {string} string "Invalid new password"
+// @Failure 401 {string} string "Unauthorized or wrong old password"
+// @Failure 429 {string} string "Too many wrong old passwords"
+// @Failure 500 "Internal server error"
+// @Router /user/password/change [post]
+func (h *userHandler) ChangePassword(ctx *gin.Context) {
+	userId, err := auth.GetUserId(ctx)
+	if err != nil {
+		ctx.Status(http.StatusUnauthorized)
+		return
+	}
+
+	sessionId, err := auth.GetSessionId(ctx)
+	if err != nil {
+		ctx.Status(http.StatusUnauthorized)
+		return
+	}
+
+	var request docs.ChangePasswordRequest
+	if err := ctx.BindJSON(&request); err != nil {
+		ctx.String(http.StatusBadRequest, "invalid body")
+		return
+	}
+
+	if err := validation.ValidatePassword(request.NewPassword); err != nil {
+		ctx.String(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = h.useCase.ChangePassword(ctx.Request.Context(), userId, sessionId, &request, clientInfo(ctx))
+
+	switch {
+	case writeTooManyAttempts(ctx, err):
+	case errors.Is(err, user.ErrInvalidCredentials):
+		ctx.String(http.StatusUnauthorized, "wrong old password")
+	case err != nil:
+		log.Println("failed to change password", err)
+		ctx.Status(http.StatusInternalServerError)
+	default:
+		ctx.Status(http.StatusNoContent)
+	}
+}
+
+// Add godoc
+// @Summary Request password reset
+// @Description Sends single-use reset link to user's email. Responds the same way whether user exists or not, requests are limited per login and IP
+// @Tags User
+// @Accept json
+// @Param input body docs.PasswordResetRequest true "Login of user"
+// @Success 202 "Reset link sent if user exists and limit is not reached"
+// @Failure 400 {string} string "Invalid body"
+// @Router /user/password/reset-request [post]
+func (h *userHandler) RequestPasswordReset(ctx *gin.Context) {
+	var request docs.PasswordResetRequest
+	if err := ctx.BindJSON(&request); err != nil {
+		ctx.String(http.StatusBadRequest, "invalid body")
+		return
+	}
+
+	// Ошибки и лимит только логируем: по ответу нельзя понять, есть ли такой пользователь
+	if err := h.useCase.RequestPasswordReset(ctx.Request.Context(), &request, clientInfo(ctx)); err != nil {
+		log.Println("failed to request password reset", err)
+	}
+
+	ctx.Status(http.StatusAccepted)
+}
+
+// Add godoc
+// @Summary Reset password
+// @Description Sets new password using reset token, all sessions of user are ended
+// @Tags User
+// @Accept json
+// @Param input body docs.ResetPasswordRequest true "Reset token and new password"
+// @Success 204 "Password changed"
+// @Failure 400 {string} string "Invalid password or reset token"
+// @Failure 500 "Internal server error"
+// @Router /user/password/reset [post]
+func (h *userHandler) ResetPassword(ctx *gin.Context) {
+	var request docs.ResetPasswordRequest
+	if err := ctx.BindJSON(&request); err != nil {
+		ctx.String(http.StatusBadRequest, "invalid body")
+		return
+	}
+
+	if err := validation.ValidatePassword(request.NewPassword); err != nil {
+		ctx.String(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err := h.useCase.ResetPassword(ctx.Request.Context(), &request)
+
+	switch {
+	case errors.Is(err, user.ErrResetTokenInvalid):
+		ctx.String(http.StatusBadRequest, user.ErrResetTokenInvalid.Error())
+	case err != nil:
+		log.Println("failed to reset password", err)
+		ctx.Status(http.StatusInternalServerError)
+	default:
+		ctx.Status(http.StatusNoContent)
+	}
+}
+
+// Add godoc
+// @Summary Confirm sign up
+// @Description Confirms ownership of Ostrovok account with code sent to its email and activates account
+// @Tags User
+// @Accept json
+// @Param input body docs.ConfirmSignUpRequest true "Verification code"
+// @Security BearerAuth
+// @Success 204 "Account activated"
+// @Failure 400 {string} string "Invalid or expired code"
+// @Failure 401 "Unauthorized"
+// @Failure 409 {string} string "Account is already verified"
+// @Failure 500 "Internal server error"
+// @Router /user/sign-up/confirm [post]
+func (h *userHandler) ConfirmSignUp(ctx *gin.Context) {
+	userId, err := auth.GetUserId(ctx)
+	if err != nil {
+		ctx.Status(http.StatusUnauthorized)
+		return
+	}
+
+	var request docs.ConfirmSignUpRequest
+	if err := ctx.BindJSON(&request); err != nil {
+		ctx.String(http.StatusBadRequest, "invalid body")
+		return
+	}
+
+	err = h.useCase.ConfirmSignUp(ctx.Request.Context(), userId, &request)
+
+	switch {
+	case errors.Is(err, user.ErrVerificationCodeInvalid):
+		ctx.String(http.StatusBadRequest, user.ErrVerificationCodeInvalid.Error())
+	case errors.Is(err, user.ErrAlreadyVerified):
+		ctx.String(http.StatusConflict, user.ErrAlreadyVerified.Error())
+	case err != nil:
+		log.Println("failed to confirm sign up", err)
+		ctx.Status(http.StatusInternalServerError)
+	default:
+		ctx.Status(http.StatusNoContent)
+	}
+}
+
+// Add godoc
+// @Summary Resend sign up code
+// @Description Sends new verification code to Ostrovok email, previous code stops working
+// @Tags User
+// @Security BearerAuth
+// @Success 202 "Code sent"
+// @Failure 401 "Unauthorized"
+// @Failure 409 {string} string "Account is already verified"
+// @Failure 429 {string} string "Code was sent recently or daily limit is reached"
+// @Failure 500 "Internal server error"
+// @Router /user/sign-up/resend [post]
+func (h *userHandler) ResendSignUpCode(ctx *gin.Context) {
+	userId, err := auth.GetUserId(ctx)
+	if err != nil {
+		ctx.Status(http.StatusUnauthorized)
+		return
+	}
+
+	err = h.useCase.ResendSignUpCode(ctx.Request.Context(), userId)
+
+	switch {
+	case writeTooManyAttempts(ctx, err):
+	case errors.Is(err, user.ErrAlreadyVerified):
+		ctx.String(http.StatusConflict, user.ErrAlreadyVerified.Error())
+	case err != nil:
+		log.Println("failed to resend sign up code", err)
+		ctx.Status(http.StatusInternalServerError)
+	default:
+		ctx.Status(http.StatusAccepted)
+	}
+}
+
+// writeTooManyAttempts отвечает 429 с Retry-After, если err - TooManyAttemptsError
+func writeTooManyAttempts(ctx *gin.Context, err error) bool {
+	var tooMany *user.TooManyAttemptsError
+	if !errors.As(err, &tooMany) {
+		return false
+	}
+
+	retryAfter := int(math.Ceil(tooMany.RetryAfter.Seconds()))
+	ctx.Header("Retry-After", strconv.Itoa(retryAfter))
+	ctx.String(http.StatusTooManyRequests, tooMany.Unwrap().Error())
+	return true
+}
+
+func clientInfo(ctx *gin.Context) sessionModel.ClientInfo {
+	return sessionModel.ClientInfo{
+		UserAgent: ctx.Request.UserAgent(),
+		IP:        ctx.ClientIP(),
+	}
+}
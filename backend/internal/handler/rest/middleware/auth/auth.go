@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
@@ -10,25 +11,32 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/handler/rest/middleware/auth/dto"
+	model "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/rbac"
+	userModel "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/user"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/usecase/rbac"
 	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/usecase/user"
 )
 
-const _AUTH_USER_ID = "__auth_user_id"
-const _AUTH_USER_ROLE = "__auth_user_role"
+const (
+	_AUTH_USER_ID    = "__auth_user_id"
+	_AUTH_SESSION_ID = "__auth_session_id"
+)
 
 type Auth interface {
 	LoginProtected() gin.HandlerFunc
-	RoleProtected(role string) gin.HandlerFunc
-	parseToken(token string) (string, string, error)
+	PermissionProtected(permission model.Permission) gin.HandlerFunc
+	parseToken(ctx context.Context, token string) (*userModel.JWTClaims, error)
 }
 
 type auth struct {
-	uc user.UseCase
+	uc     user.UseCase
+	rbacUC rbac.UseCase
 }
 
-func NewAuth(uc user.UseCase) Auth {
+func NewAuth(uc user.UseCase, rbacUC rbac.UseCase) Auth {
 	return &auth{
-		uc: uc,
+		uc:     uc,
+		rbacUC: rbacUC,
 	}
 }
 
@@ -41,69 +49,90 @@ func (a *auth) LoginProtected() gin.HandlerFunc {
 			return
 		}
 
-		userId, userRole, err := a.parseToken(req.Auth)
+		claims, err := a.parseToken(ctx.Request.Context(), req.Auth)
 		if err != nil {
 			log.Println("failed to read token", err.Error())
-			ctx.AbortWithStatus(http.StatusUnauthorized)
+			ctx.AbortWithStatus(tokenErrorStatus(err))
 			return
 		}
 
-		ctx.Set(_AUTH_USER_ID, userId)
-		ctx.Set(_AUTH_USER_ROLE, userRole)
+		ctx.Set(_AUTH_USER_ID, claims.UserID)
+		ctx.Set(_AUTH_SESSION_ID, claims.SessionID)
 
 		ctx.Next()
 	}
 }
 
-func (a *auth) RoleProtected(role string) gin.HandlerFunc {
+// PermissionProtected пускает только пользователей, у одной из ролей которых есть permission.
+// Права читаются из базы на каждый запрос, а не из токена.
+func (a *auth) PermissionProtected(permission model.Permission) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		var req dto.TokenRequest
 
 		if err := ctx.BindHeader(&req); err != nil {
 			ctx.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+
+		claims, err := a.parseToken(ctx.Request.Context(), req.Auth)
+		if err != nil {
+			log.Println("failed to read token", err.Error())
+			ctx.AbortWithStatus(tokenErrorStatus(err))
+			return
 		}
 
-		userId, userRole, err := a.parseToken(req.Auth)
+		userId, err := uuid.Parse(claims.UserID)
 		if err != nil {
 			ctx.AbortWithStatus(http.StatusUnauthorized)
 			return
 		}
 
-		if userRole != role {
+		ok, err := a.rbacUC.HasPermission(ctx.Request.Context(), userId, permission)
+		if err != nil {
+			log.Println("failed to check permission", err.Error())
+			ctx.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		if !ok {
 			ctx.AbortWithStatus(http.StatusForbidden)
 			return
 		}
 
-		ctx.Set(_AUTH_USER_ID, userId)
-		ctx.Set(_AUTH_USER_ROLE, userRole)
+		ctx.Set(_AUTH_USER_ID, claims.UserID)
+		ctx.Set(_AUTH_SESSION_ID, claims.SessionID)
 
 		ctx.Next()
 	}
 }
 
-func (a *auth) parseToken(tokenQuery string) (string, string, error) {
+func (a *auth) parseToken(ctx context.Context, tokenQuery string) (*userModel.JWTClaims, error) {
 	const bearerPrefix = "Bearer "
 	if !strings.HasPrefix(tokenQuery, bearerPrefix) {
-		return "", "", errors.New("invalid token")
+		return nil, user.ErrInvalidToken
 	}
 
 	token := strings.TrimPrefix(tokenQuery, bearerPrefix)
 	if token == "" {
-		return "", "", errors.New("invalid token")
+		return nil, user.ErrInvalidToken
 	}
 
-	claims, err := a.uc.ValidateToken(token)
+	claims, err := a.uc.ValidateToken(ctx, token)
 
 	if err != nil {
-		return "", "", fmt.Errorf("failed to validate token: %w", err)
+		return nil, fmt.Errorf("failed to validate token: %w", err)
 	}
 
-	role := "reviewer"
-	if claims.IsAdmin {
-		role = "admin"
+	return claims, nil
+}
+
+// tokenErrorStatus отличает невалидный токен от недоступности хранилища сессий
+func tokenErrorStatus(err error) int {
+	if errors.Is(err, user.ErrInvalidToken) || errors.Is(err, user.ErrSessionRevoked) {
+		return http.StatusUnauthorized
 	}
 
-	return claims.UserID, role, nil
+	return http.StatusInternalServerError
 }
 
 func GetUserId(ctx *gin.Context) (uuid.UUID, error) {
@@ -117,8 +146,13 @@ func GetUserId(ctx *gin.Context) (uuid.UUID, error) {
 	return id, nil
 }
 
-func GetUserRole(ctx *gin.Context) (string, error) {
-	role := ctx.GetString(_AUTH_USER_ROLE)
+func GetSessionId(ctx *gin.Context) (uuid.UUID, error) {
+	idStr := ctx.GetString(_AUTH_SESSION_ID)
+	id, err := uuid.Parse(idStr)
 
-	return role, nil
+	if err != nil {
+		return uuid.UUID{}, fmt.Errorf("failed to parse session uuid: %w", err)
+	}
+
+	return id, nil
 }
@@ -13,6 +13,10 @@ const (
 	APPLICATION_CREATED  = ApplicationStatus("__app_created")
 	APPLICATION_ACCEPTED = ApplicationStatus("__app_accepted")
 	APPLICATION_DECLINED = ApplicationStatus("__app_declined")
+	// Заявка сверх лимита участников, ждет освободившегося места
+	APPLICATION_WAITLISTED = ApplicationStatus("__app_waitlisted")
+	// Заявка отозвана пользователем до розыгрыша
+	APPLICATION_WITHDRAWN = ApplicationStatus("__app_withdrawn")
 )
 
 type Application struct {
@@ -22,6 +26,9 @@ type Application struct {
 	Status       ApplicationStatus
 	ExpirationAt time.Time
 	HotelName    string
+	CreatedAt    time.Time
+	// Позиция в листе ожидания начиная с 1, только для APPLICATION_WAITLISTED
+	WaitlistPosition *int
 }
 
 type UserAppLimitInfo struct {
@@ -45,3 +52,13 @@ type Filter struct {
 	Status        pkg.Opt[ApplicationStatus]
 	Limit, Offset uint64
 }
+
+// Cursor - последняя заявка страницы, заявки пользователя идут от новых к старым
+type Cursor struct {
+	CreatedAt time.Time `json:"t"`
+	ID        uuid.UUID `json:"id"`
+}
+
+func NewCursor(app *Application) Cursor {
+	return Cursor{CreatedAt: app.CreatedAt, ID: app.Id}
+}
@@ -0,0 +1,106 @@
+package catalog
+
+import (
+	"github.com/google/uuid"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/pkg"
+)
+
+type Kind string
+
+const (
+	KindLocation = Kind("location")
+	KindHotel    = Kind("hotel")
+	KindRoom     = Kind("room")
+)
+
+// Order - порядок загрузки: отели ссылаются на локации, поэтому локации идут первыми
+func (k Kind) Order() int {
+	switch k {
+	case KindLocation:
+		return 0
+	case KindRoom:
+		return 1
+	case KindHotel:
+		return 2
+	default:
+		return 3
+	}
+}
+
+type Format string
+
+const (
+	FormatJSONLines = Format("jsonl")
+	FormatCSV       = Format("csv")
+)
+
+type Action string
+
+const (
+	ActionCreated = Action("created")
+	ActionUpdated = Action("updated")
+	// ActionUnchanged - запись уже есть и совпадает с фидом
+	ActionUnchanged = Action("unchanged")
+)
+
+// Item - строка фида. LocationExternalID заполняется только у отелей
+type Item struct {
+	Line               int
+	Kind               Kind
+	ExternalID         string
+	Name               string
+	LocationExternalID string
+}
+
+type RowError struct {
+	Line       int
+	ExternalID string
+	Reason     string
+}
+
+type Result struct {
+	DryRun  bool
+	Created int
+	Updated int
+	Skipped int
+	Errors  []RowError
+}
+
+func (r *Result) Add(action Action) {
+	switch action {
+	case ActionCreated:
+		r.Created++
+	case ActionUpdated:
+		r.Updated++
+	default:
+		r.Skipped++
+	}
+}
+
+// Skip учитывает строку, которую не удалось загрузить
+func (r *Result) Skip(item Item, reason string) {
+	r.Skipped++
+	r.Errors = append(r.Errors, RowError{
+		Line:       item.Line,
+		ExternalID: item.ExternalID,
+		Reason:     reason,
+	})
+}
+
+// Cursor - позиция в справочнике, который листается по имени
+type Cursor struct {
+	Name string    `json:"n"`
+	ID   uuid.UUID `json:"id"`
+}
+
+// ParseCursor - пустой курсор означает первую страницу
+func ParseCursor(cursor string) (pkg.Opt[Cursor], error) {
+	if cursor == "" {
+		return pkg.NewEmpty[Cursor](), nil
+	}
+	var value Cursor
+	if err := pkg.DecodeCursor(cursor, &value); err != nil {
+		return pkg.Opt[Cursor]{}, err
+	}
+	return pkg.NewWithValue(value), nil
+}
@@ -0,0 +1,60 @@
+package draw
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Candidate - заявка, участвовавшая в розыгрыше, с рейтингом автора на момент розыгрыша
+type Candidate struct {
+	ApplicationID uuid.UUID
+	UserID        uuid.UUID
+	Rating        int
+	Weight        float64
+	// WinnerOrder - номер победителя начиная с 1, nil у проигравших
+	WinnerOrder *int
+}
+
+// Draw - запись аудита розыгрыша оффера
+type Draw struct {
+	ID           uuid.UUID
+	OfferID      uuid.UUID
+	Algorithm    int
+	Seed         int64
+	WinnersCount int
+	CreatedAt    time.Time
+	// Candidates в порядке участия, от него зависит результат
+	Candidates []Candidate
+}
+
+// Winners - заявки победителей в порядке выбора
+func (d Draw) Winners() []uuid.UUID {
+	winners := make([]Candidate, 0, d.WinnersCount)
+	for _, candidate := range d.Candidates {
+		if candidate.WinnerOrder != nil {
+			winners = append(winners, candidate)
+		}
+	}
+	sort.Slice(winners, func(i, j int) bool {
+		return *winners[i].WinnerOrder < *winners[j].WinnerOrder
+	})
+
+	ids := make([]uuid.UUID, len(winners))
+	for i, winner := range winners {
+		ids[i] = winner.ApplicationID
+	}
+	return ids
+}
+
+// Replay - результат повторного розыгрыша по сохраненному seed
+type Replay struct {
+	Draw Draw
+	// Winners - победители повтора в порядке выбора
+	Winners []uuid.UUID
+	// WeightsMatch - веса, пересчитанные по рейтингам, совпали с сохраненными
+	WeightsMatch bool
+	// WinnersMatch - повтор выбрал тех же победителей в том же порядке
+	WinnersMatch bool
+}
@@ -2,6 +2,7 @@ package hotel
 
 import (
 	"github.com/google/uuid"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/pkg"
 )
 
 type Hotel struct {
@@ -9,9 +10,63 @@ type Hotel struct {
 	Name         string    `db:"name"`
 	LocationID   uuid.UUID `db:"location_id"`
 	LocationName string    `db:"location_name"`
+	Address      string    `db:"address"`
+	// Latitude и Longitude заданы либо оба, либо ни одной
+	Latitude    *float64 `db:"latitude"`
+	Longitude   *float64 `db:"longitude"`
+	Stars       *int     `db:"stars"`
+	Description string   `db:"description"`
+	Contacts
+	// Images - обложки по порядку показа, заполняются только при получении отеля по id
+	Images []Image `db:"-"`
+}
+
+type Contacts struct {
+	Phone   string `db:"phone"`
+	Email   string `db:"email"`
+	Website string `db:"website"`
+}
+
+type Image struct {
+	ID   uuid.UUID
+	Link string
+}
+
+const (
+	MinStars = 1
+	MaxStars = 5
+)
+
+// Point - координаты в градусах (WGS 84)
+type Point struct {
+	Latitude  float64
+	Longitude float64
+}
+
+func (p Point) Valid() bool {
+	return p.Latitude >= -90 && p.Latitude <= 90 && p.Longitude >= -180 && p.Longitude <= 180
 }
 
 type Create struct {
-	Name       string
-	LocationID uuid.UUID
+	Name        string
+	LocationID  uuid.UUID
+	Address     string
+	Point       pkg.Opt[Point]
+	Stars       pkg.Opt[int]
+	Description string
+	Contacts    Contacts
+}
+
+// Update - меняются только заданные поля
+type Update struct {
+	ID          uuid.UUID
+	Name        pkg.Opt[string]
+	LocationID  pkg.Opt[uuid.UUID]
+	Address     pkg.Opt[string]
+	Point       pkg.Opt[Point]
+	Stars       pkg.Opt[int]
+	Description pkg.Opt[string]
+	Phone       pkg.Opt[string]
+	Email       pkg.Opt[string]
+	Website     pkg.Opt[string]
 }
@@ -0,0 +1,43 @@
+package loginattempt
+
+import (
+	"time"
+
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/pkg"
+)
+
+type Kind string
+
+const (
+	KindLogin = Kind("login")
+	KindIP    = Kind("ip")
+	// KindPasswordChange - неверный старый пароль при смене, ключ - id пользователя
+	KindPasswordChange = Kind("password_change")
+	// KindResetLogin и KindResetIP считают запросы сброса пароля, а не ошибки
+	KindResetLogin = Kind("reset_login")
+	KindResetIP    = Kind("reset_ip")
+)
+
+type Key struct {
+	Kind  Kind
+	Value string
+}
+
+// Policy - сколько ошибок подряд допускается в окне Window, прежде чем ключ блокируется.
+// Каждая следующая ошибка после порога удваивает блокировку, но не дольше MaxLockout.
+// Окно отсчитывается от последней ошибки или конца блокировки, если она закончилась позже.
+type Policy struct {
+	MaxFailures int
+	Window      time.Duration
+	BaseLockout time.Duration
+	MaxLockout  time.Duration
+}
+
+// LockoutFor возвращает длительность блокировки после failures ошибок, 0 - если блокировать рано
+func (p Policy) LockoutFor(failures int) time.Duration {
+	if failures < p.MaxFailures {
+		return 0
+	}
+
+	return pkg.ExponentialBackoff(p.BaseLockout, p.MaxLockout, failures-p.MaxFailures)
+}
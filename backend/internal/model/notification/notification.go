@@ -0,0 +1,7 @@
+package notification
+
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
@@ -0,0 +1,31 @@
+package offer
+
+import "github.com/google/uuid"
+
+// ChecklistItemType - какой ответ ожидается на пункт чек-листа
+type ChecklistItemType string
+
+const (
+	ChecklistYesNo = ChecklistItemType("yes_no")
+	// ChecklistScore - оценка от MinScore до MaxScore
+	ChecklistScore = ChecklistItemType("score")
+	ChecklistText  = ChecklistItemType("text")
+	// ChecklistPhoto - ответом служат фотографии, приложенные к пункту
+	ChecklistPhoto = ChecklistItemType("photo")
+)
+
+const (
+	MinScore = 1
+	MaxScore = 5
+)
+
+func (t ChecklistItemType) Valid() bool {
+	return t == ChecklistYesNo || t == ChecklistScore || t == ChecklistText || t == ChecklistPhoto
+}
+
+type ChecklistItem struct {
+	ID       uuid.UUID
+	Type     ChecklistItemType
+	Title    string
+	Required bool
+}
@@ -0,0 +1,81 @@
+package offer
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/pkg"
+)
+
+// Cursor - позиция в выдаче: значение ключа сортировки и id последнего оффера страницы.
+// Сортировка запоминается, чтобы курсор не применили к выдаче с другим порядком
+type Cursor struct {
+	Sort  Sort      `json:"s,omitempty"`
+	Desc  bool      `json:"d,omitempty"`
+	Value string    `json:"v,omitempty"`
+	ID    uuid.UUID `json:"id"`
+}
+
+func NewCursor(offer Offer, filter Filter) Cursor {
+	cursor := Cursor{Sort: filter.Sort, Desc: filter.Desc, ID: offer.ID}
+
+	switch filter.Sort {
+	case SortExpiration:
+		cursor.Value = offer.ExpirationAt.UTC().Format(time.RFC3339Nano)
+	case SortCheckIn:
+		cursor.Value = offer.CheckIn.UTC().Format(time.RFC3339Nano)
+	case SortRemainingSlots:
+		cursor.Value = strconv.Itoa(int(offer.ParticipantsLimit) - int(offer.ParticipantsCount))
+	case SortDistance:
+		if offer.DistanceKm != nil {
+			cursor.Value = strconv.FormatFloat(*offer.DistanceKm, 'g', -1, 64)
+		}
+	}
+
+	return cursor
+}
+
+// Matches - курсор выдан для выдачи с тем же порядком
+func (c Cursor) Matches(filter Filter) bool {
+	return c.Sort == filter.Sort && c.Desc == filter.Desc
+}
+
+// ParseCursor декодирует курсор и проверяет, что значение ключа соответствует его сортировке.
+// Курсор приходит от клиента, и подмененное значение не должно дойти до базы
+func ParseCursor(cursor string) (Cursor, error) {
+	var value Cursor
+	if err := pkg.DecodeCursor(cursor, &value); err != nil {
+		return Cursor{}, err
+	}
+
+	var err error
+	switch value.Sort {
+	case SortExpiration, SortCheckIn:
+		var t time.Time
+		// Нулевого года в Postgres нет
+		if t, err = time.Parse(time.RFC3339Nano, value.Value); err == nil && t.Year() < 1 {
+			err = fmt.Errorf("time %s is out of range", value.Value)
+		}
+	case SortRemainingSlots:
+		_, err = strconv.ParseInt(value.Value, 10, 64)
+	case SortDistance:
+		var distance float64
+		if distance, err = strconv.ParseFloat(value.Value, 64); err == nil && (math.IsNaN(distance) || math.IsInf(distance, 0)) {
+			err = fmt.Errorf("distance %s is not finite", value.Value)
+		}
+	case "":
+		if value.Value != "" {
+			err = fmt.Errorf("unexpected value for default sort")
+		}
+	default:
+		err = fmt.Errorf("unknown sort %q", value.Sort)
+	}
+
+	if err != nil {
+		return Cursor{}, fmt.Errorf("%w: %s", pkg.ErrInvalidCursor, err.Error())
+	}
+	return value, nil
+}
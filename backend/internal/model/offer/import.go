@@ -0,0 +1,25 @@
+package offer
+
+import "github.com/google/uuid"
+
+type ImportFormat string
+
+const (
+	ImportFormatCSV  = ImportFormat("csv")
+	ImportFormatXLSX = ImportFormat("xlsx")
+)
+
+// ImportRowError - причина, по которой строка файла не может быть загружена. Line - номер строки в файле
+type ImportRowError struct {
+	Line   int
+	Reason string
+}
+
+// ImportResult - офферы из файла создаются все вместе или не создаются совсем:
+// если есть хотя бы одна ошибка, Created пустой
+type ImportResult struct {
+	DryRun  bool
+	Rows    int
+	Created []uuid.UUID
+	Errors  []ImportRowError
+}
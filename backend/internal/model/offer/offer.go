@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/hotel"
 	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/pkg"
 )
 
@@ -14,21 +15,66 @@ type Offer struct {
 	RoomName          string    `db:"room_name"`
 	HotelID           uuid.UUID `db:"hotel_id"`
 	HotelName         string    `db:"hotel_name"`
+	HotelAddress      string    `db:"hotel_address"`
+	HotelLatitude     *float64  `db:"hotel_latitude"`
+	HotelLongitude    *float64  `db:"hotel_longitude"`
 	LocationID        uuid.UUID `db:"location_id"`
 	LocationName      string    `db:"location_name"`
 	CheckIn           time.Time `db:"check_in_at"`
 	CheckOut          time.Time `db:"check_out_at"`
 	ExpirationAt      time.Time `db:"expiration_at"`
-	Status            string    `db:"status"`
+	Status            Status    `db:"status"`
 	ParticipantsLimit uint      `db:"participants_limit"`
 	ParticipantsCount uint      `db:"participants_count"`
+	WinnersCount      uint      `db:"winners_count"`
+	AwardedCount      uint      `db:"awarded_count"`
+	Version           int       `db:"version"`
+	// DistanceKm - расстояние до точки поиска, заполняется только при поиске по координатам
+	DistanceKm *float64 `db:"distance_km"`
+	// Checklist - пункты задания по порядку, заполняется только при получении оффера по id
+	Checklist []ChecklistItem `db:"-"`
 }
 
 type Filter struct {
 	ID         pkg.Opt[uuid.UUID]
 	LocationID pkg.Opt[uuid.UUID]
-	Limit      uint64
-	Offset     uint64
+	HotelID    pkg.Opt[uuid.UUID]
+	RoomID     pkg.Opt[uuid.UUID]
+	// CheckInFrom и CheckOutTo - окно дат: проживание по офферу должно целиком в него попадать
+	CheckInFrom pkg.Opt[time.Time]
+	CheckOutTo  pkg.Opt[time.Time]
+	// Near - точка поиска: с ней в ответе есть расстояние до отеля, а офферы отелей без координат не попадают в выдачу
+	Near pkg.Opt[hotel.Point]
+	// WithCoordinates - только офферы отелей с координатами, для карты
+	WithCoordinates bool
+	// RadiusKm - ограничение расстояния от Near
+	RadiusKm pkg.Opt[float64]
+	// Query - полнотекстовый поиск по заданию
+	Query pkg.Opt[string]
+	// OnlyOpen - только офферы, на которые еще можно подать заявку: опубликован, не истек и есть места
+	OnlyOpen bool
+	// Statuses - пустой список означает любой статус
+	Statuses []Status
+	Sort     Sort
+	Desc     bool
+	Limit    uint64
+	Offset   uint64
+	// After - выдача после этой позиции вместо Offset
+	After pkg.Opt[Cursor]
+}
+
+type Sort string
+
+const (
+	SortExpiration     = Sort("expiration")
+	SortCheckIn        = Sort("check_in")
+	SortRemainingSlots = Sort("remaining_slots")
+	// SortDistance - только вместе с Filter.Near
+	SortDistance = Sort("distance")
+)
+
+func (s Sort) Valid() bool {
+	return s == SortExpiration || s == SortCheckIn || s == SortRemainingSlots || s == SortDistance
 }
 
 type Create struct {
@@ -40,19 +86,23 @@ type Create struct {
 	HotelID           uuid.UUID
 	LocalID           uuid.UUID
 	ParticipantsLimit uint
+	WinnersCount      uint
+	Status            Status
+	Checklist         []ChecklistItem
 }
 
 type Edit struct {
-	OfferID      uuid.UUID
-	Task         pkg.Opt[string]
-	RoomID       pkg.Opt[uuid.UUID]
-	HotelID      pkg.Opt[uuid.UUID]
-	CheckIn      pkg.Opt[time.Time]
-	CheckOut     pkg.Opt[time.Time]
-	ExpirationAT pkg.Opt[time.Time]
-}
-
-type PageSettings struct {
-	Limit  uint64
-	Offset uint64
+	OfferID uuid.UUID
+	// Version - версия, которую видел редактирующий. Если оффер успели изменить, правка отклоняется
+	Version           int
+	Task              pkg.Opt[string]
+	RoomID            pkg.Opt[uuid.UUID]
+	HotelID           pkg.Opt[uuid.UUID]
+	CheckIn           pkg.Opt[time.Time]
+	CheckOut          pkg.Opt[time.Time]
+	ExpirationAT      pkg.Opt[time.Time]
+	ParticipantsLimit pkg.Opt[uint]
+	WinnersCount      pkg.Opt[uint]
+	// Checklist заменяет чек-лист целиком
+	Checklist pkg.Opt[[]ChecklistItem]
 }
@@ -0,0 +1,44 @@
+package offer
+
+type Status string
+
+const (
+	// StatusDraft - оффер подготовлен, но пользователи его еще не видят
+	StatusDraft     = Status("draft")
+	StatusPublished = Status("published")
+	// StatusDrawing больше не выставляется: воркер разыгрывает оффер одной транзакцией прямо из published.
+	// Остается только у офферов, застрявших в розыгрыше до этого
+	StatusDrawing = Status("drawing")
+	// StatusAwarded - победитель выбран, ждем его отчет
+	StatusAwarded   = Status("awarded")
+	StatusCompleted = Status("completed")
+	StatusCancelled = Status("cancelled")
+)
+
+// После розыгрыша оффер не отменяется: у победителей уже есть отчеты, за которые выдаются промокоды и рейтинг
+var transitions = map[Status][]Status{
+	StatusDraft:     {StatusPublished, StatusCancelled},
+	StatusPublished: {StatusAwarded, StatusCompleted, StatusCancelled},
+	StatusDrawing:   {StatusAwarded, StatusCompleted},
+	StatusAwarded:   {StatusCompleted},
+}
+
+func (s Status) CanTransitionTo(next Status) bool {
+	for _, allowed := range transitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// VisibleStatuses - статусы офферов, которые видят пользователи в поиске
+var VisibleStatuses = []Status{StatusPublished, StatusDrawing, StatusAwarded, StatusCompleted}
+
+// ActiveStatuses - оффер еще в работе: пока такие есть, его отель и номер нельзя удалить
+var ActiveStatuses = []Status{StatusDraft, StatusPublished, StatusDrawing, StatusAwarded}
+
+// Editable - до розыгрыша условия оффера еще можно менять
+func (s Status) Editable() bool {
+	return s == StatusDraft || s == StatusPublished
+}
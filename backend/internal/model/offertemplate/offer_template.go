@@ -0,0 +1,96 @@
+package offertemplate
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/pkg"
+)
+
+type Period string
+
+const (
+	PeriodWeekly  = Period("weekly")
+	PeriodMonthly = Period("monthly")
+)
+
+func (p Period) Valid() bool {
+	return p == PeriodWeekly || p == PeriodMonthly
+}
+
+// Template - оффер, который повторяется с периодом Period начиная со StartsAt.
+// Даты конкретного оффера отсчитываются от даты его публикации
+type Template struct {
+	ID                uuid.UUID `db:"id"`
+	HotelID           uuid.UUID `db:"hotel_id"`
+	HotelName         string    `db:"hotel_name"`
+	RoomID            uuid.UUID `db:"room_id"`
+	RoomName          string    `db:"room_name"`
+	Task              string    `db:"task"`
+	ParticipantsLimit uint      `db:"participants_limit"`
+	WinnersCount      uint      `db:"winners_count"`
+	Period            Period    `db:"period"`
+	StartsAt          time.Time `db:"starts_at"`
+	// CheckInAfterDays - через сколько дней после публикации заезд
+	CheckInAfterDays int `db:"check_in_after_days"`
+	Nights           int `db:"nights"`
+	// ApplicationDays - сколько дней после публикации принимаются заявки
+	ApplicationDays   int       `db:"application_days"`
+	MaterializedCount int       `db:"materialized_count"`
+	NextPublishAt     time.Time `db:"next_publish_at"`
+	Active            bool      `db:"active"`
+	CreatedAt         time.Time `db:"created_at"`
+}
+
+// Occurrence - даты одного повторения шаблона
+type Occurrence struct {
+	PublishAt    time.Time
+	CheckIn      time.Time
+	CheckOut     time.Time
+	ExpirationAt time.Time
+}
+
+// PublishAt - дата публикации n-го повторения (с нуля).
+// Считаем от StartsAt, а не от предыдущей даты, чтобы месячный период не сползал после коротких месяцев.
+// Если в месяце нет дня StartsAt, публикуем в его последний день: каждый месяц ровно один оффер
+func (t Template) PublishAt(n int) time.Time {
+	switch t.Period {
+	case PeriodWeekly:
+		return t.StartsAt.AddDate(0, 0, 7*n)
+	default:
+		return pkg.AddMonths(t.StartsAt, n)
+	}
+}
+
+func (t Template) Occurrence(n int) Occurrence {
+	publishAt := t.PublishAt(n)
+	checkIn := publishAt.AddDate(0, 0, t.CheckInAfterDays)
+
+	return Occurrence{
+		PublishAt:    publishAt,
+		CheckIn:      checkIn,
+		CheckOut:     checkIn.AddDate(0, 0, t.Nights),
+		ExpirationAt: publishAt.AddDate(0, 0, t.ApplicationDays),
+	}
+}
+
+type Create struct {
+	HotelID           uuid.UUID
+	RoomID            uuid.UUID
+	Task              string
+	ParticipantsLimit uint
+	WinnersCount      uint
+	Period            Period
+	StartsAt          time.Time
+	CheckInAfterDays  int
+	Nights            int
+	ApplicationDays   int
+}
+
+// MaterializeResult - итог одного прохода планировщика
+type MaterializeResult struct {
+	// Created - созданные офферы
+	Created int
+	// Skipped - повторения, для которых оффер на те же даты уже есть или прием заявок уже закончился
+	Skipped int
+}
@@ -0,0 +1,72 @@
+package promocode
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Status string
+
+const (
+	StatusIssued   = Status("issued")
+	StatusRedeemed = Status("redeemed")
+	// StatusExpired в базе не хранится, выставляется при чтении по ExpiresAt
+	StatusExpired = Status("expired")
+	StatusRevoked = Status("revoked")
+)
+
+// TTL - срок действия промокода с момента выдачи
+const TTL = 90 * 24 * time.Hour
+
+type Promocode struct {
+	ID         uuid.UUID
+	Code       string
+	UserID     uuid.UUID
+	ReportID   uuid.UUID
+	Value      int
+	Currency   string
+	Status     Status
+	ExpiresAt  time.Time
+	IssuedAt   time.Time
+	RedeemedAt *time.Time
+	RevokedAt  *time.Time
+	ReplacedBy *uuid.UUID
+}
+
+// Nominal - номинал промокода, зависит от рейтинга пользователя на момент выдачи
+type Nominal struct {
+	MinRating int
+	Value     int
+	Currency  string
+}
+
+var nominals = []Nominal{
+	{MinRating: 300, Value: 3000, Currency: "RUB"},
+	{MinRating: 100, Value: 2000, Currency: "RUB"},
+	{MinRating: 0, Value: 1000, Currency: "RUB"},
+}
+
+func NominalForRating(rating int) Nominal {
+	for _, n := range nominals {
+		if rating >= n.MinRating {
+			return n
+		}
+	}
+	return nominals[len(nominals)-1]
+}
+
+func New(code string, userID, reportID uuid.UUID, nominal Nominal) Promocode {
+	now := time.Now()
+	return Promocode{
+		ID:        uuid.New(),
+		Code:      code,
+		UserID:    userID,
+		ReportID:  reportID,
+		Value:     nominal.Value,
+		Currency:  nominal.Currency,
+		Status:    StatusIssued,
+		ExpiresAt: now.Add(TTL),
+		IssuedAt:  now,
+	}
+}
@@ -0,0 +1,34 @@
+package rbac
+
+import "github.com/google/uuid"
+
+type Permission string
+
+const (
+	PermOfferSearch        = Permission("offer:search")
+	PermOfferRead          = Permission("offer:read")
+	PermOfferWrite         = Permission("offer:write")
+	PermApplicationApply   = Permission("application:apply")
+	PermApplicationRead    = Permission("application:read")
+	PermReportSubmit       = Permission("report:submit")
+	PermReportRead         = Permission("report:read")
+	PermReportConfirm      = Permission("report:confirm")
+	PermCatalogWrite       = Permission("catalog:write")
+	PermAnalyticsRead      = Permission("analytics:read")
+	PermUserManageRoles    = Permission("user:manage_roles")
+	PermUserManageSessions = Permission("user:manage_sessions")
+	PermPromocodeManage    = Permission("promocode:manage")
+	PermDrawAudit          = Permission("draw:audit")
+)
+
+const (
+	RoleReviewer = "reviewer"
+	RoleAdmin    = "admin"
+)
+
+type Role struct {
+	ID          uuid.UUID
+	Name        string
+	Description string
+	Permissions []Permission
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/offer"
 	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/pkg"
 )
 
@@ -12,6 +13,16 @@ type ImageURL string
 type Image struct {
 	ID   uuid.UUID
 	Link string
+	// ItemID - пункт чек-листа, к которому относится фото, nil для общих фото отчета
+	ItemID *uuid.UUID
+}
+
+// Answer - ответ на пункт чек-листа. Заполнено поле, соответствующее типу пункта
+type Answer struct {
+	ItemID  uuid.UUID
+	Checked *bool
+	Score   *int
+	Text    *string
 }
 
 type Report struct {
@@ -29,6 +40,10 @@ type Report struct {
 	RoomName      string
 	Images        []Image
 	Promocode     string
+	CreatedAt     time.Time
+	// Checklist и Answers заполняются только при получении отчета по id
+	Checklist []offer.ChecklistItem
+	Answers   []Answer
 }
 
 func NewReport(applicationId uuid.UUID, ExpirationAt time.Time) Report {
@@ -46,3 +61,13 @@ type Filter struct {
 	LocationID    pkg.Opt[uuid.UUID]
 	Limit, Offset uint64
 }
+
+// Cursor - последний отчет страницы, отчеты пользователя идут от новых к старым
+type Cursor struct {
+	CreatedAt time.Time `json:"t"`
+	ID        uuid.UUID `json:"id"`
+}
+
+func NewCursor(report Report) Cursor {
+	return Cursor{CreatedAt: report.CreatedAt, ID: report.ID}
+}
@@ -1,8 +1,32 @@
 package room
 
-import "github.com/google/uuid"
+import (
+	"github.com/google/uuid"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/catalog"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/pkg"
+)
 
 type Room struct {
 	ID   uuid.UUID `db:"id"`
 	Name string    `db:"name"`
+	// HotelID - отель, к которому относится номер. nil у общих типов номеров из каталога
+	HotelID *uuid.UUID `db:"hotel_id"`
+}
+
+// BelongsTo - номер можно использовать в офферах отеля hotelID
+func (r Room) BelongsTo(hotelID uuid.UUID) bool {
+	return r.HotelID == nil || *r.HotelID == hotelID
+}
+
+type Create struct {
+	Name    string
+	HotelID pkg.Opt[uuid.UUID]
+}
+
+type Filter struct {
+	// HotelID - номера, доступные отелю: его собственные и общие
+	HotelID pkg.Opt[uuid.UUID]
+	// Limit - 0 без ограничения, After - курсор по имени
+	Limit uint64
+	After pkg.Opt[catalog.Cursor]
 }
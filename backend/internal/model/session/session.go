@@ -0,0 +1,57 @@
+package session
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RefreshSession - одна выданная refresh-пара. ID совпадает с jti refresh-токена,
+// FamilyID общий для всех токенов, полученных ротацией от одного логина.
+type RefreshSession struct {
+	ID        uuid.UUID
+	FamilyID  uuid.UUID
+	UserID    uuid.UUID
+	ExpiresAt time.Time
+	CreatedAt time.Time
+	RotatedAt *time.Time
+	RevokedAt *time.Time
+}
+
+func NewRefreshSession(userID, familyID uuid.UUID, ttl time.Duration) RefreshSession {
+	return RefreshSession{
+		ID:        uuid.New(),
+		FamilyID:  familyID,
+		UserID:    userID,
+		ExpiresAt: time.Now().Add(ttl),
+	}
+}
+
+// Session - вход пользователя с одного устройства. ID совпадает с FamilyID его refresh-сессий
+// и попадает в access-токен, поэтому отзыв сессии сразу гасит и access, и refresh.
+type Session struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	UserAgent  string
+	IP         string
+	ExpiresAt  time.Time
+	CreatedAt  time.Time
+	LastSeenAt time.Time
+	RevokedAt  *time.Time
+}
+
+// ClientInfo - данные о клиенте, с которого выполнен вход
+type ClientInfo struct {
+	UserAgent string
+	IP        string
+}
+
+func NewSession(userID uuid.UUID, client ClientInfo, ttl time.Duration) Session {
+	return Session{
+		ID:        uuid.New(),
+		UserID:    userID,
+		UserAgent: client.UserAgent,
+		IP:        client.IP,
+		ExpiresAt: time.Now().Add(ttl),
+	}
+}
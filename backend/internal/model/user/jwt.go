@@ -7,5 +7,6 @@ type JWTClaims struct {
 	OstrovokLogin string `json:"ostrovok_login"`
 	Email         string `json:"email"`
 	IsAdmin       bool   `json:"is_admin"`
+	SessionID     string `json:"sid"`
 	jwt.RegisteredClaims
 }
@@ -3,6 +3,15 @@ package user
 import (
 	"github.com/google/uuid"
 	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/achievement"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/promocode"
+)
+
+type Status string
+
+const (
+	// StatusPending - аккаунт зарегистрирован, но владение учеткой Островка еще не подтверждено
+	StatusPending = Status("pending")
+	StatusActive  = Status("active")
 )
 
 type User struct {
@@ -10,6 +19,10 @@ type User struct {
 	OstrovokLogin string
 	Email         string
 	IsAdmin       bool
+	Status        Status
+	Roles         []string
+	Permissions   []string
 	Rating        int // Сделал проверку на <0 в usecase
 	Achievements  []achievement.Achievement
+	Promocodes    []promocode.Promocode
 }
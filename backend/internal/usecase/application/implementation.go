@@ -8,6 +8,7 @@ import (
 	"github.com/google/uuid"
 	applicationRepo "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/application"
 	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/application"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/pkg"
 )
 
 type ApplicationService struct {
@@ -24,40 +25,63 @@ func (s *ApplicationService) CreateApplication(
 	ctx context.Context,
 	userId uuid.UUID,
 	offerId uuid.UUID,
-) (uuid.UUID, error) {
+) (*application.Application, error) {
 	newApplication := application.NewApplication(userId, offerId)
 
 	err := s.repo.CreateApplication(ctx, newApplication)
 
 	switch {
 	case errors.Is(err, applicationRepo.ErrOfferNotExist) ||
+		errors.Is(err, applicationRepo.ErrOfferNotOpen) ||
 		errors.Is(err, applicationRepo.ErrUserNotExist) ||
-		errors.Is(err, applicationRepo.ErrParticipantsLimit) ||
 		errors.Is(err, applicationRepo.ErrAppLimit):
-		return uuid.UUID{}, err
+		return nil, err
 	case err != nil:
-		return uuid.UUID{}, fmt.Errorf("failed to create application in repo: %w", err)
+		return nil, fmt.Errorf("failed to create application in repo: %w", err)
 	}
 
-	return newApplication.Id, nil
+	// Статус выставляет репозиторий: участник или лист ожидания
+	return newApplication, nil
 }
 
 func (s *ApplicationService) GetApplications(
 	ctx context.Context,
 	userId uuid.UUID,
-	pageNum, pageSize int,
-) ([]*application.Application, int, error) {
-	applications, pagesCount, err := s.repo.GetApplications(ctx, userId, pageNum, pageSize)
+	page pkg.PageRequest,
+) (pkg.Page[*application.Application], error) {
+	if err := page.Validate(); err != nil {
+		return pkg.Page[*application.Application]{}, err
+	}
 
-	if err == applicationRepo.ErrPageNotFound {
-		return nil, 0, err
+	if cursor, ok := page.Cursor.Get(); ok {
+		var after pkg.Opt[application.Cursor]
+		if cursor != "" {
+			var value application.Cursor
+			if err := pkg.DecodeCursor(cursor, &value); err != nil {
+				return pkg.Page[*application.Application]{}, err
+			}
+			after = pkg.NewWithValue(value)
+		}
+
+		// Лишняя заявка показывает, что есть следующая страница
+		applications, err := s.repo.GetApplications(ctx, userId, page.Size+1, 0, after)
+		if err != nil {
+			return pkg.Page[*application.Application]{}, fmt.Errorf("failed to get all applications from repo: %w", err)
+		}
+		return pkg.CursorPage(applications, page.Size, application.NewCursor)
+	}
+
+	applications, err := s.repo.GetApplications(ctx, userId, page.Size, page.Offset(), pkg.NewEmpty[application.Cursor]())
+	if err != nil {
+		return pkg.Page[*application.Application]{}, fmt.Errorf("failed to get all applications from repo: %w", err)
 	}
 
+	count, err := s.repo.CountApplications(ctx, userId)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to get all applications from repo: %w", err)
+		return pkg.Page[*application.Application]{}, fmt.Errorf("failed to count applications in repo: %w", err)
 	}
 
-	return applications, pagesCount, nil
+	return pkg.NumberPage(applications, count, page.Size), nil
 }
 
 func (s *ApplicationService) GetApplicationById(
@@ -82,6 +106,29 @@ func (s *ApplicationService) GetApplicationById(
 	return app, nil
 }
 
+func (s *ApplicationService) Withdraw(
+	ctx context.Context,
+	userId uuid.UUID,
+	applicationId uuid.UUID,
+) error {
+	if _, err := s.GetApplicationById(ctx, userId, applicationId); err != nil {
+		return err
+	}
+
+	err := s.repo.Withdraw(ctx, applicationId)
+
+	switch {
+	case errors.Is(err, applicationRepo.ErrApplicationNotFound) ||
+		errors.Is(err, applicationRepo.ErrOfferNotOpen) ||
+		errors.Is(err, applicationRepo.ErrNotWithdrawable):
+		return err
+	case err != nil:
+		return fmt.Errorf("failed to withdraw application in repo: %w", err)
+	}
+
+	return nil
+}
+
 func (s *ApplicationService) GetUserAppLimitInfo(ctx context.Context, userID uuid.UUID) (*application.UserAppLimitInfo, error) {
 	info, err := s.repo.GetUserAppLimitInfo(ctx, userID)
 	if err != nil {
@@ -99,8 +146,5 @@ func (s *ApplicationService) GetByFilter(ctx context.Context, filter *applicatio
 	if err != nil {
 		return nil, 0, err
 	}
-	if count%int(filter.Limit) == 0 {
-		return app, count / int(filter.Limit), nil
-	}
-	return app, count/int(filter.Limit) + 1, nil
+	return app, pkg.PagesCount(count, filter.Limit), nil
 }
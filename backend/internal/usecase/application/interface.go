@@ -5,12 +5,14 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/application"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/pkg"
 )
 
 type ApplicationUseCase interface {
-	CreateApplication(ctx context.Context, userId uuid.UUID, offerId uuid.UUID) (uuid.UUID, error)
-	GetApplications(ctx context.Context, userId uuid.UUID, pageNum, pageSize int) ([]*application.Application, int, error)
+	CreateApplication(ctx context.Context, userId uuid.UUID, offerId uuid.UUID) (*application.Application, error)
+	GetApplications(ctx context.Context, userId uuid.UUID, page pkg.PageRequest) (pkg.Page[*application.Application], error)
 	GetApplicationById(ctx context.Context, userId uuid.UUID, applicationId uuid.UUID) (*application.Application, error)
+	Withdraw(ctx context.Context, userId uuid.UUID, applicationId uuid.UUID) error
 	GetUserAppLimitInfo(ctx context.Context, userID uuid.UUID) (*application.UserAppLimitInfo, error)
 	GetByFilter(ctx context.Context, filter *application.Filter) ([]*application.Application, int, error)
 }
@@ -0,0 +1,126 @@
+package catalog
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	model "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/catalog"
+)
+
+const maxLineSize = 1024 * 1024
+
+type jsonItem struct {
+	Type               string `json:"type"`
+	ExternalID         string `json:"external_id"`
+	Name               string `json:"name"`
+	LocationExternalID string `json:"location_external_id"`
+}
+
+// parseJSONLines читает по объекту на строку. Битые строки не прерывают разбор, а попадают в invalid
+func parseJSONLines(r io.Reader) (items []model.Item, invalid []model.RowError, err error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		var row jsonItem
+		if err := json.Unmarshal([]byte(text), &row); err != nil {
+			invalid = append(invalid, model.RowError{Line: line, Reason: "invalid json: " + err.Error()})
+			continue
+		}
+
+		items = append(items, model.Item{
+			Line:               line,
+			Kind:               model.Kind(row.Type),
+			ExternalID:         row.ExternalID,
+			Name:               row.Name,
+			LocationExternalID: row.LocationExternalID,
+		})
+	}
+
+	if err := scanner.Err(); err != nil {
+		if errors.Is(err, bufio.ErrTooLong) {
+			return nil, nil, fmt.Errorf("%w: line %d is longer than %d bytes", ErrInvalidFeed, line+1, maxLineSize)
+		}
+		return nil, nil, fmt.Errorf("failed to read feed: %w", err)
+	}
+
+	return items, invalid, nil
+}
+
+var csvColumns = []string{"type", "external_id", "name", "location_external_id"}
+
+// parseCSV ожидает заголовок. Колонка location_external_id нужна только если в фиде есть отели
+func parseCSV(r io.Reader) (items []model.Item, invalid []model.RowError, err error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	var parseErr *csv.ParseError
+	if errors.Is(err, io.EOF) || errors.As(err, &parseErr) {
+		return nil, nil, fmt.Errorf("%w: invalid csv header: %s", ErrInvalidFeed, err.Error())
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read csv header: %w", err)
+	}
+
+	index := make(map[string]int, len(header))
+	for i, column := range header {
+		index[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+
+	for _, column := range csvColumns[:3] {
+		if _, ok := index[column]; !ok {
+			return nil, nil, fmt.Errorf("%w: csv header has no %s column", ErrInvalidFeed, column)
+		}
+	}
+
+	field := func(record []string, column string) string {
+		i, ok := index[column]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			invalid = append(invalid, model.RowError{Line: parseErr.Line, Reason: "invalid csv: " + parseErr.Err.Error()})
+			continue
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read csv: %w", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+
+		items = append(items, model.Item{
+			Line:               line,
+			Kind:               model.Kind(strings.ToLower(field(record, "type"))),
+			ExternalID:         field(record, "external_id"),
+			Name:               field(record, "name"),
+			LocationExternalID: field(record, "location_external_id"),
+		})
+	}
+
+	return items, invalid, nil
+}
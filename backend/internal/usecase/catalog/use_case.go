@@ -0,0 +1,115 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/catalog"
+	model "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/catalog"
+)
+
+var ErrUnknownFormat = errors.New("unknown catalog feed format")
+
+// ErrInvalidFeed - фид нельзя разобрать целиком: нет заголовка или обязательной колонки, слишком длинная строка
+var ErrInvalidFeed = errors.New("invalid catalog feed")
+
+type UseCase interface {
+	// Import загружает фид каталога: локации, отели и типы номеров сопоставляются по external id
+	Import(ctx context.Context, feed io.Reader, format model.Format, dryRun bool) (model.Result, error)
+}
+
+type useCase struct {
+	repo catalog.Repo
+}
+
+func NewUseCase(repo catalog.Repo) UseCase {
+	return &useCase{
+		repo: repo,
+	}
+}
+
+func (u *useCase) Import(ctx context.Context, feed io.Reader, format model.Format, dryRun bool) (model.Result, error) {
+	var (
+		items   []model.Item
+		invalid []model.RowError
+		err     error
+	)
+
+	switch format {
+	case model.FormatJSONLines:
+		items, invalid, err = parseJSONLines(feed)
+	case model.FormatCSV:
+		items, invalid, err = parseCSV(feed)
+	default:
+		return model.Result{}, ErrUnknownFormat
+	}
+
+	if err != nil {
+		return model.Result{}, err
+	}
+
+	valid := make([]model.Item, 0, len(items))
+	for _, item := range items {
+		if reason := validate(item); reason != "" {
+			invalid = append(invalid, model.RowError{Line: item.Line, ExternalID: item.ExternalID, Reason: reason})
+			continue
+		}
+		valid = append(valid, item)
+	}
+
+	sort.SliceStable(valid, func(i, j int) bool {
+		return valid[i].Kind.Order() < valid[j].Kind.Order()
+	})
+
+	result, err := u.repo.Import(ctx, valid, dryRun)
+	if err != nil {
+		return model.Result{}, fmt.Errorf("failed to import catalog: %w", err)
+	}
+
+	result.Skipped += len(invalid)
+	result.Errors = append(invalid, result.Errors...)
+
+	sort.SliceStable(result.Errors, func(i, j int) bool {
+		return result.Errors[i].Line < result.Errors[j].Line
+	})
+
+	return result, nil
+}
+
+func validate(item model.Item) string {
+	switch item.Kind {
+	case model.KindLocation, model.KindRoom:
+	case model.KindHotel:
+		if item.LocationExternalID == "" {
+			return "location_external_id is required for hotel"
+		}
+	default:
+		return fmt.Sprintf("unknown type %q", item.Kind)
+	}
+
+	if item.ExternalID == "" {
+		return "external_id is required"
+	}
+
+	if strings.TrimSpace(item.Name) == "" {
+		return "name is required"
+	}
+
+	return ""
+}
+
+// FormatFromFileName определяет формат фида по расширению файла
+func FormatFromFileName(name string) model.Format {
+	switch {
+	case strings.HasSuffix(strings.ToLower(name), ".csv"):
+		return model.FormatCSV
+	case strings.HasSuffix(strings.ToLower(name), ".jsonl"), strings.HasSuffix(strings.ToLower(name), ".ndjson"):
+		return model.FormatJSONLines
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,155 @@
+package draw
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/draw"
+	model "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/draw"
+	userModel "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/user"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/pkg"
+)
+
+type UseCase interface {
+	// DrawReady разыгрывает до limit офферов, прием заявок в которых закончился, каждый своей транзакцией.
+	// Оффер, который не удалось разыграть, откладывается до следующего запуска. Возвращает число разыгранных
+	DrawReady(ctx context.Context, limit int) (int, error)
+	GetByID(ctx context.Context, id uuid.UUID) (model.Draw, error)
+	GetByOfferID(ctx context.Context, offerID uuid.UUID) ([]model.Draw, error)
+	// Replay повторяет сохраненный розыгрыш по его seed и сверяет победителей
+	Replay(ctx context.Context, id uuid.UUID) (model.Replay, error)
+}
+
+type useCase struct {
+	repo draw.Repo
+}
+
+func NewUseCase(repo draw.Repo) UseCase {
+	return &useCase{repo: repo}
+}
+
+func (u *useCase) DrawReady(ctx context.Context, limit int) (int, error) {
+	drawn := 0
+	var failed []uuid.UUID
+
+	for drawn+len(failed) < limit {
+		offerID, ok, err := u.repo.DrawNext(ctx, failed, u.draw)
+		if !ok {
+			return drawn, err
+		}
+		if err != nil {
+			log.Printf("failed to draw offer %s: %v", offerID, err)
+			failed = append(failed, offerID)
+			continue
+		}
+		drawn++
+	}
+
+	return drawn, nil
+}
+
+// draw разыгрывает winnersCount мест среди кандидатов и готовит запись аудита.
+// Запись сохраняется в той же транзакции, что и результат: розыгрыш, который нельзя показать, не проводим
+func (u *useCase) draw(offerID uuid.UUID, winnersCount int, candidates []model.Candidate) (model.Draw, error) {
+	result := pkg.DrawByRating(toUsers(candidates), winnersCount, pkg.NewDrawSeed())
+
+	record := model.Draw{
+		ID:           uuid.New(),
+		OfferID:      offerID,
+		Algorithm:    result.Algorithm,
+		Seed:         result.Seed,
+		WinnersCount: winnersCount,
+		Candidates:   make([]model.Candidate, len(candidates)),
+	}
+	for i, candidate := range candidates {
+		candidate.Weight = result.Candidates[i].Weight
+		record.Candidates[i] = candidate
+	}
+
+	if _, err := applyWinners(record.Candidates, result.Winners); err != nil {
+		return model.Draw{}, err
+	}
+	return record, nil
+}
+
+func (u *useCase) GetByID(ctx context.Context, id uuid.UUID) (model.Draw, error) {
+	return u.repo.GetByID(ctx, id)
+}
+
+func (u *useCase) GetByOfferID(ctx context.Context, offerID uuid.UUID) ([]model.Draw, error) {
+	return u.repo.GetByOfferID(ctx, offerID)
+}
+
+func (u *useCase) Replay(ctx context.Context, id uuid.UUID) (model.Replay, error) {
+	record, err := u.repo.GetByID(ctx, id)
+	if err != nil {
+		return model.Replay{}, err
+	}
+
+	saved := pkg.Draw{
+		Algorithm:  record.Algorithm,
+		Seed:       record.Seed,
+		Candidates: make([]pkg.DrawCandidate, len(record.Candidates)),
+	}
+	for i, candidate := range record.Candidates {
+		saved.Candidates[i] = pkg.DrawCandidate{UserID: candidate.UserID, Rating: candidate.Rating, Weight: candidate.Weight}
+	}
+
+	replayed, err := pkg.ReplayDraw(saved, record.WinnersCount)
+	if err != nil {
+		return model.Replay{}, err
+	}
+
+	weightsMatch := true
+	for i, candidate := range replayed.Candidates {
+		if candidate.Weight != saved.Candidates[i].Weight {
+			weightsMatch = false
+		}
+	}
+
+	// Порядок выбора повтора записываем в копию кандидатов, чтобы сравнить с сохраненным
+	candidates := slices.Clone(record.Candidates)
+	winners, err := applyWinners(candidates, replayed.Winners)
+	if err != nil {
+		return model.Replay{}, err
+	}
+
+	return model.Replay{
+		Draw:         record,
+		Winners:      winners,
+		WeightsMatch: weightsMatch,
+		WinnersMatch: slices.Equal(winners, record.Winners()),
+	}, nil
+}
+
+func toUsers(candidates []model.Candidate) []userModel.User {
+	users := make([]userModel.User, len(candidates))
+	for i, candidate := range candidates {
+		users[i] = userModel.User{ID: candidate.UserID, Rating: candidate.Rating}
+	}
+	return users
+}
+
+// applyWinners проставляет кандидатам номер победителя и возвращает их заявки в порядке выбора
+func applyWinners(candidates []model.Candidate, winnerUserIDs []uuid.UUID) ([]uuid.UUID, error) {
+	byUser := make(map[uuid.UUID]int, len(candidates))
+	for i, candidate := range candidates {
+		candidates[i].WinnerOrder = nil
+		byUser[candidate.UserID] = i
+	}
+
+	winners := make([]uuid.UUID, 0, len(winnerUserIDs))
+	for order, userID := range winnerUserIDs {
+		i, ok := byUser[userID]
+		if !ok {
+			return nil, fmt.Errorf("winner %s is not a draw candidate", userID)
+		}
+		winnerOrder := order + 1
+		candidates[i].WinnerOrder = &winnerOrder
+		winners = append(winners, candidates[i].ApplicationID)
+	}
+	return winners, nil
+}
@@ -2,24 +2,53 @@ package hotel
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log"
+	"mime/multipart"
+	"path/filepath"
 
 	"github.com/google/uuid"
 	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/hotel"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/location"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/s3/image"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/catalog"
 	model "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/hotel"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/report"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/pkg"
+)
+
+var (
+	ErrInvalidCoordinates = errors.New("latitude must be in [-90, 90] and longitude in [-180, 180]")
+	ErrInvalidStars       = fmt.Errorf("stars must be in [%d, %d]", model.MinStars, model.MaxStars)
+	ErrNoImages           = errors.New("no images to upload")
 )
 
 type UseCase interface {
 	GetAll(ctx context.Context) ([]model.Hotel, error)
+	// GetPage без курсора возвращает весь справочник
+	GetPage(ctx context.Context, page pkg.PageRequest) (pkg.Page[model.Hotel], error)
+	GetByID(ctx context.Context, id uuid.UUID) (model.Hotel, error)
 	Create(ctx context.Context, create model.Create) (uuid.UUID, error)
+	Update(ctx context.Context, update model.Update) (model.Hotel, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// AddImages загружает обложки в хранилище и добавляет их в конец списка
+	AddImages(ctx context.Context, hotelID uuid.UUID, images []*multipart.FileHeader) (model.Hotel, error)
+	DeleteImage(ctx context.Context, hotelID, imageID uuid.UUID) error
 }
 
 type useCase struct {
-	repo hotel.Repo
+	repo         hotel.Repo
+	locationRepo location.Repo
+	imageRepo    image.Repo
 }
 
-func NewUseCase(repo hotel.Repo) UseCase {
+func NewUseCase(repo hotel.Repo, locationRepo location.Repo, imageRepo image.Repo) UseCase {
 	return &useCase{
-		repo: repo,
+		repo:         repo,
+		locationRepo: locationRepo,
+		imageRepo:    imageRepo,
 	}
 }
 
@@ -27,6 +56,137 @@ func (u *useCase) GetAll(ctx context.Context) ([]model.Hotel, error) {
 	return u.repo.GetAll(ctx)
 }
 
+func (u *useCase) GetPage(ctx context.Context, page pkg.PageRequest) (pkg.Page[model.Hotel], error) {
+	cursor, ok := page.Cursor.Get()
+	if !ok {
+		hotels, err := u.repo.GetAll(ctx)
+		return pkg.Page[model.Hotel]{Items: hotels}, err
+	}
+	if err := page.Validate(); err != nil {
+		return pkg.Page[model.Hotel]{}, err
+	}
+
+	after, err := catalog.ParseCursor(cursor)
+	if err != nil {
+		return pkg.Page[model.Hotel]{}, err
+	}
+
+	hotels, err := u.repo.GetPage(ctx, page.Size+1, after)
+	if err != nil {
+		return pkg.Page[model.Hotel]{}, err
+	}
+	return pkg.CursorPage(hotels, page.Size, func(hotel model.Hotel) catalog.Cursor {
+		return catalog.Cursor{Name: hotel.Name, ID: hotel.ID}
+	})
+}
+
+func (u *useCase) GetByID(ctx context.Context, id uuid.UUID) (model.Hotel, error) {
+	return u.repo.GetByID(ctx, id)
+}
+
 func (u *useCase) Create(ctx context.Context, create model.Create) (uuid.UUID, error) {
+	if point, ok := create.Point.Get(); ok && !point.Valid() {
+		return uuid.Nil, ErrInvalidCoordinates
+	}
+	if stars, ok := create.Stars.Get(); ok && !validStars(stars) {
+		return uuid.Nil, ErrInvalidStars
+	}
+	if err := u.checkLocation(ctx, create.LocationID); err != nil {
+		return uuid.Nil, err
+	}
 	return u.repo.Create(ctx, create)
 }
+
+func (u *useCase) Update(ctx context.Context, update model.Update) (model.Hotel, error) {
+	if point, ok := update.Point.Get(); ok && !point.Valid() {
+		return model.Hotel{}, ErrInvalidCoordinates
+	}
+	if stars, ok := update.Stars.Get(); ok && !validStars(stars) {
+		return model.Hotel{}, ErrInvalidStars
+	}
+	if locationID, ok := update.LocationID.Get(); ok {
+		if err := u.checkLocation(ctx, locationID); err != nil {
+			return model.Hotel{}, err
+		}
+	}
+
+	if err := u.repo.Update(ctx, update); err != nil {
+		return model.Hotel{}, err
+	}
+	return u.repo.GetByID(ctx, update.ID)
+}
+
+func (u *useCase) Delete(ctx context.Context, id uuid.UUID) error {
+	return u.repo.Delete(ctx, id)
+}
+
+func (u *useCase) AddImages(ctx context.Context, hotelID uuid.UUID, files []*multipart.FileHeader) (model.Hotel, error) {
+	if len(files) == 0 {
+		return model.Hotel{}, ErrNoImages
+	}
+
+	// Не загружаем файлы в хранилище для несуществующего отеля
+	if _, err := u.repo.GetByID(ctx, hotelID); err != nil {
+		return model.Hotel{}, err
+	}
+
+	images := make([]model.Image, 0, len(files))
+	for _, file := range files {
+		url, err := u.saveImage(ctx, file)
+		if err != nil {
+			u.removeImages(ctx, images)
+			return model.Hotel{}, err
+		}
+		images = append(images, model.Image{ID: uuid.New(), Link: string(url)})
+	}
+
+	if err := u.repo.AddImages(ctx, hotelID, images); err != nil {
+		u.removeImages(ctx, images)
+		return model.Hotel{}, err
+	}
+	return u.repo.GetByID(ctx, hotelID)
+}
+
+func (u *useCase) DeleteImage(ctx context.Context, hotelID, imageID uuid.UUID) error {
+	deleted, err := u.repo.DeleteImage(ctx, hotelID, imageID)
+	if err != nil {
+		return err
+	}
+
+	// Запись уже удалена, оставшийся в хранилище файл ни на что не влияет
+	if err := u.imageRepo.Delete(ctx, report.ImageURL(deleted.Link)); err != nil {
+		log.Println("failed to delete hotel image from storage", err)
+	}
+	return nil
+}
+
+func (u *useCase) checkLocation(ctx context.Context, locationID uuid.UUID) error {
+	_, err := u.locationRepo.GetByID(ctx, locationID)
+	if errors.Is(err, location.ErrNotFound) {
+		return hotel.ErrLocationNotFound
+	}
+	return err
+}
+
+func (u *useCase) saveImage(ctx context.Context, file *multipart.FileHeader) (report.ImageURL, error) {
+	content, err := file.Open()
+	if err != nil {
+		return "", err
+	}
+	defer content.Close()
+
+	return u.imageRepo.Save(ctx, filepath.Ext(file.Filename), file.Header.Get("Content-Type"), content)
+}
+
+// removeImages убирает из хранилища файлы, которые не удалось сохранить в базе
+func (u *useCase) removeImages(ctx context.Context, images []model.Image) {
+	for _, img := range images {
+		if err := u.imageRepo.Delete(ctx, report.ImageURL(img.Link)); err != nil {
+			log.Println("failed to delete hotel image from storage", err)
+		}
+	}
+}
+
+func validStars(stars int) bool {
+	return stars >= model.MinStars && stars <= model.MaxStars
+}
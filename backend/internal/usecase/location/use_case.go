@@ -5,12 +5,19 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/location"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/catalog"
 	model "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/location"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/pkg"
 )
 
 type UseCase interface {
 	GetAll(ctx context.Context) ([]model.Location, error)
+	// GetPage без курсора возвращает весь справочник
+	GetPage(ctx context.Context, page pkg.PageRequest) (pkg.Page[model.Location], error)
+	GetByID(ctx context.Context, id uuid.UUID) (model.Location, error)
 	Create(ctx context.Context, name string) (uuid.UUID, error)
+	Rename(ctx context.Context, id uuid.UUID, name string) (model.Location, error)
+	Delete(ctx context.Context, id uuid.UUID) error
 }
 
 type useCase struct {
@@ -27,6 +34,45 @@ func (u *useCase) GetAll(ctx context.Context) ([]model.Location, error) {
 	return u.repo.GetAll(ctx)
 }
 
+func (u *useCase) GetPage(ctx context.Context, page pkg.PageRequest) (pkg.Page[model.Location], error) {
+	cursor, ok := page.Cursor.Get()
+	if !ok {
+		locations, err := u.repo.GetAll(ctx)
+		return pkg.Page[model.Location]{Items: locations}, err
+	}
+	if err := page.Validate(); err != nil {
+		return pkg.Page[model.Location]{}, err
+	}
+
+	after, err := catalog.ParseCursor(cursor)
+	if err != nil {
+		return pkg.Page[model.Location]{}, err
+	}
+
+	locations, err := u.repo.GetPage(ctx, page.Size+1, after)
+	if err != nil {
+		return pkg.Page[model.Location]{}, err
+	}
+	return pkg.CursorPage(locations, page.Size, func(location model.Location) catalog.Cursor {
+		return catalog.Cursor{Name: location.Name, ID: location.ID}
+	})
+}
+
+func (u *useCase) GetByID(ctx context.Context, id uuid.UUID) (model.Location, error) {
+	return u.repo.GetByID(ctx, id)
+}
+
 func (u *useCase) Create(ctx context.Context, name string) (uuid.UUID, error) {
 	return u.repo.Create(ctx, name)
 }
+
+func (u *useCase) Rename(ctx context.Context, id uuid.UUID, name string) (model.Location, error) {
+	if err := u.repo.Rename(ctx, id, name); err != nil {
+		return model.Location{}, err
+	}
+	return u.repo.GetByID(ctx, id)
+}
+
+func (u *useCase) Delete(ctx context.Context, id uuid.UUID) error {
+	return u.repo.Delete(ctx, id)
+}
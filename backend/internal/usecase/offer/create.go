@@ -4,14 +4,69 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/hotel"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/room"
 	model "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/offer"
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrHotelNotFound  = errors.New("hotel not found")
+	ErrRoomNotFound   = errors.New("room not found")
+	ErrRoomNotInHotel = errors.New("room does not belong to hotel")
 )
 
 func (u *useCase) Create(ctx context.Context, create model.Create) (uuid.UUID, error) {
 	id := uuid.New()
+	if err := prepareCreate(&create); err != nil {
+		return uuid.Nil, err
+	}
+	if err := u.CheckRoom(ctx, create.HotelID, create.RoomID); err != nil {
+		return uuid.Nil, err
+	}
 	err := u.repo.Create(ctx, id, create)
 	if err != nil {
 		return uuid.Nil, err
 	}
 	return id, nil
 }
+
+// prepareCreate проставляет значения по умолчанию и проверяет новый оффер
+func prepareCreate(create *model.Create) error {
+	if create.Status == "" {
+		create.Status = model.StatusPublished
+	}
+	if create.Status != model.StatusDraft && create.Status != model.StatusPublished {
+		return ErrInvalidTransition
+	}
+	if create.WinnersCount == 0 {
+		create.WinnersCount = 1
+	}
+	if create.WinnersCount > create.ParticipantsLimit {
+		return ErrInvalidWinnersCount
+	}
+	return prepareChecklist(create.Checklist)
+}
+
+// CheckRoom - отель не удален, а номер общий или принадлежит этому отелю
+func (u *useCase) CheckRoom(ctx context.Context, hotelID, roomID uuid.UUID) error {
+	if _, err := u.hotelRepo.GetByID(ctx, hotelID); err != nil {
+		if errors.Is(err, hotel.ErrNotFound) {
+			return ErrHotelNotFound
+		}
+		return err
+	}
+
+	r, err := u.roomRepo.GetByID(ctx, roomID)
+	if err != nil {
+		if errors.Is(err, room.ErrNotFound) {
+			return ErrRoomNotFound
+		}
+		return err
+	}
+
+	if !r.BelongsTo(hotelID) {
+		return ErrRoomNotInHotel
+	}
+	return nil
+}
@@ -2,10 +2,132 @@ package offer
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
 
+	"github.com/google/uuid"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/offer"
 	model "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/offer"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/pkg"
+	"github.com/pkg/errors"
 )
 
-func (u *useCase) Edit(ctx context.Context, edit model.Edit) error {
-	return u.repo.Edit(ctx, edit)
+var (
+	// ErrOfferLocked - розыгрыш уже начался, условия оффера менять нельзя
+	ErrOfferLocked              = errors.New("offer can not be edited after draw has started")
+	ErrExpirationAfterCheckIn   = errors.New("expiration_at must be before check_in_at")
+	ErrCheckOutBeforeCheckIn    = errors.New("check_out_at must be after check_in_at")
+	ErrInvalidParticipantsLimit = errors.New("participants_limit must be positive and not less than current participants count")
+	ErrInvalidWinnersCount      = errors.New("winners_count must be positive and not greater than participants_limit")
+	ErrInvalidChecklist         = errors.New("checklist items must have title and type yes_no, score, text or photo")
+)
+
+// Edit проверяет правку на фоне текущего состояния оффера и возвращает оффер после изменения
+func (u *useCase) Edit(ctx context.Context, edit model.Edit) (model.Offer, error) {
+	current, err := u.GetByID(ctx, edit.OfferID)
+	if err != nil {
+		return model.Offer{}, err
+	}
+
+	if !current.Status.Editable() {
+		return model.Offer{}, ErrOfferLocked
+	}
+
+	if current.Version != edit.Version {
+		return model.Offer{}, offer.ErrStatusChanged
+	}
+
+	if err := validateEdit(current, edit); err != nil {
+		return model.Offer{}, err
+	}
+
+	_, hotelSet := edit.HotelID.Get()
+	_, roomSet := edit.RoomID.Get()
+	if hotelSet || roomSet {
+		err := u.CheckRoom(ctx, valueOr(edit.HotelID, current.HotelID), valueOr(edit.RoomID, current.RoomID))
+		if err != nil {
+			return model.Offer{}, err
+		}
+	}
+
+	if _, err := u.repo.Edit(ctx, edit); err != nil {
+		return model.Offer{}, err
+	}
+
+	return u.GetByID(ctx, edit.OfferID)
+}
+
+func validateEdit(current model.Offer, edit model.Edit) error {
+	_, checkInSet := edit.CheckIn.Get()
+	_, checkOutSet := edit.CheckOut.Get()
+	_, expirationSet := edit.ExpirationAT.Get()
+
+	// Старые офферы могли быть заведены с любыми датами, поэтому порядок проверяем только при смене дат
+	if checkInSet || checkOutSet || expirationSet {
+		err := validateDates(
+			valueOr(edit.CheckIn, current.CheckIn),
+			valueOr(edit.CheckOut, current.CheckOut),
+			valueOr(edit.ExpirationAT, current.ExpirationAt),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	if limit, ok := edit.ParticipantsLimit.Get(); ok && (limit == 0 || limit < current.ParticipantsCount) {
+		return ErrInvalidParticipantsLimit
+	}
+
+	if checklist, ok := edit.Checklist.Get(); ok {
+		if err := prepareChecklist(checklist); err != nil {
+			return err
+		}
+	}
+
+	winnersCount := valueOr(edit.WinnersCount, current.WinnersCount)
+	participantsLimit := valueOr(edit.ParticipantsLimit, current.ParticipantsLimit)
+	if winnersCount == 0 || winnersCount > participantsLimit {
+		return ErrInvalidWinnersCount
+	}
+
+	return nil
+}
+
+// validateDates - прием заявок заканчивается до заезда, выезд позже заезда
+func validateDates(checkIn, checkOut, expirationAt time.Time) error {
+	if !expirationAt.Before(checkIn) {
+		return ErrExpirationAfterCheckIn
+	}
+
+	if !checkOut.After(checkIn) {
+		return ErrCheckOutBeforeCheckIn
+	}
+
+	return nil
+}
+
+// maxChecklistItems - ограничение на число пунктов в чек-листе
+const maxChecklistItems = 50
+
+// prepareChecklist проверяет пункты чек-листа и выдает им id
+func prepareChecklist(items []model.ChecklistItem) error {
+	if len(items) > maxChecklistItems {
+		return errors.Wrap(ErrInvalidChecklist, fmt.Sprintf("at most %d items allowed", maxChecklistItems))
+	}
+	for i := range items {
+		items[i].Title = strings.TrimSpace(items[i].Title)
+		if items[i].Title == "" || !items[i].Type.Valid() {
+			return errors.Wrap(ErrInvalidChecklist, fmt.Sprintf("item %d", i+1))
+		}
+		items[i].ID = uuid.New()
+	}
+	return nil
+}
+
+func valueOr[T any](opt pkg.Opt[T], fallback T) T {
+	if value, ok := opt.Get(); ok {
+		return value
+	}
+	return fallback
 }
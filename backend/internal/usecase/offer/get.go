@@ -23,53 +23,69 @@ func (u *useCase) GetByID(ctx context.Context, id uuid.UUID) (model.Offer, error
 	if len(offers) != 1 {
 		return model.Offer{}, errors.Wrap(ErrWrongNumOffers, fmt.Sprintf("got %d offers, want: 1", len(offers)))
 	}
+	offers[0].Checklist, err = u.repo.GetChecklist(ctx, id)
+	if err != nil {
+		return model.Offer{}, err
+	}
 	return offers[0], nil
 }
 
-func (u *useCase) GetForPage(
-	ctx context.Context,
-	pageSettings model.PageSettings,
-) (offers []model.Offer, pageCount int, err error) {
+func (u *useCase) GetForPage(ctx context.Context, page pkg.PageRequest) (pkg.Page[model.Offer], error) {
+	return u.GetByFilter(ctx, model.Filter{}, page)
+}
 
-	//GET offers by filter use only limit and offset
-	filter := model.Filter{
-		Limit:  pageSettings.Limit,
-		Offset: pageSettings.Offset,
-	}
-	offers, err = u.repo.GetByFilter(ctx, filter)
-	if err != nil {
-		return nil, 0, err
+// GetByFilter в режиме курсора не считает страницы, а запрашивает на один оффер больше, чтобы узнать о следующей
+func (u *useCase) GetByFilter(ctx context.Context, filter model.Filter, page pkg.PageRequest) (pkg.Page[model.Offer], error) {
+	if err := page.Validate(); err != nil {
+		return pkg.Page[model.Offer]{}, err
 	}
 
-	//GET count
-	count, err := u.repo.GetCount(ctx, filter)
-	if err != nil {
-		return nil, 0, err
-	}
-	pageCount = count / int(pageSettings.Limit)
-	if count%int(pageSettings.Limit) == 0 {
-		return offers, pageCount, nil
+	if cursor, ok := page.Cursor.Get(); ok {
+		if cursor != "" {
+			after, err := model.ParseCursor(cursor)
+			if err != nil {
+				return pkg.Page[model.Offer]{}, err
+			}
+			if !after.Matches(filter) {
+				return pkg.Page[model.Offer]{}, errors.Wrap(pkg.ErrInvalidCursor, "cursor was issued for another sort order")
+			}
+			filter.After = pkg.NewWithValue(after)
+		}
+
+		filter.Limit = page.Size + 1
+		offers, err := u.repo.GetByFilter(ctx, filter)
+		if err != nil {
+			return pkg.Page[model.Offer]{}, err
+		}
+		return pkg.CursorPage(offers, page.Size, func(offer model.Offer) model.Cursor {
+			return model.NewCursor(offer, filter)
+		})
 	}
-	return offers, pageCount + 1, nil
-}
 
-func (u *useCase) GetByFilter(
-	ctx context.Context,
-	filter model.Filter,
-) (offers []model.Offer, pageCount int, err error) {
-	offers, err = u.repo.GetByFilter(ctx, filter)
+	filter.Limit = page.Size
+	filter.Offset = page.Offset()
+	offers, err := u.repo.GetByFilter(ctx, filter)
 	if err != nil {
-		return nil, 0, err
+		return pkg.Page[model.Offer]{}, err
 	}
 
-	//GET count
 	count, err := u.repo.GetCount(ctx, filter)
 	if err != nil {
-		return nil, 0, err
-	}
-	pageCount = count / int(filter.Limit)
-	if count%int(filter.Limit) == 0 {
-		return offers, pageCount, nil
+		return pkg.Page[model.Offer]{}, err
 	}
-	return offers, pageCount + 1, nil
+	return pkg.NumberPage(offers, count, page.Size), nil
+}
+
+// mapOffersLimit - карта показывает не больше стольких ближайших к закрытию офферов
+const mapOffersLimit = 500
+
+// GetForMap - открытые офферы отелей с координатами
+func (u *useCase) GetForMap(ctx context.Context, filter model.Filter) ([]model.Offer, error) {
+	filter.OnlyOpen = true
+	filter.WithCoordinates = true
+	filter.Sort = model.SortExpiration
+	filter.Limit = mapOffersLimit
+	filter.Offset = 0
+
+	return u.repo.GetByFilter(ctx, filter)
 }
@@ -0,0 +1,258 @@
+package offer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/offer"
+	hotelModel "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/hotel"
+	model "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/offer"
+	roomModel "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/room"
+)
+
+var (
+	ErrUnknownImportFormat = errors.New("unknown offers file format")
+	// ErrInvalidImportFile - файл не удалось разобрать целиком: нет заголовка, битый CSV или XLSX
+	ErrInvalidImportFile = errors.New("invalid offers file")
+)
+
+// ImportFormatFromFileName определяет формат по расширению, если его не передали явно
+func ImportFormatFromFileName(name string) model.ImportFormat {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".csv":
+		return model.ImportFormatCSV
+	case ".xlsx":
+		return model.ImportFormatXLSX
+	default:
+		return ""
+	}
+}
+
+func (u *useCase) Import(
+	ctx context.Context,
+	file io.ReaderAt,
+	size int64,
+	format model.ImportFormat,
+	dryRun bool,
+) (model.ImportResult, error) {
+	var (
+		rows []importRow
+		err  error
+	)
+
+	switch format {
+	case model.ImportFormatCSV:
+		rows, err = parseImportCSV(io.NewSectionReader(file, 0, size))
+	case model.ImportFormatXLSX:
+		rows, err = parseImportXLSX(file, size)
+	default:
+		return model.ImportResult{}, ErrUnknownImportFormat
+	}
+
+	if err != nil {
+		return model.ImportResult{}, fmt.Errorf("%w: %v", ErrInvalidImportFile, err)
+	}
+
+	if len(rows) == 0 {
+		return model.ImportResult{}, fmt.Errorf("%w: file has no offers", ErrInvalidImportFile)
+	}
+
+	resolver, err := u.newImportResolver(ctx)
+	if err != nil {
+		return model.ImportResult{}, err
+	}
+
+	result := model.ImportResult{DryRun: dryRun, Rows: len(rows)}
+	creates := make([]model.Create, 0, len(rows))
+	for _, row := range rows {
+		create, reasons := resolver.toCreate(row)
+		if len(reasons) > 0 {
+			result.Errors = append(result.Errors, model.ImportRowError{
+				Line:   row.line,
+				Reason: strings.Join(reasons, "; "),
+			})
+			continue
+		}
+		creates = append(creates, create)
+	}
+
+	// Не трогаем базу, пока в файле есть ошибки: все равно ничего не будет создано
+	if len(result.Errors) > 0 {
+		return result, nil
+	}
+
+	ids := make([]uuid.UUID, len(creates))
+	for i := range ids {
+		ids[i] = uuid.New()
+	}
+
+	err = u.repo.CreateBatch(ctx, ids, creates, dryRun)
+
+	var batchErr *offer.BatchError
+	if errors.As(err, &batchErr) {
+		for i, rowErr := range batchErr.Rows {
+			result.Errors = append(result.Errors, model.ImportRowError{
+				Line:   rows[i].line,
+				Reason: rowErr.Error(),
+			})
+		}
+		sort.Slice(result.Errors, func(i, j int) bool {
+			return result.Errors[i].Line < result.Errors[j].Line
+		})
+		return result, nil
+	}
+
+	if err != nil {
+		return model.ImportResult{}, fmt.Errorf("failed to import offers: %w", err)
+	}
+
+	if !dryRun {
+		result.Created = ids
+	}
+
+	return result, nil
+}
+
+// importResolver находит отели и номера по id или названию без учета регистра.
+// Названия номеров уникальны только в пределах отеля, поэтому по ключу хранятся все подходящие номера
+type importResolver struct {
+	hotels map[string]hotelModel.Hotel
+	rooms  map[string][]roomModel.Room
+}
+
+func (u *useCase) newImportResolver(ctx context.Context) (*importResolver, error) {
+	hotels, err := u.hotelRepo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hotels: %w", err)
+	}
+
+	rooms, err := u.roomRepo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rooms: %w", err)
+	}
+
+	resolver := &importResolver{
+		hotels: make(map[string]hotelModel.Hotel, 2*len(hotels)),
+		rooms:  make(map[string][]roomModel.Room, 2*len(rooms)),
+	}
+	for _, h := range hotels {
+		resolver.hotels[h.ID.String()] = h
+		resolver.hotels[strings.ToLower(h.Name)] = h
+	}
+	for _, r := range rooms {
+		resolver.rooms[r.ID.String()] = append(resolver.rooms[r.ID.String()], r)
+		resolver.rooms[strings.ToLower(r.Name)] = append(resolver.rooms[strings.ToLower(r.Name)], r)
+	}
+
+	return resolver, nil
+}
+
+// findRoom выбирает номер, доступный отелю. Собственный номер отеля важнее общего с тем же названием
+func (r *importResolver) findRoom(key string, hotelID uuid.UUID) (roomModel.Room, bool) {
+	var (
+		found roomModel.Room
+		ok    bool
+	)
+	for _, room := range r.rooms[strings.ToLower(key)] {
+		if !room.BelongsTo(hotelID) {
+			continue
+		}
+		if room.HotelID != nil {
+			return room, true
+		}
+		found, ok = room, true
+	}
+	return found, ok
+}
+
+// toCreate собирает все ошибки строки сразу, чтобы их можно было исправить за один проход
+func (r *importResolver) toCreate(row importRow) (model.Create, []string) {
+	var (
+		create  model.Create
+		reasons []string
+	)
+
+	h, hotelOk := r.hotels[strings.ToLower(row.get("hotel"))]
+	if hotelOk {
+		create.HotelID = h.ID
+		create.LocalID = h.LocationID
+	} else {
+		reasons = append(reasons, fmt.Sprintf("hotel %q not found", row.get("hotel")))
+	}
+
+	// Без отеля нельзя понять, какие номера ему доступны
+	if hotelOk {
+		if room, ok := r.findRoom(row.get("room"), h.ID); ok {
+			create.RoomID = room.ID
+		} else {
+			reasons = append(reasons, fmt.Sprintf("room %q not found in hotel %q", row.get("room"), row.get("hotel")))
+		}
+	}
+
+	create.Task = row.get("task")
+	if create.Task == "" {
+		reasons = append(reasons, "task is required")
+	}
+
+	datesOk := true
+	for _, field := range []struct {
+		column string
+		target *time.Time
+	}{
+		{"check_in", &create.CheckIn},
+		{"check_out", &create.CheckOut},
+		{"expiration_at", &create.ExpirationAT},
+	} {
+		t, err := parseImportTime(row.get(field.column))
+		if err != nil {
+			reasons = append(reasons, field.column+": "+err.Error())
+			datesOk = false
+			continue
+		}
+		*field.target = t
+	}
+
+	if datesOk {
+		if err := validateDates(create.CheckIn, create.CheckOut, create.ExpirationAT); err != nil {
+			reasons = append(reasons, err.Error())
+		}
+	}
+
+	limit, err := parseImportUint(row.get("participants_limit"))
+	switch {
+	case err != nil:
+		reasons = append(reasons, "participants_limit: "+err.Error())
+	case limit == 0:
+		reasons = append(reasons, "participants_limit must be positive")
+	}
+	create.ParticipantsLimit = limit
+
+	if value := row.get("winners_count"); value != "" {
+		create.WinnersCount, err = parseImportUint(value)
+		if err != nil {
+			reasons = append(reasons, "winners_count: "+err.Error())
+		}
+	}
+
+	draft, err := parseImportBool(row.get("draft"))
+	if err != nil {
+		reasons = append(reasons, "draft: "+err.Error())
+	}
+	if draft {
+		create.Status = model.StatusDraft
+	}
+
+	// Те же проверки и значения по умолчанию, что и у одиночного создания
+	if err := prepareCreate(&create); err != nil && limit > 0 {
+		reasons = append(reasons, err.Error())
+	}
+
+	return create, reasons
+}
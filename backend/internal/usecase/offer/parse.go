@@ -0,0 +1,159 @@
+package offer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/pkg"
+)
+
+// importRow - строка файла по названиям колонок из заголовка
+type importRow struct {
+	line   int
+	values map[string]string
+}
+
+func (r importRow) get(column string) string {
+	return strings.TrimSpace(r.values[column])
+}
+
+var (
+	// importRequiredColumns - обязательные колонки. Необязательные: winners_count и draft
+	importRequiredColumns = []string{
+		"hotel", "room", "task", "check_in", "check_out", "expiration_at", "participants_limit",
+	}
+
+	// importColumnAliases - названия колонок как в ответах API
+	importColumnAliases = map[string]string{
+		"check_in_at":  "check_in",
+		"check_out_at": "check_out",
+		"hotel_id":     "hotel",
+		"room_id":      "room",
+	}
+)
+
+func parseImportCSV(r io.Reader) ([]importRow, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read csv: %w", err)
+	}
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	// Excel с русской локалью сохраняет CSV через точку с запятой
+	if header, _, _ := bytes.Cut(data, []byte("\n")); bytes.Count(header, []byte(";")) > bytes.Count(header, []byte(",")) {
+		reader.Comma = ';'
+	}
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read csv: %w", err)
+	}
+
+	return importRowsFromRecords(records)
+}
+
+func parseImportXLSX(r io.ReaderAt, size int64) ([]importRow, error) {
+	records, err := pkg.ReadXLSX(r, size)
+	if err != nil {
+		return nil, err
+	}
+
+	return importRowsFromRecords(records)
+}
+
+// importRowsFromRecords ожидает заголовок в первой строке. Пустые строки пропускаются, номера строк сохраняются
+func importRowsFromRecords(records [][]string) ([]importRow, error) {
+	if len(records) == 0 {
+		return nil, fmt.Errorf("file is empty")
+	}
+
+	header := make([]string, len(records[0]))
+	known := make(map[string]bool, len(header))
+	for i, column := range records[0] {
+		column = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(column, "\ufeff")))
+		if alias, ok := importColumnAliases[column]; ok {
+			column = alias
+		}
+		header[i] = column
+		known[column] = true
+	}
+
+	for _, column := range importRequiredColumns {
+		if !known[column] {
+			return nil, fmt.Errorf("header has no %s column", column)
+		}
+	}
+
+	rows := make([]importRow, 0, len(records)-1)
+	for i, record := range records[1:] {
+		row := importRow{line: i + 2, values: make(map[string]string, len(header))}
+
+		empty := true
+		for j, value := range record {
+			if j >= len(header) || header[j] == "" {
+				continue
+			}
+			row.values[header[j]] = value
+			if strings.TrimSpace(value) != "" {
+				empty = false
+			}
+		}
+
+		if !empty {
+			rows = append(rows, row)
+		}
+	}
+
+	return rows, nil
+}
+
+var importTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"02.01.2006 15:04",
+	"02.01.2006",
+}
+
+// parseImportTime понимает RFC3339, даты без зоны (считаются в UTC) и даты Excel
+func parseImportTime(value string) (time.Time, error) {
+	for _, layout := range importTimeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+
+	if serial, err := strconv.ParseFloat(value, 64); err == nil && serial > 0 {
+		return pkg.ExcelSerialToTime(serial), nil
+	}
+
+	return time.Time{}, fmt.Errorf("invalid date %q", value)
+}
+
+// parseImportUint - XLSX хранит целые числа как "5" или "5.0"
+func parseImportUint(value string) (uint, error) {
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil || n < 0 || n != float64(uint(n)) {
+		return 0, fmt.Errorf("invalid number %q", value)
+	}
+	return uint(n), nil
+}
+
+func parseImportBool(value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "", "0", "false", "no", "нет":
+		return false, nil
+	case "1", "true", "yes", "да":
+		return true, nil
+	default:
+		return false, fmt.Errorf("invalid flag %q", value)
+	}
+}
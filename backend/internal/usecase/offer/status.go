@@ -0,0 +1,135 @@
+package offer
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/google/uuid"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/notification"
+	model "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/offer"
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrInvalidTransition = errors.New("offer status transition is not allowed")
+)
+
+func (u *useCase) Publish(ctx context.Context, id uuid.UUID) error {
+	return u.ChangeStatus(ctx, id, model.StatusPublished)
+}
+
+// ChangeStatus проверяет, что переход допустим из текущего статуса оффера
+func (u *useCase) ChangeStatus(ctx context.Context, id uuid.UUID, to model.Status) error {
+	offer, err := u.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return u.transition(ctx, offer, to)
+}
+
+func (u *useCase) transition(ctx context.Context, offer model.Offer, to model.Status) error {
+	if !offer.Status.CanTransitionTo(to) {
+		return errors.Wrap(ErrInvalidTransition, fmt.Sprintf("%s -> %s", offer.Status, to))
+	}
+
+	return u.repo.UpdateStatus(ctx, offer.ID, offer.Status, to)
+}
+
+// PublishScheduled публикует черновики, созданные по шаблонам, когда наступает их дата публикации.
+// Возвращает число опубликованных офферов
+func (u *useCase) PublishScheduled(ctx context.Context) (int, error) {
+	offers, err := u.repo.GetScheduledForPublish(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	published := 0
+	for _, offer := range offers {
+		// Черновик могли опубликовать или отменить вручную, это не ошибка
+		if err := u.transition(ctx, offer, model.StatusPublished); err != nil {
+			log.Printf("failed to publish scheduled offer %s: %v", offer.ID, err)
+			continue
+		}
+		published++
+	}
+
+	return published, nil
+}
+
+// Cancel отменяет оффер, отклоняет необработанные заявки и сообщает об этом их авторам
+func (u *useCase) Cancel(ctx context.Context, id uuid.UUID) error {
+	offer, err := u.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if !offer.Status.CanTransitionTo(model.StatusCancelled) {
+		return errors.Wrap(ErrInvalidTransition, fmt.Sprintf("%s -> %s", offer.Status, model.StatusCancelled))
+	}
+
+	userIDs, err := u.repo.Cancel(ctx, id, offer.Status)
+	if err != nil {
+		return err
+	}
+
+	// Оффер уже отменен, поэтому ошибки доставки только логируем
+	for _, userID := range userIDs {
+		if err := u.notifyCancelled(ctx, userID, offer); err != nil {
+			log.Printf("failed to notify user %s about cancelled offer %s: %v", userID, offer.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// CompleteByReport завершает оффер, когда проверены отчеты всех победителей
+func (u *useCase) CompleteByReport(ctx context.Context, reportID uuid.UUID) error {
+	offerID, err := u.repo.GetIDByReportID(ctx, reportID)
+	if err != nil {
+		return err
+	}
+
+	offer, err := u.GetByID(ctx, offerID)
+	if err != nil {
+		return err
+	}
+
+	// Повторная проверка отчета оффер уже не меняет
+	if offer.Status == model.StatusCompleted {
+		return nil
+	}
+
+	unreviewed, err := u.repo.CountUnreviewedReports(ctx, offerID)
+	if err != nil {
+		return err
+	}
+
+	if unreviewed > 0 {
+		return nil
+	}
+
+	return u.transition(ctx, offer, model.StatusCompleted)
+}
+
+func (u *useCase) notifyCancelled(ctx context.Context, userID uuid.UUID, offer model.Offer) error {
+	user, err := u.userRepo.GetUserById(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	ostrovokUser, err := u.ostrovokClient.GetUserByLogin(ctx, user.OstrovokLogin)
+	if err != nil {
+		return err
+	}
+
+	return u.notifier.Send(ctx, notification.Message{
+		To:      ostrovokUser.Email,
+		Subject: "Розыгрыш отменен",
+		Body: fmt.Sprintf(
+			"Розыгрыш проверки отеля «%s» (%s - %s) отменен, ваша заявка отклонена. Она не учитывается в лимите активных заявок.",
+			offer.HotelName, offer.CheckIn.Format("02.01.2006"), offer.CheckOut.Format("02.01.2006"),
+		),
+	})
+}
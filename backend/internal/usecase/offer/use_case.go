@@ -2,28 +2,64 @@ package offer
 
 import (
 	"context"
+	"io"
 
 	"github.com/google/uuid"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/notifier"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/ostrovok"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/hotel"
 	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/offer"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/room"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/user"
 	model "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/offer"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/pkg"
 )
 
 type UseCase interface {
 	Create(ctx context.Context, create model.Create) (uuid.UUID, error)
+	// CheckRoom проверяет, что отель не удален, а номер общий или принадлежит этому отелю
+	CheckRoom(ctx context.Context, hotelID, roomID uuid.UUID) error
+	// Import создает офферы из CSV или XLSX файла: все строки сразу или ни одной
+	Import(ctx context.Context, file io.ReaderAt, size int64, format model.ImportFormat, dryRun bool) (model.ImportResult, error)
 
 	GetByID(ctx context.Context, id uuid.UUID) (model.Offer, error)
-	GetForPage(ctx context.Context, pageSettings model.PageSettings) (offers []model.Offer, pageCount int, err error)
-	GetByFilter(ctx context.Context, filter model.Filter) (offers []model.Offer, pagesCount int, err error)
+	GetForPage(ctx context.Context, page pkg.PageRequest) (pkg.Page[model.Offer], error)
+	// GetByFilter - Limit и Offset фильтра задаются страницей
+	GetByFilter(ctx context.Context, filter model.Filter, page pkg.PageRequest) (pkg.Page[model.Offer], error)
+	GetForMap(ctx context.Context, filter model.Filter) ([]model.Offer, error)
 
-	Edit(ctx context.Context, filter model.Edit) error
+	Edit(ctx context.Context, edit model.Edit) (model.Offer, error)
+
+	Publish(ctx context.Context, id uuid.UUID) error
+	Cancel(ctx context.Context, id uuid.UUID) error
+	ChangeStatus(ctx context.Context, id uuid.UUID, to model.Status) error
+	PublishScheduled(ctx context.Context) (int, error)
+	CompleteByReport(ctx context.Context, reportID uuid.UUID) error
 }
 
 type useCase struct {
-	repo offer.Repo
+	repo           offer.Repo
+	userRepo       user.Repo
+	hotelRepo      hotel.Repo
+	roomRepo       room.Repo
+	ostrovokClient ostrovok.Client
+	notifier       notifier.Notifier
 }
 
-func NewUseCase(repo offer.Repo) UseCase {
+func NewUseCase(
+	repo offer.Repo,
+	userRepo user.Repo,
+	hotelRepo hotel.Repo,
+	roomRepo room.Repo,
+	ostrovokClient ostrovok.Client,
+	notifier notifier.Notifier,
+) UseCase {
 	return &useCase{
-		repo: repo,
+		repo:           repo,
+		userRepo:       userRepo,
+		hotelRepo:      hotelRepo,
+		roomRepo:       roomRepo,
+		ostrovokClient: ostrovokClient,
+		notifier:       notifier,
 	}
 }
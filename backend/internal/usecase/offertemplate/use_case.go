@@ -0,0 +1,120 @@
+package offertemplate
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/offertemplate"
+	model "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/offertemplate"
+)
+
+var (
+	ErrInvalidPeriod            = errors.New("period must be weekly or monthly")
+	ErrInvalidParticipantsLimit = errors.New("participants_limit must be positive")
+	ErrInvalidWinnersCount      = errors.New("winners_count must be positive and not greater than participants_limit")
+	ErrInvalidDays              = errors.New("check_in_after_days, nights and application_days must be positive")
+	ErrApplicationAfterCheckIn  = errors.New("application_days must be less than check_in_after_days")
+)
+
+type UseCase interface {
+	Create(ctx context.Context, create model.Create) (uuid.UUID, error)
+	GetByID(ctx context.Context, id uuid.UUID) (model.Template, error)
+	GetAll(ctx context.Context) ([]model.Template, error)
+	SetActive(ctx context.Context, id uuid.UUID, active bool) error
+	// Materialize создает офферы по шаблонам на lookahead вперед
+	Materialize(ctx context.Context) (model.MaterializeResult, error)
+}
+
+// RoomChecker проверяет отель и номер по тем же правилам, что и для офферов
+type RoomChecker interface {
+	CheckRoom(ctx context.Context, hotelID, roomID uuid.UUID) error
+}
+
+type useCase struct {
+	repo      offertemplate.Repo
+	rooms     RoomChecker
+	lookahead time.Duration
+}
+
+func NewUseCase(repo offertemplate.Repo, rooms RoomChecker, lookahead time.Duration) UseCase {
+	return &useCase{
+		repo:      repo,
+		rooms:     rooms,
+		lookahead: lookahead,
+	}
+}
+
+func (u *useCase) Create(ctx context.Context, create model.Create) (uuid.UUID, error) {
+	if create.WinnersCount == 0 {
+		create.WinnersCount = 1
+	}
+
+	if err := validate(create); err != nil {
+		return uuid.Nil, err
+	}
+
+	if err := u.rooms.CheckRoom(ctx, create.HotelID, create.RoomID); err != nil {
+		return uuid.Nil, err
+	}
+
+	id := uuid.New()
+	if err := u.repo.Create(ctx, id, create); err != nil {
+		return uuid.Nil, err
+	}
+	return id, nil
+}
+
+func validate(create model.Create) error {
+	if !create.Period.Valid() {
+		return ErrInvalidPeriod
+	}
+
+	if create.ParticipantsLimit == 0 {
+		return ErrInvalidParticipantsLimit
+	}
+
+	if create.WinnersCount > create.ParticipantsLimit {
+		return ErrInvalidWinnersCount
+	}
+
+	if create.CheckInAfterDays <= 0 || create.Nights <= 0 || create.ApplicationDays <= 0 {
+		return ErrInvalidDays
+	}
+
+	// Розыгрыш должен пройти до заезда
+	if create.ApplicationDays >= create.CheckInAfterDays {
+		return ErrApplicationAfterCheckIn
+	}
+
+	return nil
+}
+
+func (u *useCase) GetByID(ctx context.Context, id uuid.UUID) (model.Template, error) {
+	return u.repo.GetByID(ctx, id)
+}
+
+func (u *useCase) GetAll(ctx context.Context) ([]model.Template, error) {
+	return u.repo.GetAll(ctx)
+}
+
+// SetActive - пока шаблон был выключен, отель или номер могли удалить, поэтому при включении проверяем их заново
+func (u *useCase) SetActive(ctx context.Context, id uuid.UUID, active bool) error {
+	if active {
+		template, err := u.repo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := u.rooms.CheckRoom(ctx, template.HotelID, template.RoomID); err != nil {
+			return err
+		}
+	}
+
+	return u.repo.SetActive(ctx, id, active)
+}
+
+func (u *useCase) Materialize(ctx context.Context) (model.MaterializeResult, error) {
+	return u.repo.Materialize(ctx, time.Now().Add(u.lookahead))
+}
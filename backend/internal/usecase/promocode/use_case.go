@@ -0,0 +1,114 @@
+package promocode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/ostrovok"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/promocode"
+	model "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/promocode"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/pkg"
+)
+
+// maxCodeAttempts - сколько раз запрашиваем новый код у Островка, если сгенерированный уже занят
+const maxCodeAttempts = 5
+
+type UseCase interface {
+	// Issue выдает промокод за принятый отчет, номинал зависит от рейтинга пользователя
+	Issue(ctx context.Context, userID, reportID uuid.UUID, rating int) (model.Promocode, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]model.Promocode, error)
+	GetByFilter(ctx context.Context, filter promocode.Filter) ([]model.Promocode, int, error)
+	Revoke(ctx context.Context, id uuid.UUID) error
+	// Reissue выдает новый код взамен старого с тем же номиналом и сроком действия от текущего момента
+	Reissue(ctx context.Context, id uuid.UUID) (model.Promocode, error)
+	Redeem(ctx context.Context, code string) (model.Promocode, error)
+}
+
+type useCase struct {
+	repo           promocode.Repo
+	ostrovokClient ostrovok.Client
+}
+
+func NewUseCase(repo promocode.Repo, ostrovokClient ostrovok.Client) UseCase {
+	return &useCase{
+		repo:           repo,
+		ostrovokClient: ostrovokClient,
+	}
+}
+
+func (u *useCase) Issue(ctx context.Context, userID, reportID uuid.UUID, rating int) (model.Promocode, error) {
+	nominal := model.NominalForRating(rating)
+
+	return u.withUniqueCode(ctx, func(code string) (model.Promocode, error) {
+		p := model.New(code, userID, reportID, nominal)
+		return p, u.repo.Create(ctx, p)
+	})
+}
+
+func (u *useCase) GetByUserID(ctx context.Context, userID uuid.UUID) ([]model.Promocode, error) {
+	return u.repo.GetByUserID(ctx, userID)
+}
+
+func (u *useCase) GetByFilter(ctx context.Context, filter promocode.Filter) ([]model.Promocode, int, error) {
+	promocodes, err := u.repo.GetByFilter(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	count, err := u.repo.GetCountByFilter(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return promocodes, pkg.PagesCount(count, filter.Limit), nil
+}
+
+func (u *useCase) Revoke(ctx context.Context, id uuid.UUID) error {
+	return u.repo.Revoke(ctx, id)
+}
+
+func (u *useCase) Reissue(ctx context.Context, id uuid.UUID) (model.Promocode, error) {
+	old, err := u.repo.GetByID(ctx, id)
+	if err != nil {
+		return model.Promocode{}, err
+	}
+
+	if old.Status == model.StatusRedeemed || old.ReplacedBy != nil {
+		return model.Promocode{}, promocode.ErrNotActive
+	}
+
+	nominal := model.Nominal{Value: old.Value, Currency: old.Currency}
+
+	return u.withUniqueCode(ctx, func(code string) (model.Promocode, error) {
+		p := model.New(code, old.UserID, old.ReportID, nominal)
+		return p, u.repo.Reissue(ctx, old.ID, p)
+	})
+}
+
+func (u *useCase) Redeem(ctx context.Context, code string) (model.Promocode, error) {
+	return u.repo.Redeem(ctx, code)
+}
+
+// withUniqueCode получает код у Островка и повторяет сохранение, пока код не окажется свободным
+func (u *useCase) withUniqueCode(ctx context.Context, save func(code string) (model.Promocode, error)) (model.Promocode, error) {
+	for range maxCodeAttempts {
+		code, err := u.ostrovokClient.GeneratePromocode(ctx)
+		if err != nil {
+			return model.Promocode{}, fmt.Errorf("failed to generate promocode: %w", err)
+		}
+
+		p, err := save(code)
+		if errors.Is(err, promocode.ErrCodeTaken) {
+			continue
+		}
+		if err != nil {
+			return model.Promocode{}, err
+		}
+
+		return p, nil
+	}
+
+	return model.Promocode{}, fmt.Errorf("failed to find free promocode after %d attempts: %w", maxCodeAttempts, promocode.ErrCodeTaken)
+}
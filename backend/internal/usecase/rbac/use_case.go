@@ -0,0 +1,58 @@
+package rbac
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/rbac"
+	model "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/rbac"
+)
+
+var (
+	ErrSelfRevoke = errors.New("can not revoke own role management access")
+)
+
+type UseCase interface {
+	HasPermission(ctx context.Context, userID uuid.UUID, permission model.Permission) (bool, error)
+	GetRoles(ctx context.Context) ([]model.Role, error)
+	GetUserRoles(ctx context.Context, userID uuid.UUID) ([]string, error)
+	AssignRole(ctx context.Context, userID uuid.UUID, role string) error
+	RevokeRole(ctx context.Context, actorID, userID uuid.UUID, role string) error
+}
+
+type useCase struct {
+	repo rbac.Repo
+}
+
+func NewUseCase(repo rbac.Repo) UseCase {
+	return &useCase{
+		repo: repo,
+	}
+}
+
+// HasPermission каждый раз ходит в базу, поэтому смена ролей действует сразу, без перевыпуска токена
+func (u *useCase) HasPermission(ctx context.Context, userID uuid.UUID, permission model.Permission) (bool, error) {
+	return u.repo.HasPermission(ctx, userID, permission)
+}
+
+func (u *useCase) GetRoles(ctx context.Context) ([]model.Role, error) {
+	return u.repo.GetRoles(ctx)
+}
+
+func (u *useCase) GetUserRoles(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	return u.repo.GetUserRoles(ctx, userID)
+}
+
+func (u *useCase) AssignRole(ctx context.Context, userID uuid.UUID, role string) error {
+	return u.repo.AssignRole(ctx, userID, role)
+}
+
+func (u *useCase) RevokeRole(ctx context.Context, actorID, userID uuid.UUID, role string) error {
+	// Не даем админу случайно лишить себя доступа к управлению ролями
+	if actorID == userID && role == model.RoleAdmin {
+		return ErrSelfRevoke
+	}
+
+	return u.repo.RevokeRole(ctx, userID, role)
+}
@@ -0,0 +1,79 @@
+package report
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	offerModel "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/offer"
+	report2 "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/report"
+)
+
+var (
+	ErrChecklistIncomplete = errors.New("required checklist items are not answered")
+	ErrInvalidAnswer       = errors.New("invalid checklist answer")
+)
+
+// validateAnswers сверяет ответы с чек-листом оффера. photos - сколько фото приложено к каждому пункту.
+// Пункт-фото считается отвеченным, если к нему приложено хотя бы одно фото
+func validateAnswers(checklist []offerModel.ChecklistItem, answers []report2.Answer, photos map[uuid.UUID]int) error {
+	items := make(map[uuid.UUID]offerModel.ChecklistItem, len(checklist))
+	for _, item := range checklist {
+		items[item.ID] = item
+	}
+
+	answered := make(map[uuid.UUID]bool, len(answers))
+	for _, answer := range answers {
+		item, ok := items[answer.ItemID]
+		if !ok {
+			return fmt.Errorf("%w: unknown item %s", ErrInvalidAnswer, answer.ItemID)
+		}
+		if answered[answer.ItemID] {
+			return fmt.Errorf("%w: item %q answered twice", ErrInvalidAnswer, item.Title)
+		}
+		if !answerMatches(item.Type, answer) {
+			return fmt.Errorf("%w: item %q expects %s", ErrInvalidAnswer, item.Title, item.Type)
+		}
+		answered[answer.ItemID] = true
+	}
+
+	for itemID := range photos {
+		if _, ok := items[itemID]; !ok {
+			return fmt.Errorf("%w: photos for unknown item %s", ErrInvalidAnswer, itemID)
+		}
+	}
+
+	var missing []string
+	for _, item := range checklist {
+		done := answered[item.ID]
+		if item.Type == offerModel.ChecklistPhoto {
+			done = photos[item.ID] > 0
+		}
+		if item.Required && !done {
+			missing = append(missing, item.Title)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: %s", ErrChecklistIncomplete, strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+// answerMatches - в ответе заполнено значение нужного типа. К пункту-фото можно оставить текстовый комментарий
+func answerMatches(itemType offerModel.ChecklistItemType, answer report2.Answer) bool {
+	switch itemType {
+	case offerModel.ChecklistYesNo:
+		return answer.Checked != nil && answer.Score == nil && answer.Text == nil
+	case offerModel.ChecklistScore:
+		return answer.Score != nil && *answer.Score >= offerModel.MinScore && *answer.Score <= offerModel.MaxScore &&
+			answer.Checked == nil && answer.Text == nil
+	case offerModel.ChecklistText:
+		return answer.Text != nil && strings.TrimSpace(*answer.Text) != "" && answer.Checked == nil && answer.Score == nil
+	case offerModel.ChecklistPhoto:
+		return answer.Checked == nil && answer.Score == nil
+	default:
+		return false
+	}
+}
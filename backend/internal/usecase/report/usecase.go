@@ -13,12 +13,15 @@ import (
 	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/application"
 	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/user"
 	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/handler/rest/validation"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/usecase/offer"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/usecase/promocode"
 
 	"github.com/google/uuid"
-	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/ostrovok"
+	promocodeRepo "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/promocode"
 	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/report"
 	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/s3/image"
 	report2 "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/report"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/pkg"
 )
 
 var (
@@ -27,12 +30,13 @@ var (
 
 type Usecase interface {
 	GetByID(ctx context.Context, id uuid.UUID) (report2.Report, bool, error)
-	GetByUserID(ctx context.Context, userID uuid.UUID, limit, offset int64) ([]report2.Report, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID, page pkg.PageRequest) (pkg.Page[report2.Report], error)
 	Get(ctx context.Context, limit, offset int64) ([]report2.Report, error)
 	GetByIDAndUserID(ctx context.Context, id, userID uuid.UUID) (report2.Report, bool, error)
 	Count(ctx context.Context) (int64, error)
 	CountByUserId(ctx context.Context, userId uuid.UUID) (int64, error)
-	Update(ctx context.Context, report report2.Report, images []*multipart.FileHeader) error
+	// Update сохраняет отчет с ответами по чек-листу. itemImages - фото, приложенные к пунктам чек-листа
+	Update(ctx context.Context, report report2.Report, images []*multipart.FileHeader, itemImages map[uuid.UUID][]*multipart.FileHeader) error
 	UpdateStatus(ctx context.Context, report report2.Report) error
 	GetByApplicationId(ctx context.Context, applicationId, userId uuid.UUID) (uuid.UUID, error)
 	GetByFilter(ctx context.Context, filter report2.Filter) ([]report2.Report, int, error)
@@ -41,27 +45,30 @@ type Usecase interface {
 type usecase struct {
 	db              report.Repo
 	s3              image.Repo
-	ostrovokClient  ostrovok.Client
+	promocodes      promocode.UseCase
 	userRepo        user.Repo
 	appsRepo        application.ApplicationRepo
 	achievementRepo achievement.Repo
+	offers          offer.UseCase
 }
 
 func New(
 	db report.Repo,
 	s3 image.Repo,
-	ostrovokClient ostrovok.Client,
+	promocodes promocode.UseCase,
 	userRepo user.Repo,
 	appsRepo application.ApplicationRepo,
 	achievementRepo achievement.Repo,
+	offers offer.UseCase,
 ) Usecase {
 	return &usecase{
 		db:              db,
 		s3:              s3,
-		ostrovokClient:  ostrovokClient,
+		promocodes:      promocodes,
 		userRepo:        userRepo,
 		appsRepo:        appsRepo,
 		achievementRepo: achievementRepo,
+		offers:          offers,
 	}
 }
 
@@ -81,8 +88,38 @@ func (u *usecase) GetByID(ctx context.Context, id uuid.UUID) (report2.Report, bo
 	return u.db.GetByID(ctx, id)
 }
 
-func (u *usecase) GetByUserID(ctx context.Context, userID uuid.UUID, limit, offset int64) ([]report2.Report, error) {
-	return u.db.GetByUserID(ctx, userID, limit, offset)
+func (u *usecase) GetByUserID(ctx context.Context, userID uuid.UUID, page pkg.PageRequest) (pkg.Page[report2.Report], error) {
+	if err := page.Validate(); err != nil {
+		return pkg.Page[report2.Report]{}, err
+	}
+
+	if cursor, ok := page.Cursor.Get(); ok {
+		after := pkg.NewEmpty[report2.Cursor]()
+		if cursor != "" {
+			var value report2.Cursor
+			if err := pkg.DecodeCursor(cursor, &value); err != nil {
+				return pkg.Page[report2.Report]{}, err
+			}
+			after = pkg.NewWithValue(value)
+		}
+
+		reports, err := u.db.GetByUserID(ctx, userID, page.Size+1, 0, after)
+		if err != nil {
+			return pkg.Page[report2.Report]{}, err
+		}
+		return pkg.CursorPage(reports, page.Size, report2.NewCursor)
+	}
+
+	reports, err := u.db.GetByUserID(ctx, userID, page.Size, page.Offset(), pkg.NewEmpty[report2.Cursor]())
+	if err != nil {
+		return pkg.Page[report2.Report]{}, err
+	}
+
+	count, err := u.db.CountByUserId(ctx, userID)
+	if err != nil {
+		return pkg.Page[report2.Report]{}, err
+	}
+	return pkg.NumberPage(reports, int(count), page.Size), nil
 }
 
 func (u *usecase) GetByIDAndUserID(ctx context.Context, id, userID uuid.UUID) (report2.Report, bool, error) {
@@ -102,7 +139,26 @@ func (u *usecase) GetByIDAndUserID(ctx context.Context, id, userID uuid.UUID) (r
 	return rep, true, nil
 }
 
-func (u *usecase) Update(ctx context.Context, report report2.Report, images []*multipart.FileHeader) error {
+func (u *usecase) Update(
+	ctx context.Context,
+	report report2.Report,
+	images []*multipart.FileHeader,
+	itemImages map[uuid.UUID][]*multipart.FileHeader,
+) error {
+	checklist, err := u.db.GetChecklist(ctx, report.ID)
+	if err != nil {
+		return err
+	}
+
+	// Проверяем до работы с хранилищем, чтобы неполный отчет не затер уже загруженные фото
+	photos := make(map[uuid.UUID]int, len(itemImages))
+	for itemID, files := range itemImages {
+		photos[itemID] = len(files)
+	}
+	if err := validateAnswers(checklist, report.Answers, photos); err != nil {
+		return err
+	}
+
 	if err := u.removeOldImages(ctx, report); err != nil {
 		return err
 	}
@@ -119,6 +175,21 @@ func (u *usecase) Update(ctx context.Context, report report2.Report, images []*m
 		})
 	}
 
+	for itemID, files := range itemImages {
+		for _, img := range files {
+			url, err := u.saveImage(ctx, img)
+			if err != nil {
+				return err
+			}
+
+			report.Images = append(report.Images, report2.Image{
+				ID:     uuid.New(),
+				Link:   string(url),
+				ItemID: &itemID,
+			})
+		}
+	}
+
 	return u.db.Upsert(ctx, report)
 }
 
@@ -127,30 +198,28 @@ func (u *usecase) UpdateStatus(ctx context.Context, report report2.Report) error
 		return errors.New("invalid status")
 	}
 
-	if report.Status == "accepted" {
-		promocode, err := u.ostrovokClient.GeneratePromocode(ctx)
-
-		if err != nil {
-			return err
-		}
-
-		report.Promocode = promocode
-
-		err = u.db.UpdatePromocode(ctx, report)
-
-		if err != nil {
-			return fmt.Errorf("failed to save promocode: %w", err)
-		}
+	user, err := u.userRepo.GetUserByReportId(ctx, report.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
 	}
 
-	err := u.db.UpdateStatus(ctx, report)
-	if err != nil {
+	if err := u.db.UpdateStatus(ctx, report); err != nil {
 		return err
 	}
 
-	user, err := u.userRepo.GetUserByReportId(ctx, report.ID)
-	if err != nil {
-		return fmt.Errorf("failed to get user: %w", err)
+	// Промокод выдаем только за уже принятый отчет. Если выдача упала, повторное принятие
+	// выдаст его снова. Второй промокод за тот же отчет не появится, пока первый не отозван,
+	// даже если он уже погашен: это гарантирует uq_promocode_report_active
+	if report.Status == "accepted" {
+		_, err := u.promocodes.Issue(ctx, user.ID, report.ID, user.Rating)
+		if err != nil && !errors.Is(err, promocodeRepo.ErrAlreadyIssued) {
+			return fmt.Errorf("failed to issue promocode: %w", err)
+		}
+	}
+
+	// Отчет проверен - оффер завершен. Ошибка не должна отменять саму проверку
+	if err := u.offers.CompleteByReport(ctx, report.ID); err != nil {
+		log.Println("failed to complete offer", err)
 	}
 
 	var newRating int
@@ -237,10 +306,5 @@ func (u *usecase) GetByFilter(ctx context.Context, filter report2.Filter) ([]rep
 	if err != nil {
 		return nil, 0, err
 	}
-	// Не считая остаток
-	withOutRemainder := count / int(filter.Limit)
-	if count%int(filter.Limit) == 0 {
-		return reports, withOutRemainder, nil
-	}
-	return reports, withOutRemainder + 1, nil
+	return reports, pkg.PagesCount(count, filter.Limit), nil
 }
@@ -2,24 +2,37 @@ package room
 
 import (
 	"context"
+	"errors"
 
 	"github.com/google/uuid"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/hotel"
 	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/room"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/catalog"
 	model "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/room"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/pkg"
 )
 
 type UseCase interface {
 	GetAll(ctx context.Context) ([]model.Room, error)
-	Create(ctx context.Context, name string) (uuid.UUID, error)
+	// GetByFilter с отелем возвращает его собственные номера и общие типы номеров
+	GetByFilter(ctx context.Context, filter model.Filter) ([]model.Room, error)
+	// GetPage без курсора возвращает все подходящие номера
+	GetPage(ctx context.Context, filter model.Filter, page pkg.PageRequest) (pkg.Page[model.Room], error)
+	GetByID(ctx context.Context, id uuid.UUID) (model.Room, error)
+	Create(ctx context.Context, create model.Create) (uuid.UUID, error)
+	Rename(ctx context.Context, id uuid.UUID, name string) (model.Room, error)
+	Delete(ctx context.Context, id uuid.UUID) error
 }
 
 type useCase struct {
-	repo room.Repo
+	repo      room.Repo
+	hotelRepo hotel.Repo
 }
 
-func NewUseCase(repo room.Repo) UseCase {
+func NewUseCase(repo room.Repo, hotelRepo hotel.Repo) UseCase {
 	return &useCase{
-		repo: repo,
+		repo:      repo,
+		hotelRepo: hotelRepo,
 	}
 }
 
@@ -27,6 +40,70 @@ func (u *useCase) GetAll(ctx context.Context) ([]model.Room, error) {
 	return u.repo.GetAll(ctx)
 }
 
-func (u *useCase) Create(ctx context.Context, name string) (uuid.UUID, error) {
-	return u.repo.Create(ctx, name)
+func (u *useCase) GetByFilter(ctx context.Context, filter model.Filter) ([]model.Room, error) {
+	if hotelID, ok := filter.HotelID.Get(); ok {
+		if err := u.checkHotel(ctx, hotelID); err != nil {
+			return nil, err
+		}
+	}
+	return u.repo.GetByFilter(ctx, filter)
+}
+
+func (u *useCase) GetPage(ctx context.Context, filter model.Filter, page pkg.PageRequest) (pkg.Page[model.Room], error) {
+	cursor, ok := page.Cursor.Get()
+	if !ok {
+		rooms, err := u.GetByFilter(ctx, filter)
+		return pkg.Page[model.Room]{Items: rooms}, err
+	}
+	if err := page.Validate(); err != nil {
+		return pkg.Page[model.Room]{}, err
+	}
+
+	after, err := catalog.ParseCursor(cursor)
+	if err != nil {
+		return pkg.Page[model.Room]{}, err
+	}
+
+	filter.Limit = page.Size + 1
+	filter.After = after
+	rooms, err := u.GetByFilter(ctx, filter)
+	if err != nil {
+		return pkg.Page[model.Room]{}, err
+	}
+	return pkg.CursorPage(rooms, page.Size, func(room model.Room) catalog.Cursor {
+		return catalog.Cursor{Name: room.Name, ID: room.ID}
+	})
+}
+
+func (u *useCase) GetByID(ctx context.Context, id uuid.UUID) (model.Room, error) {
+	return u.repo.GetByID(ctx, id)
+}
+
+func (u *useCase) Create(ctx context.Context, create model.Create) (uuid.UUID, error) {
+	if hotelID, ok := create.HotelID.Get(); ok {
+		if err := u.checkHotel(ctx, hotelID); err != nil {
+			return uuid.Nil, err
+		}
+	}
+	return u.repo.Create(ctx, create)
+}
+
+func (u *useCase) Rename(ctx context.Context, id uuid.UUID, name string) (model.Room, error) {
+	if err := u.repo.Rename(ctx, id, name); err != nil {
+		return model.Room{}, err
+	}
+	return u.repo.GetByID(ctx, id)
+}
+
+func (u *useCase) Delete(ctx context.Context, id uuid.UUID) error {
+	return u.repo.Delete(ctx, id)
+}
+
+// checkHotel - удаленный отель считается несуществующим, внешний ключ этого не проверит
+func (u *useCase) checkHotel(ctx context.Context, hotelID uuid.UUID) error {
+	_, err := u.hotelRepo.GetByID(ctx, hotelID)
+	if errors.Is(err, hotel.ErrNotFound) {
+		return room.ErrHotelNotFound
+	}
+	return err
 }
@@ -0,0 +1,118 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	model "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/loginattempt"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/pkg"
+)
+
+var (
+	// 5 ошибок подряд на логин - блокировка от минуты, дальше удваивается до часа
+	loginPolicy = model.Policy{
+		MaxFailures: 5,
+		Window:      15 * time.Minute,
+		BaseLockout: time.Minute,
+		MaxLockout:  time.Hour,
+	}
+
+	// С одного IP могут входить несколько человек, поэтому порог выше
+	ipPolicy = model.Policy{
+		MaxFailures: 20,
+		Window:      15 * time.Minute,
+		BaseLockout: time.Minute,
+		MaxLockout:  time.Hour,
+	}
+
+	// Письма со ссылкой сброса: не больше 3 в час на логин и 20 в час с одного IP.
+	// Окно считается от конца блокировки, поэтому повторные превышения растягивают ее до суток
+	resetLoginPolicy = model.Policy{
+		MaxFailures: 3,
+		Window:      time.Hour,
+		BaseLockout: time.Hour,
+		MaxLockout:  24 * time.Hour,
+	}
+
+	resetIPPolicy = model.Policy{
+		MaxFailures: 20,
+		Window:      time.Hour,
+		BaseLockout: 15 * time.Minute,
+		MaxLockout:  24 * time.Hour,
+	}
+
+	policies = map[model.Kind]model.Policy{
+		model.KindLogin:          loginPolicy,
+		model.KindIP:             ipPolicy,
+		model.KindPasswordChange: loginPolicy,
+		model.KindResetLogin:     resetLoginPolicy,
+		model.KindResetIP:        resetIPPolicy,
+	}
+
+	dummyPasswordHash = pkg.HashPassword("dummy-password-for-timing")
+)
+
+func attemptKeys(login, ip string) []model.Key {
+	return []model.Key{
+		{Kind: model.KindLogin, Value: login},
+		{Kind: model.KindIP, Value: ip},
+	}
+}
+
+func resetKeys(login, ip string) []model.Key {
+	return []model.Key{
+		{Kind: model.KindResetLogin, Value: login},
+		{Kind: model.KindResetIP, Value: ip},
+	}
+}
+
+func (u *useCase) checkLocked(ctx context.Context, keys []model.Key) error {
+	lockedUntil, err := u.attemptRepo.GetLockedUntil(ctx, keys...)
+	if err != nil {
+		return fmt.Errorf("failed to check login lock: %w", err)
+	}
+
+	if lockedUntil != nil {
+		return &TooManyAttemptsError{RetryAfter: time.Until(*lockedUntil)}
+	}
+
+	return nil
+}
+
+// registerFailure учитывает неудачную попытку по всем ключам.
+// Возвращает ErrInvalidCredentials или TooManyAttemptsError, если попытка привела к блокировке.
+func (u *useCase) registerFailure(ctx context.Context, keys []model.Key, ip string) error {
+	if err := u.registerAttempt(ctx, keys, ip); err != nil {
+		return err
+	}
+
+	return ErrInvalidCredentials
+}
+
+// registerAttempt учитывает попытку по всем ключам и возвращает TooManyAttemptsError, если она привела к блокировке
+func (u *useCase) registerAttempt(ctx context.Context, keys []model.Key, ip string) error {
+	var latest *time.Time
+
+	for _, key := range keys {
+		lockedUntil, err := u.attemptRepo.RegisterFailure(ctx, key, policies[key.Kind], ip)
+		if err != nil {
+			return fmt.Errorf("failed to register login failure: %w", err)
+		}
+
+		if lockedUntil != nil {
+			log.Printf("login lockout: %s=%q ip=%q until %s", key.Kind, key.Value, ip, lockedUntil.Format(time.RFC3339))
+
+			if latest == nil || lockedUntil.After(*latest) {
+				latest = lockedUntil
+			}
+		}
+	}
+
+	if latest != nil {
+		return &TooManyAttemptsError{RetryAfter: time.Until(*latest)}
+	}
+
+	return nil
+}
@@ -1,6 +1,38 @@
 package user
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"time"
+)
 
-var ErrIncorrectPassword = errors.New("incorrect password")
+// ErrInvalidCredentials одинаков для неизвестного логина и неверного пароля, чтобы нельзя было перебирать пользователей
+var ErrInvalidCredentials = errors.New("invalid login or password")
 var ErrInvalidToken = errors.New("invalid token")
+var ErrRefreshTokenReused = errors.New("refresh token reused, session revoked")
+var ErrSessionRevoked = errors.New("session revoked or expired")
+var ErrSessionNotFound = errors.New("session not found")
+var ErrUserAlreadyRegistered = errors.New("пользователь уже зарегистрирован")
+var ErrEmailMismatch = errors.New("email does not match ostrovok account")
+var ErrVerificationCodeInvalid = errors.New("verification code is invalid or expired")
+var ErrAlreadyVerified = errors.New("account is already verified")
+var ErrResetTokenInvalid = errors.New("password reset token is invalid or expired")
+var ErrTooManyAttempts = errors.New("too many log-in attempts")
+var ErrTooManyCodeRequests = errors.New("too many verification code requests")
+
+// TooManyAttemptsError - превышен лимит попыток. Reason - что именно ограничено, по умолчанию ErrTooManyAttempts
+type TooManyAttemptsError struct {
+	RetryAfter time.Duration
+	Reason     error
+}
+
+func (e *TooManyAttemptsError) Error() string {
+	return fmt.Sprintf("%s, retry after %s", e.Unwrap().Error(), e.RetryAfter.Round(time.Second))
+}
+
+func (e *TooManyAttemptsError) Unwrap() error {
+	if e.Reason != nil {
+		return e.Reason
+	}
+	return ErrTooManyAttempts
+}
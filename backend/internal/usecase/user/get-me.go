@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
 
 	"github.com/google/uuid"
 	repo "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/user"
@@ -29,11 +30,33 @@ func (u *useCase) GetMe(ctx context.Context, userId uuid.UUID) (*model.User, err
 
 	user.Achievements = achievements
 
-	ostrovokUser, err := u.ostrovokClient.GetUserByLogin(ctx, user.OstrovokLogin)
+	user.Promocodes, err = u.promocodeRepo.GetByUserID(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	user.Roles, err = u.rbacRepo.GetUserRoles(ctx, userId)
 	if err != nil {
 		return nil, err
 	}
 
+	permissions, err := u.rbacRepo.GetUserPermissions(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	user.Permissions = make([]string, 0, len(permissions))
+	for _, p := range permissions {
+		user.Permissions = append(user.Permissions, string(p))
+	}
+
+	// Профиль без почты лучше, чем ошибка, пока Островок недоступен
+	ostrovokUser, err := u.ostrovokClient.GetUserByLogin(ctx, user.OstrovokLogin)
+	if err != nil {
+		log.Println("failed to get ostrovok profile: ", err)
+		return user, nil
+	}
+
 	user.Email = ostrovokUser.Email
-	return user, err
+	return user, nil
 }
@@ -2,40 +2,102 @@ package user
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"time"
+	"log"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	_ "github.com/lib/pq"
 	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/docs"
+	userRepo "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/user"
+	sessionModel "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/session"
 	model "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/user"
 	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/pkg"
 )
 
-func (u *useCase) Login(ctx context.Context, req *docs.LogInRequest) (*docs.AuthResponse, error) {
+func (u *useCase) Login(ctx context.Context, req *docs.LogInRequest, client sessionModel.ClientInfo) (*docs.AuthResponse, error) {
+	keys := attemptKeys(req.OstrovokLogin, client.IP)
+
+	if err := u.checkLocked(ctx, keys); err != nil {
+		return nil, err
+	}
+
 	user, storedPasswordHash, err := u.repo.FindUserByLogin(ctx, req.OstrovokLogin)
-	if err != nil {
+	if err != nil && !errors.Is(err, userRepo.ErrUserNotFound) {
 		return nil, err
 	}
 
-	if pkg.HashPassword(req.Password) != storedPasswordHash {
-		return nil, ErrIncorrectPassword
+	if err != nil {
+		// Считаем хеш и для несуществующего логина, чтобы ответ не отличался по времени
+		pkg.VerifyPassword(req.Password, dummyPasswordHash)
+		return nil, u.registerFailure(ctx, keys, client.IP)
+	}
+
+	ok, needsRehash := pkg.VerifyPassword(req.Password, storedPasswordHash)
+	if !ok {
+		return nil, u.registerFailure(ctx, keys, client.IP)
+	}
+
+	if err := u.attemptRepo.Reset(ctx, keys[0]); err != nil {
+		log.Println("failed to reset login attempts", err)
 	}
 
-	return generateTokens(user, u.jwtSecret)
+	// Старые sha256-хеши и хеши с устаревшими параметрами пересчитываем прозрачно для пользователя
+	if needsRehash {
+		if err := u.repo.UpdatePasswordHash(ctx, user.ID, pkg.HashPassword(req.Password)); err != nil {
+			log.Println("failed to rehash password", err)
+		}
+	}
+
+	return u.startSession(ctx, user, client)
 }
 
-func (u *useCase) generateToken(user *model.User, duration time.Duration, audience string) (string, error) {
-	return generateToken(user, duration, audience, u.jwtSecret)
+// startSession открывает новую сессию пользователя с новой семьей refresh-токенов и выдает первую пару токенов
+func (u *useCase) startSession(ctx context.Context, user *model.User, client sessionModel.ClientInfo) (*docs.AuthResponse, error) {
+	session := sessionModel.NewSession(user.ID, client, refreshTokenTTL)
+	refresh := sessionModel.NewRefreshSession(user.ID, session.ID, refreshTokenTTL)
+
+	if err := u.sessionRepo.CreateSession(ctx, session, refresh); err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return generateTokens(user, session.ID, refresh.ID, u.jwtKeys)
 }
 
-func (u *useCase) ValidateToken(tokenString string) (*model.JWTClaims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &model.JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method")
-		}
-		return u.jwtSecret, nil
-	})
+// ValidateToken проверяет подпись access-токена и то, что его сессия еще не отозвана
+func (u *useCase) ValidateToken(ctx context.Context, tokenString string) (*model.JWTClaims, error) {
+	claims, err := u.parseToken(tokenString, accessAudience)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidToken, err.Error())
+	}
+
+	sessionID, err := uuid.Parse(claims.SessionID)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	active, err := u.sessionRepo.CheckActive(ctx, sessionID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !active {
+		return nil, ErrSessionRevoked
+	}
+
+	return claims, nil
+}
+
+func (u *useCase) parseToken(tokenString, audience string) (*model.JWTClaims, error) {
+	options := append(u.jwtKeys.ParserOptions(), jwt.WithAudience(audience))
+
+	token, err := jwt.ParseWithClaims(tokenString, &model.JWTClaims{}, u.jwtKeys.Keyfunc, options...)
 
 	if err != nil {
 		return nil, err
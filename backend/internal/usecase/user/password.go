@@ -0,0 +1,173 @@
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/docs"
+	resetRepo "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/passwordreset"
+	userRepo "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/user"
+	loginAttemptModel "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/loginattempt"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/notification"
+	sessionModel "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/session"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/pkg"
+)
+
+const (
+	resetTokenTTL    = time.Hour
+	resetSendTimeout = time.Minute
+)
+
+// ChangePassword меняет пароль и завершает все остальные сессии пользователя.
+// Неверный старый пароль считается как неудачный вход, чтобы с украденной сессией его нельзя было подобрать
+func (u *useCase) ChangePassword(
+	ctx context.Context,
+	userId, sessionId uuid.UUID,
+	req *docs.ChangePasswordRequest,
+	client sessionModel.ClientInfo,
+) error {
+	keys := []loginAttemptModel.Key{{Kind: loginAttemptModel.KindPasswordChange, Value: userId.String()}}
+
+	if err := u.checkLocked(ctx, keys); err != nil {
+		return err
+	}
+
+	storedPasswordHash, err := u.repo.GetPasswordHash(ctx, userId)
+	if err != nil {
+		return err
+	}
+
+	if ok, _ := pkg.VerifyPassword(req.OldPassword, storedPasswordHash); !ok {
+		return u.registerFailure(ctx, keys, client.IP)
+	}
+
+	if err := u.attemptRepo.Reset(ctx, keys[0]); err != nil {
+		log.Println("failed to reset password change attempts", err)
+	}
+
+	if err := u.repo.UpdatePasswordHash(ctx, userId, pkg.HashPassword(req.NewPassword)); err != nil {
+		return err
+	}
+
+	if _, err := u.sessionRepo.RevokeOtherSessions(ctx, userId, sessionId); err != nil {
+		return fmt.Errorf("failed to revoke other sessions: %w", err)
+	}
+
+	return nil
+}
+
+// RequestPasswordReset отправляет ссылку сброса на почту из Островка.
+// Для неизвестного логина молча ничего не делает, чтобы не раскрывать наличие пользователя.
+// Запросы ограничены по логину и IP, неизвестные логины тоже считаются
+func (u *useCase) RequestPasswordReset(ctx context.Context, req *docs.PasswordResetRequest, client sessionModel.ClientInfo) error {
+	keys := resetKeys(req.OstrovokLogin, client.IP)
+
+	if err := u.checkLocked(ctx, keys); err != nil {
+		return err
+	}
+
+	// Запрос, на котором сработал лимит, еще выполняем, блокируются следующие
+	var tooMany *TooManyAttemptsError
+	if err := u.registerAttempt(ctx, keys, client.IP); err != nil && !errors.As(err, &tooMany) {
+		return err
+	}
+
+	// Пользователя ищем и письмо отправляем в фоне с ограниченным временем: иначе по времени ответа
+	// было бы видно, есть ли такой логин
+	go func(login string) {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), resetSendTimeout)
+		defer cancel()
+
+		if err := u.sendPasswordReset(ctx, login); err != nil {
+			log.Println("failed to send password reset", err)
+		}
+	}(req.OstrovokLogin)
+
+	return nil
+}
+
+func (u *useCase) sendPasswordReset(ctx context.Context, login string) error {
+	user, _, err := u.repo.FindUserByLogin(ctx, login)
+	if errors.Is(err, userRepo.ErrUserNotFound) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	ostrovokUser, err := u.ostrovokClient.GetUserByLogin(ctx, user.OstrovokLogin)
+	if err != nil {
+		return fmt.Errorf("failed to get user email: %w", err)
+	}
+
+	token, err := generateResetToken()
+	if err != nil {
+		return err
+	}
+
+	if err := u.resetRepo.Create(ctx, user.ID, hashResetToken(token), time.Now().Add(resetTokenTTL)); err != nil {
+		return err
+	}
+
+	msg := notification.Message{
+		To:      ostrovokUser.Email,
+		Subject: "Сброс пароля",
+		Body: fmt.Sprintf(
+			"Чтобы задать новый пароль, перейдите по ссылке:\n%s\n\nСсылка действует %d минут. Если вы не запрашивали сброс, просто проигнорируйте это письмо.",
+			fmt.Sprintf(u.resetURL, token), int(resetTokenTTL.Minutes()),
+		),
+	}
+
+	if err := u.notifier.Send(ctx, msg); err != nil {
+		return fmt.Errorf("failed to send reset token: %w", err)
+	}
+
+	return nil
+}
+
+// ResetPassword задает новый пароль по одноразовому токену и завершает все сессии пользователя
+func (u *useCase) ResetPassword(ctx context.Context, req *docs.ResetPasswordRequest) error {
+	userId, err := u.resetRepo.Consume(ctx, hashResetToken(req.Token))
+
+	switch {
+	case errors.Is(err, resetRepo.ErrTokenNotFound) ||
+		errors.Is(err, resetRepo.ErrTokenUsed) ||
+		errors.Is(err, resetRepo.ErrTokenExpired):
+		return fmt.Errorf("%w: %s", ErrResetTokenInvalid, err.Error())
+	case err != nil:
+		return err
+	}
+
+	if err := u.repo.UpdatePasswordHash(ctx, userId, pkg.HashPassword(req.NewPassword)); err != nil {
+		return err
+	}
+
+	if _, err := u.sessionRepo.RevokeAllSessions(ctx, userId); err != nil {
+		log.Println("failed to revoke sessions after password reset", err)
+	}
+
+	return nil
+}
+
+func generateResetToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate reset token: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func hashResetToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,47 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/docs"
+	sessionRepo "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/session"
+)
+
+func (u *useCase) Refresh(ctx context.Context, req *docs.RefreshRequest) (*docs.AuthResponse, error) {
+	claims, err := u.parseToken(req.RefreshToken, refreshAudience)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidToken, err.Error())
+	}
+
+	sessionID, err := uuid.Parse(claims.ID)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	next, err := u.sessionRepo.Rotate(ctx, sessionID, uuid.New(), time.Now().Add(refreshTokenTTL))
+
+	switch {
+	case errors.Is(err, sessionRepo.ErrSessionReused):
+		return nil, ErrRefreshTokenReused
+	case errors.Is(err, sessionRepo.ErrSessionNotFound) ||
+		errors.Is(err, sessionRepo.ErrSessionRevoked) ||
+		errors.Is(err, sessionRepo.ErrSessionExpired):
+		return nil, fmt.Errorf("%w: %s", ErrInvalidToken, err.Error())
+	case err != nil:
+		return nil, fmt.Errorf("failed to rotate refresh session: %w", err)
+	}
+
+	// Перечитываем пользователя, чтобы в новый access попали актуальные права
+	user, err := u.repo.GetUserById(ctx, next.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user from repo: %w", err)
+	}
+
+	user.Email = claims.Email
+
+	return generateTokens(user, next.FamilyID, next.ID, u.jwtKeys)
+}
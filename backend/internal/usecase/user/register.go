@@ -3,9 +3,12 @@ package user
 import (
 	"context"
 	"errors"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/docs"
+	userRepo "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/user"
+	sessionModel "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/session"
 	model "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/user"
 	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/pkg"
 )
@@ -15,34 +18,52 @@ type RegisterRequest struct {
 	Password      string `json:"password"`
 }
 
-func (u *useCase) Register(ctx context.Context, req *docs.SignUpRequest) (*docs.AuthResponse, error) {
+// Register заводит аккаунт в статусе pending и отправляет код подтверждения на почту из Островка.
+// Ролей у такого аккаунта нет, пока код не подтвержден через ConfirmSignUp.
+func (u *useCase) Register(ctx context.Context, req *docs.SignUpRequest, client sessionModel.ClientInfo) (*docs.AuthResponse, error) {
 	// 1. Получаем пользователя из системы Островок
 	ostrovokUser, err := u.ostrovokClient.GetUserByLogin(ctx, req.OstrovokLogin)
 	if err != nil {
 		return nil, err
 	}
 
-	exists, err := u.repo.UserExists(ctx, req.OstrovokLogin)
-	if err != nil {
-		return nil, err
-	}
-	if exists {
-		return nil, errors.New("пользователь уже зарегистрирован")
+	if !strings.EqualFold(strings.TrimSpace(req.Email), ostrovokUser.Email) {
+		return nil, ErrEmailMismatch
 	}
 
 	passwordHash := pkg.HashPassword(req.Password)
 
-	user := &model.User{
-		ID:            uuid.New(),
-		OstrovokLogin: ostrovokUser.Login,
-		Email:         ostrovokUser.Email,
-		IsAdmin:       false,
+	user, _, err := u.repo.FindUserByLogin(ctx, req.OstrovokLogin)
+
+	// Для новой регистрации пароль уже сохранен при создании пользователя
+	restartPassword := pkg.NewEmpty[string]()
+
+	switch {
+	case errors.Is(err, userRepo.ErrUserNotFound):
+		user = &model.User{
+			ID:            uuid.New(),
+			OstrovokLogin: ostrovokUser.Login,
+			Status:        model.StatusPending,
+		}
+
+		if err := u.repo.CreateUser(ctx, user, passwordHash); err != nil {
+			return nil, err
+		}
+	case err != nil:
+		return nil, err
+	case user.Status != model.StatusPending:
+		return nil, ErrUserAlreadyRegistered
+	default:
+		// Владение не подтверждено никем, поэтому незавершенную регистрацию можно начать заново.
+		// Пароль меняется и прежние сессии закрываются вместе с новым кодом и под его лимитом
+		restartPassword.Set(passwordHash)
 	}
 
-	err = u.repo.CreateUser(ctx, user, passwordHash)
-	if err != nil {
+	user.Email = ostrovokUser.Email
+
+	if err := u.sendVerificationCode(ctx, user.ID, ostrovokUser.Email, restartPassword); err != nil {
 		return nil, err
 	}
 
-	return generateTokens(user, u.jwtSecret)
+	return u.startSession(ctx, user, client)
 }
@@ -0,0 +1,30 @@
+package user
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	sessionRepo "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/session"
+	sessionModel "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/session"
+)
+
+func (u *useCase) GetSessions(ctx context.Context, userId uuid.UUID) ([]sessionModel.Session, error) {
+	return u.sessionRepo.GetActiveSessions(ctx, userId)
+}
+
+// RevokeSession завершает сессию пользователя: ее access-токены перестают проходить проверку сразу,
+// refresh-токены больше не обмениваются
+func (u *useCase) RevokeSession(ctx context.Context, userId, sessionId uuid.UUID) error {
+	err := u.sessionRepo.RevokeSession(ctx, userId, sessionId)
+
+	if errors.Is(err, sessionRepo.ErrSessionNotFound) {
+		return ErrSessionNotFound
+	}
+
+	return err
+}
+
+func (u *useCase) RevokeAllSessions(ctx context.Context, userId uuid.UUID) (int64, error) {
+	return u.sessionRepo.RevokeAllSessions(ctx, userId)
+}
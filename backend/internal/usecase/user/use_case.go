@@ -5,34 +5,79 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/docs"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/notifier"
 	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/ostrovok"
 	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/achievement"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/loginattempt"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/passwordreset"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/promocode"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/rbac"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/session"
 	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/user"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/verification"
+	sessionModel "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/session"
 	model "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/user"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/pkg"
 )
 
 type UseCase interface {
-	Register(ctx context.Context, req *docs.SignUpRequest) (*docs.AuthResponse, error)
-	ValidateToken(tokenString string) (*model.JWTClaims, error)
-	Login(ctx context.Context, req *docs.LogInRequest) (*docs.AuthResponse, error)
+	Register(ctx context.Context, req *docs.SignUpRequest, client sessionModel.ClientInfo) (*docs.AuthResponse, error)
+	ValidateToken(ctx context.Context, tokenString string) (*model.JWTClaims, error)
+	Login(ctx context.Context, req *docs.LogInRequest, client sessionModel.ClientInfo) (*docs.AuthResponse, error)
+	Refresh(ctx context.Context, req *docs.RefreshRequest) (*docs.AuthResponse, error)
 	GetMe(ctx context.Context, userId uuid.UUID) (*model.User, error)
+	GetSessions(ctx context.Context, userId uuid.UUID) ([]sessionModel.Session, error)
+	RevokeSession(ctx context.Context, userId, sessionId uuid.UUID) error
+	RevokeAllSessions(ctx context.Context, userId uuid.UUID) (int64, error)
+	ChangePassword(ctx context.Context, userId, sessionId uuid.UUID, req *docs.ChangePasswordRequest, client sessionModel.ClientInfo) error
+	RequestPasswordReset(ctx context.Context, req *docs.PasswordResetRequest, client sessionModel.ClientInfo) error
+	ResetPassword(ctx context.Context, req *docs.ResetPasswordRequest) error
+	ConfirmSignUp(ctx context.Context, userId uuid.UUID, req *docs.ConfirmSignUpRequest) error
+	ResendSignUpCode(ctx context.Context, userId uuid.UUID) error
 }
 
 type useCase struct {
-	repo            user.Repo
-	ostrovokClient  ostrovok.Client
-	jwtSecret       []byte
-	achievementRepo achievement.Repo
+	repo             user.Repo
+	ostrovokClient   ostrovok.Client
+	jwtKeys          *pkg.JWTKeySet
+	achievementRepo  achievement.Repo
+	sessionRepo      session.Repo
+	rbacRepo         rbac.Repo
+	attemptRepo      loginattempt.Repo
+	resetRepo        passwordreset.Repo
+	verificationRepo verification.Repo
+	promocodeRepo    promocode.Repo
+	notifier         notifier.Notifier
+	resetURL         string
 }
 
-func NewUseCase(repo user.Repo, ostrovokClient ostrovok.Client, achievementRepo achievement.Repo) UseCase {
-
-	jwtSecret := getEnvWithDefault("JWT_SECRET", "your-super-secret-jwt-key-change-in-production")
+func NewUseCase(
+	repo user.Repo,
+	ostrovokClient ostrovok.Client,
+	achievementRepo achievement.Repo,
+	sessionRepo session.Repo,
+	rbacRepo rbac.Repo,
+	attemptRepo loginattempt.Repo,
+	resetRepo passwordreset.Repo,
+	verificationRepo verification.Repo,
+	promocodeRepo promocode.Repo,
+	notifier notifier.Notifier,
+	resetURL string,
+	jwtKeys *pkg.JWTKeySet,
+) UseCase {
 
 	return &useCase{
-		repo:            repo,
-		ostrovokClient:  ostrovokClient,
-		jwtSecret:       []byte(jwtSecret),
-		achievementRepo: achievementRepo,
+		repo:             repo,
+		ostrovokClient:   ostrovokClient,
+		jwtKeys:          jwtKeys,
+		achievementRepo:  achievementRepo,
+		sessionRepo:      sessionRepo,
+		rbacRepo:         rbacRepo,
+		attemptRepo:      attemptRepo,
+		resetRepo:        resetRepo,
+		verificationRepo: verificationRepo,
+		promocodeRepo:    promocodeRepo,
+		notifier:         notifier,
+		resetURL:         resetURL,
 	}
 }
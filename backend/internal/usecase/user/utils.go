@@ -1,21 +1,30 @@
 package user
 
 import (
-	"os"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/docs"
 	model "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/user"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/pkg"
 )
 
-func generateTokens(user *model.User, jwtSecret []byte) (*docs.AuthResponse, error) {
-	accessToken, err := generateToken(user, 24*time.Hour, "access", jwtSecret)
+const (
+	accessTokenTTL  = 24 * time.Hour
+	refreshTokenTTL = 30 * 24 * time.Hour
+
+	accessAudience  = "access"
+	refreshAudience = "refresh"
+)
+
+func generateTokens(user *model.User, sessionID, refreshID uuid.UUID, jwtKeys *pkg.JWTKeySet) (*docs.AuthResponse, error) {
+	accessToken, err := generateToken(user, sessionID, accessTokenTTL, accessAudience, uuid.NewString(), jwtKeys)
 	if err != nil {
 		return nil, err
 	}
 
-	refreshToken, err := generateToken(user, 30*24*time.Hour, "refresh", jwtSecret)
+	refreshToken, err := generateToken(user, sessionID, refreshTokenTTL, refreshAudience, refreshID.String(), jwtKeys)
 	if err != nil {
 		return nil, err
 	}
@@ -23,34 +32,28 @@ func generateTokens(user *model.User, jwtSecret []byte) (*docs.AuthResponse, err
 	return &docs.AuthResponse{
 		AccessToken:  accessToken,
 		RefreshToken: refreshToken,
-		AccessTTL:    86400,   // 24 часа
-		RefreshTTL:   2592000, // 30 дней
+		AccessTTL:    int(accessTokenTTL.Seconds()),  // 24 часа
+		RefreshTTL:   int(refreshTokenTTL.Seconds()), // 30 дней
 	}, nil
 }
 
-func generateToken(user *model.User, duration time.Duration, audience string, jwtSecret []byte) (string, error) {
+func generateToken(user *model.User, sessionID uuid.UUID, duration time.Duration, audience, tokenID string, jwtKeys *pkg.JWTKeySet) (string, error) {
 	userIDStr := user.ID.String()
 	claims := model.JWTClaims{
 		UserID:        userIDStr,
 		OstrovokLogin: user.OstrovokLogin,
 		Email:         user.Email,
 		IsAdmin:       user.IsAdmin,
+		SessionID:     sessionID.String(),
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(duration)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			Issuer:    "ostrovok-secret-guest",
 			Subject:   userIDStr,
 			Audience:  []string{audience},
+			ID:        tokenID,
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(jwtSecret)
-}
-
-func getEnvWithDefault(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
-	}
-	return defaultValue
+	return jwtKeys.Sign(claims)
 }
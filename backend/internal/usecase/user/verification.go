@@ -0,0 +1,130 @@
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/docs"
+	verificationRepo "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/verification"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/notification"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/rbac"
+	model "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/user"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/pkg"
+)
+
+const (
+	verificationCodeTTL         = 15 * time.Minute
+	verificationCodeMaxAttempts = 10
+	verificationCodeDigits      = 6
+)
+
+// Попытки ввода считаются за сутки по всем кодам, новый код не дает перебирать дальше
+var verificationLimits = verificationRepo.Limits{
+	Cooldown:    time.Minute,
+	MaxSends:    5,
+	MaxAttempts: verificationCodeMaxAttempts,
+	Window:      24 * time.Hour,
+}
+
+// ConfirmSignUp подтверждает владение учеткой Островка и активирует аккаунт с ролью reviewer
+func (u *useCase) ConfirmSignUp(ctx context.Context, userId uuid.UUID, req *docs.ConfirmSignUpRequest) error {
+	err := u.verificationRepo.Confirm(
+		ctx, userId, hashVerificationCode(userId, req.Code), verificationCodeMaxAttempts, rbac.RoleReviewer,
+	)
+
+	switch {
+	case errors.Is(err, verificationRepo.ErrCodeNotFound):
+		return ErrAlreadyVerified
+	case errors.Is(err, verificationRepo.ErrCodeMismatch) ||
+		errors.Is(err, verificationRepo.ErrCodeExpired) ||
+		errors.Is(err, verificationRepo.ErrCodeAttemptsExceed):
+		return fmt.Errorf("%w: %s", ErrVerificationCodeInvalid, err.Error())
+	}
+
+	return err
+}
+
+// ResendSignUpCode отправляет новый код, прежний перестает действовать
+func (u *useCase) ResendSignUpCode(ctx context.Context, userId uuid.UUID) error {
+	user, err := u.repo.GetUserById(ctx, userId)
+	if err != nil {
+		return err
+	}
+
+	if user.Status != model.StatusPending {
+		return ErrAlreadyVerified
+	}
+
+	ostrovokUser, err := u.ostrovokClient.GetUserByLogin(ctx, user.OstrovokLogin)
+	if err != nil {
+		return fmt.Errorf("failed to get user email: %w", err)
+	}
+
+	return u.sendVerificationCode(ctx, userId, ostrovokUser.Email, pkg.NewEmpty[string]())
+}
+
+// sendVerificationCode сохраняет и отправляет новый код. Если задан passwordHash, регистрация
+// начинается заново: пароль меняется и сессии завершаются, только если лимит отправки не исчерпан
+func (u *useCase) sendVerificationCode(ctx context.Context, userId uuid.UUID, email string, passwordHash pkg.Opt[string]) error {
+	code, err := generateVerificationCode()
+	if err != nil {
+		return err
+	}
+
+	codeHash, expiresAt := hashVerificationCode(userId, code), time.Now().Add(verificationCodeTTL)
+	if hash, ok := passwordHash.Get(); ok {
+		err = u.verificationRepo.Restart(ctx, userId, codeHash, expiresAt, verificationLimits, hash)
+	} else {
+		err = u.verificationRepo.Save(ctx, userId, codeHash, expiresAt, verificationLimits)
+	}
+
+	var limit *verificationRepo.ResendLimitError
+	if errors.As(err, &limit) {
+		return &TooManyAttemptsError{RetryAfter: time.Until(limit.RetryAt), Reason: ErrTooManyCodeRequests}
+	}
+	if err != nil {
+		return err
+	}
+
+	msg := notification.Message{
+		To:      email,
+		Subject: "Код подтверждения регистрации",
+		Body: fmt.Sprintf(
+			"Ваш код подтверждения: %s\n\nКод действует %d минут. Если вы не регистрировались в программе «Тайный гость», просто проигнорируйте это письмо.",
+			code, int(verificationCodeTTL.Minutes()),
+		),
+	}
+
+	if err := u.notifier.Send(ctx, msg); err != nil {
+		return fmt.Errorf("failed to send verification code: %w", err)
+	}
+
+	return nil
+}
+
+func generateVerificationCode() (string, error) {
+	max := big.NewInt(1)
+	for i := 0; i < verificationCodeDigits; i++ {
+		max.Mul(max, big.NewInt(10))
+	}
+
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate verification code: %w", err)
+	}
+
+	return fmt.Sprintf("%0*d", verificationCodeDigits, n), nil
+}
+
+// Код короткий, поэтому солим его id пользователя
+func hashVerificationCode(userId uuid.UUID, code string) string {
+	sum := sha256.Sum256([]byte(userId.String() + ":" + code))
+	return hex.EncodeToString(sum[:])
+}
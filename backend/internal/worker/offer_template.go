@@ -0,0 +1,66 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/go-co-op/gocron"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/usecase/offer"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/usecase/offertemplate"
+)
+
+// OfferTemplateWorker заранее создает офферы по шаблонам и публикует их, когда наступает дата публикации
+type OfferTemplateWorker struct {
+	templateUseCase offertemplate.UseCase
+	offerUseCase    offer.UseCase
+	scheduler       *gocron.Scheduler
+}
+
+func NewOfferTemplateWorker(templateUseCase offertemplate.UseCase, offerUseCase offer.UseCase) *OfferTemplateWorker {
+	return &OfferTemplateWorker{
+		templateUseCase: templateUseCase,
+		offerUseCase:    offerUseCase,
+		scheduler:       gocron.NewScheduler(time.UTC),
+	}
+}
+
+func (w *OfferTemplateWorker) Start() {
+	_, err := w.scheduler.Every(1).Minutes().Do(func() {
+		w.process()
+	})
+	if err != nil {
+		log.Printf("❌ Error schedule offer templates: %v", err)
+		return
+	}
+
+	w.scheduler.StartAsync()
+
+	log.Println("Offer template worker started")
+}
+
+func (w *OfferTemplateWorker) Stop() {
+	w.scheduler.Stop()
+	log.Println("Offer template worker stopped")
+}
+
+func (w *OfferTemplateWorker) process() {
+	ctx := context.Background()
+
+	result, err := w.templateUseCase.Materialize(ctx)
+	if err != nil {
+		log.Printf("❌ Error materialize offer templates: %v", err)
+	} else if result.Created > 0 || result.Skipped > 0 {
+		log.Printf("Offer templates: %d offers created, %d skipped", result.Created, result.Skipped)
+	}
+
+	published, err := w.offerUseCase.PublishScheduled(ctx)
+	if err != nil {
+		log.Printf("❌ Error publish scheduled offers: %v", err)
+		return
+	}
+
+	if published > 0 {
+		log.Printf("Offer templates: %d offers published", published)
+	}
+}
@@ -2,45 +2,29 @@ package worker
 
 import (
 	"context"
-	"errors"
 	"log"
 	"time"
 
 	"github.com/go-co-op/gocron"
-	"github.com/google/uuid"
-	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/application"
-	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/offer"
-	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/report"
-	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/user"
-	appModel "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/application"
-	reportModel "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/report"
-	userModel "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/user"
-	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/pkg"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/usecase/draw"
 )
 
+// drawBatchSize - сколько офферов воркер разыгрывает за один запуск, остальные дождутся следующего
+const drawBatchSize = 10
+
+// SecretGuestWorker разыгрывает офферы, прием заявок в которых закончился.
+// Реплики берут офферы через SKIP LOCKED, поэтому один оффер не разыгрывается дважды
 type SecretGuestWorker struct {
-	offerRepo       offer.Repo
-	applicationRepo application.ApplicationRepo
-	reportRepo      report.Repo
-	userRepo        user.Repo
-	scheduler       *gocron.Scheduler
+	drawUseCase draw.UseCase
+	scheduler   *gocron.Scheduler
 }
 
-func NewSecretGuestWorker(
-	offerRepo offer.Repo,
-	applicationRepo application.ApplicationRepo,
-	reportRepo report.Repo,
-	userRepo user.Repo,
-) *SecretGuestWorker {
+func NewSecretGuestWorker(drawUseCase draw.UseCase) *SecretGuestWorker {
 	return &SecretGuestWorker{
-		offerRepo:       offerRepo,
-		applicationRepo: applicationRepo,
-		reportRepo:      reportRepo,
-		userRepo:        userRepo,
-		scheduler:       gocron.NewScheduler(time.UTC),
+		drawUseCase: drawUseCase,
+		scheduler:   gocron.NewScheduler(time.UTC),
 	}
 }
-
 func (w *SecretGuestWorker) Start() {
 	log.Println("Worker started")
 
@@ -69,101 +53,15 @@ func (w *SecretGuestWorker) process() {
 
 	log.Printf("\n [%s] Start offer processing\n", time.Now().Format("15:04:05"))
 
-	offers, err := w.offerRepo.GetByExpirationTime(ctx)
+	drawn, err := w.drawUseCase.DrawReady(ctx, drawBatchSize)
 	if err != nil {
-		log.Printf("❌ Error offers receive %v", err)
-		return
+		log.Printf("❌ Error draw offers: %v", err)
 	}
 
-	if len(offers) == 0 {
+	if drawn == 0 {
 		log.Println("No expired offers")
 		return
 	}
 
-	for _, i := range offers {
-		if err := w.offerRepo.EditStatus(ctx, i.ID, "in_progress"); err != nil {
-			log.Printf("❌ Error change status %v", err)
-			continue
-		}
-
-		applications, err := w.applicationRepo.GetByOfferID(ctx, i.ID)
-		if err != nil {
-			log.Printf("❌Error application receive %v", err)
-			continue
-		}
-
-		if len(applications) == 0 {
-			err := w.offerRepo.EditStatus(ctx, i.ID, "done")
-			if err != nil {
-				return
-			}
-			continue
-		}
-
-		winner, err := w.selectWinnerByRating(ctx, applications)
-		if err != nil {
-			log.Printf("❌ Error selecting winner: %v", err)
-			continue
-		}
-
-		log.Printf("🎉 winner: %s (User %s)", winner.Id, winner.UserId)
-
-		if err := w.offerRepo.EditStatus(ctx, i.ID, "done"); err != nil {
-			log.Printf("❌ Error end offer: %v", err)
-			continue
-		}
-
-		report := reportModel.NewReport(winner.Id, winner.ExpirationAt)
-
-		if err := w.reportRepo.Create(ctx, report); err != nil {
-			log.Printf("❌ Error create report: %v", err)
-			continue
-		}
-
-		winner.Status = appModel.APPLICATION_ACCEPTED
-
-		if err := w.applicationRepo.UpdateApplicationStatus(ctx, winner); err != nil {
-			log.Printf("❌ Error update application status: %v", err)
-			continue
-		}
-	}
-
-	log.Println("\n Processing done")
-}
-
-func (w *SecretGuestWorker) selectWinnerByRating(ctx context.Context, applications []*appModel.Application) (*appModel.Application, error) {
-	if len(applications) == 0 {
-		return nil, errors.New("no applications found")
-	}
-
-	users := make([]userModel.User, 0, len(applications))
-	appByUserID := make(map[uuid.UUID]*appModel.Application)
-
-	for _, app := range applications {
-		user, err := w.userRepo.GetUserById(ctx, app.UserId)
-		if err != nil {
-			log.Printf("⚠️ Warning: failed to get user %s: %v", app.UserId, err)
-			continue
-		}
-
-		users = append(users, *user)
-		appByUserID[user.ID] = app
-	}
-
-	if len(users) == 0 {
-		return nil, errors.New("no valid users found for applications")
-	}
-
-	winnerUserID := pkg.ChooseByRating(users)
-
-	if winnerUserID == uuid.Nil {
-		return nil, errors.New("failed to select winner")
-	}
-
-	winnerApp, ok := appByUserID[winnerUserID]
-	if !ok {
-		return nil, errors.New("winner application not found")
-	}
-
-	return winnerApp, nil
+	log.Printf("\n Processing done, %d offers drawn", drawn)
 }
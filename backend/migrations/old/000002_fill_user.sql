@@ -10,4 +10,10 @@ VALUES ('f47ac10b-58cc-4372-a567-0e02b2c3d479', 'doverlof',
        ('67e55044-10b1-426f-9247-bb680e5fe0c8', 'chicherin',
         'be75f8c0378700bb1f4c1c9dbc64d68fd1a65a4c81cb7f469998200acfc691e2', false),
        ('5f30217f-3cbb-4adc-9e6b-82184a6c4c9e', 'root',
-        'ee1a7dc746c6024bd64fe2e2245e6566fbff2d27f617284a002ec1202734d3c7', true);
\ No newline at end of file
+        'ee1a7dc746c6024bd64fe2e2245e6566fbff2d27f617284a002ec1202734d3c7', true);
+
+INSERT INTO user_role (user_id, role_id)
+SELECT u.id, r.id
+FROM "user" u
+         INNER JOIN role r ON r.name = CASE WHEN u.is_admin THEN 'admin' ELSE 'reviewer' END
+ON CONFLICT DO NOTHING;
\ No newline at end of file
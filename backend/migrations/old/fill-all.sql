@@ -12,6 +12,12 @@ VALUES ('f47ac10b-58cc-4372-a567-0e02b2c3d479', 'doverlof',
        ('5f30217f-3cbb-4adc-9e6b-82184a6c4c9e', 'root',
         'ee1a7dc746c6024bd64fe2e2245e6566fbff2d27f617284a002ec1202734d3c7', true);
 
+INSERT INTO user_role (user_id, role_id)
+SELECT u.id, r.id
+FROM "user" u
+         INNER JOIN role r ON r.name = CASE WHEN u.is_admin THEN 'admin' ELSE 'reviewer' END
+ON CONFLICT DO NOTHING;
+
 INSERT INTO location (id, name)
 VALUES ('f47ac10b-58cc-4372-a567-0e02b2c3d479', 'Moscow'),
        ('6fa459ea-ee8a-3ca4-894e-db77e160355e', 'Saint Petersburg'),
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS refresh_session
+(
+    id          UUID NOT NULL PRIMARY KEY,
+    family_id   UUID NOT NULL,
+    user_id     UUID NOT NULL REFERENCES "user" (id),
+    expires_at  TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at  TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    rotated_at  TIMESTAMP WITH TIME ZONE,
+    revoked_at  TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_refresh_session_family ON refresh_session (family_id);
+CREATE INDEX IF NOT EXISTS idx_refresh_session_user ON refresh_session (user_id);
@@ -0,0 +1,98 @@
+CREATE TABLE IF NOT EXISTS role
+(
+    id          UUID NOT NULL PRIMARY KEY DEFAULT gen_random_uuid(),
+    name        TEXT NOT NULL UNIQUE,
+    description TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS permission
+(
+    id          UUID NOT NULL PRIMARY KEY DEFAULT gen_random_uuid(),
+    name        TEXT NOT NULL UNIQUE,
+    description TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS role_permission
+(
+    role_id       UUID NOT NULL REFERENCES role (id) ON DELETE CASCADE,
+    permission_id UUID NOT NULL REFERENCES permission (id) ON DELETE CASCADE,
+
+    PRIMARY KEY (role_id, permission_id)
+);
+
+CREATE TABLE IF NOT EXISTS user_role
+(
+    user_id    UUID NOT NULL REFERENCES "user" (id) ON DELETE CASCADE,
+    role_id    UUID NOT NULL REFERENCES role (id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    PRIMARY KEY (user_id, role_id)
+);
+
+ALTER TABLE "user" ALTER COLUMN is_admin SET DEFAULT false;
+
+INSERT INTO role (name, description)
+VALUES ('reviewer', 'Тайный гость: подает заявки и пишет отчеты'),
+       ('admin', 'Полный доступ'),
+       ('moderator', 'Проверяет отчеты'),
+       ('analyst', 'Смотрит аналитику, заявки и отчеты'),
+       ('support', 'Помогает пользователям, видит заявки и отчеты'),
+       ('content_manager', 'Ведет офферы и каталог отелей')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO permission (name, description)
+VALUES ('offer:search', 'Поиск открытых офферов'),
+       ('offer:read', 'Просмотр всех офферов'),
+       ('offer:write', 'Создание и редактирование офферов'),
+       ('application:apply', 'Подача и просмотр своих заявок'),
+       ('application:read', 'Просмотр всех заявок'),
+       ('report:submit', 'Заполнение и просмотр своих отчетов'),
+       ('report:read', 'Просмотр всех отчетов'),
+       ('report:confirm', 'Принятие и отклонение отчетов'),
+       ('catalog:write', 'Создание отелей, локаций и номеров'),
+       ('analytics:read', 'Просмотр аналитики'),
+       ('user:manage_roles', 'Назначение ролей пользователям')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permission (role_id, permission_id)
+SELECT r.id, p.id
+FROM (VALUES ('reviewer', 'offer:search'),
+             ('reviewer', 'application:apply'),
+             ('reviewer', 'report:submit'),
+
+             ('moderator', 'offer:read'),
+             ('moderator', 'application:read'),
+             ('moderator', 'report:read'),
+             ('moderator', 'report:confirm'),
+
+             ('analyst', 'offer:read'),
+             ('analyst', 'application:read'),
+             ('analyst', 'report:read'),
+             ('analyst', 'analytics:read'),
+
+             ('support', 'offer:read'),
+             ('support', 'application:read'),
+             ('support', 'report:read'),
+
+             ('content_manager', 'offer:read'),
+             ('content_manager', 'offer:write'),
+             ('content_manager', 'catalog:write')) AS rp(role_name, permission_name)
+         INNER JOIN role r ON r.name = rp.role_name
+         INNER JOIN permission p ON p.name = rp.permission_name
+ON CONFLICT DO NOTHING;
+
+-- Админу выдаем все права, кроме пользовательских (он не подает заявки)
+INSERT INTO role_permission (role_id, permission_id)
+SELECT r.id, p.id
+FROM role r
+         CROSS JOIN permission p
+WHERE r.name = 'admin'
+  AND p.name NOT IN ('offer:search', 'application:apply', 'report:submit')
+ON CONFLICT DO NOTHING;
+
+-- Переносим старый флаг is_admin в роли
+INSERT INTO user_role (user_id, role_id)
+SELECT u.id, r.id
+FROM "user" u
+         INNER JOIN role r ON r.name = CASE WHEN u.is_admin THEN 'admin' ELSE 'reviewer' END
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,26 @@
+-- Сессия пользователя - один логин на одном устройстве. Все refresh-токены,
+-- полученные ротацией от этого логина, имеют family_id = user_session.id
+CREATE TABLE IF NOT EXISTS user_session
+(
+    id           UUID NOT NULL PRIMARY KEY,
+    user_id      UUID NOT NULL REFERENCES "user" (id),
+    user_agent   TEXT NOT NULL DEFAULT '',
+    ip           TEXT NOT NULL DEFAULT '',
+    expires_at   TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at   TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    last_seen_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    revoked_at   TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_session_user ON user_session (user_id);
+
+INSERT INTO permission (name, description)
+VALUES ('user:manage_sessions', 'Принудительное завершение сессий пользователей')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO role_permission (role_id, permission_id)
+SELECT r.id, p.id
+FROM role r
+         INNER JOIN permission p ON p.name = 'user:manage_sessions'
+WHERE r.name IN ('admin', 'support')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,24 @@
+-- Счетчики неудачных входов. key - логин или IP в зависимости от kind
+CREATE TABLE IF NOT EXISTS login_attempt
+(
+    kind            TEXT NOT NULL,
+    key             TEXT NOT NULL,
+    failures        INT  NOT NULL DEFAULT 0,
+    last_failure_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    locked_until    TIMESTAMP WITH TIME ZONE,
+    PRIMARY KEY (kind, key)
+);
+
+-- Журнал блокировок для разбора атак
+CREATE TABLE IF NOT EXISTS login_lockout
+(
+    id           UUID NOT NULL PRIMARY KEY DEFAULT gen_random_uuid(),
+    kind         TEXT NOT NULL,
+    key          TEXT NOT NULL,
+    failures     INT  NOT NULL,
+    ip           TEXT NOT NULL DEFAULT '',
+    locked_until TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at   TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_login_lockout_created ON login_lockout (created_at);
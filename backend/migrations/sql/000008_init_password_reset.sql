@@ -0,0 +1,12 @@
+-- Одноразовые токены сброса пароля. Храним только sha256 токена, сам токен уходит пользователю
+CREATE TABLE IF NOT EXISTS password_reset_token
+(
+    id         UUID NOT NULL PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id    UUID NOT NULL REFERENCES "user" (id),
+    token_hash TEXT NOT NULL UNIQUE,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    used_at    TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_password_reset_token_user ON password_reset_token (user_id);
@@ -0,0 +1,13 @@
+-- Новые аккаунты ждут подтверждения владения учеткой Островка. Уже существующие считаем подтвержденными
+ALTER TABLE "user"
+    ADD COLUMN IF NOT EXISTS status VARCHAR(16) NOT NULL DEFAULT 'active';
+
+-- Одноразовый код, отправленный на почту из Островка. У пользователя не больше одного действующего кода
+CREATE TABLE IF NOT EXISTS email_verification
+(
+    user_id    UUID NOT NULL PRIMARY KEY REFERENCES "user" (id),
+    code_hash  TEXT NOT NULL,
+    attempts   INT  NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
@@ -0,0 +1,53 @@
+-- Реестр промокодов: код уникален, у каждого есть номинал, срок действия и отчет, за который он выдан.
+-- Статус expired не хранится, а вычисляется при чтении по expires_at
+CREATE TABLE IF NOT EXISTS promocode
+(
+    id          UUID        NOT NULL PRIMARY KEY,
+    code        VARCHAR(32) NOT NULL UNIQUE,
+    user_id     UUID        NOT NULL REFERENCES "user" (id),
+    report_id   UUID REFERENCES report (id),
+    value       INT         NOT NULL,
+    currency    VARCHAR(3)  NOT NULL DEFAULT 'RUB',
+    status      VARCHAR(16) NOT NULL DEFAULT 'issued',
+    expires_at  TIMESTAMP WITH TIME ZONE NOT NULL,
+    issued_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    redeemed_at TIMESTAMP WITH TIME ZONE,
+    revoked_at  TIMESTAMP WITH TIME ZONE,
+    replaced_by UUID REFERENCES promocode (id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_promocode_user ON promocode (user_id);
+
+-- За один отчет не может быть двух действующих промокодов
+CREATE UNIQUE INDEX IF NOT EXISTS uq_promocode_report_issued ON promocode (report_id) WHERE status = 'issued';
+
+DO
+$$
+    BEGIN
+        IF EXISTS (SELECT 1
+                   FROM information_schema.columns
+                   WHERE table_name = 'report'
+                     AND column_name = 'promocode') THEN
+            INSERT INTO promocode (id, code, user_id, report_id, value, currency, expires_at, issued_at)
+            SELECT gen_random_uuid(), r.promocode, a.user_id, r.id, 1000, 'RUB', r.created_at + INTERVAL '90 days', r.created_at
+            FROM report r
+                     INNER JOIN application a ON a.id = r.application_id
+            WHERE r.promocode IS NOT NULL
+              AND r.promocode <> ''
+            ON CONFLICT DO NOTHING;
+
+            ALTER TABLE report DROP COLUMN promocode;
+        END IF;
+    END
+$$;
+
+INSERT INTO permission (name, description)
+VALUES ('promocode:manage', 'Просмотр, отзыв и перевыпуск промокодов')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO role_permission (role_id, permission_id)
+SELECT r.id, p.id
+FROM role r
+         INNER JOIN permission p ON p.name = 'promocode:manage'
+WHERE r.name IN ('admin', 'support')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,9 @@
+-- Идентификаторы из фида каталога Островка, по ним импорт находит уже загруженные записи
+ALTER TABLE location
+    ADD COLUMN IF NOT EXISTS external_id TEXT UNIQUE;
+
+ALTER TABLE hotel
+    ADD COLUMN IF NOT EXISTS external_id TEXT UNIQUE;
+
+ALTER TABLE room
+    ADD COLUMN IF NOT EXISTS external_id TEXT UNIQUE;
@@ -0,0 +1,53 @@
+-- Жизненный цикл оффера: draft -> published -> drawing -> awarded -> completed, отмена - cancelled
+UPDATE offer SET status = 'published' WHERE status = 'created' OR status IS NULL;
+
+UPDATE offer SET status = 'drawing' WHERE status = 'in_progress';
+
+-- Розыгрыш с непроверенным отчетом победителя еще ждет отчет, остальные завершены
+UPDATE offer o
+SET status = CASE
+                 WHEN EXISTS (SELECT 1
+                              FROM application a
+                                       INNER JOIN report r ON r.application_id = a.id
+                              WHERE a.offer_id = o.id
+                                AND r.status NOT IN ('accepted', 'declined')) THEN 'awarded'
+                 ELSE 'completed'
+    END
+WHERE status = 'done';
+
+ALTER TABLE offer
+    ALTER COLUMN status SET DEFAULT 'published',
+    ALTER COLUMN status SET NOT NULL;
+
+ALTER TABLE offer
+    DROP CONSTRAINT IF EXISTS chk_offer_status;
+
+ALTER TABLE offer
+    ADD CONSTRAINT chk_offer_status
+        CHECK (status IN ('draft', 'published', 'drawing', 'awarded', 'completed', 'cancelled'));
+
+DROP MATERIALIZED VIEW IF EXISTS monthly_stats;
+
+CREATE MATERIALIZED VIEW IF NOT EXISTS monthly_stats AS
+SELECT DATE_TRUNC('month', CURRENT_DATE) as month_start,
+
+       COUNT(DISTINCT CASE
+                          WHEN o.status IN ('awarded', 'completed')
+                              AND o.check_in_at >= DATE_TRUNC('month', CURRENT_DATE)
+                              AND o.check_in_at < DATE_TRUNC('month', CURRENT_DATE) + INTERVAL '1 month'
+                              THEN o.id END)  as completed_offers,
+
+       COUNT(DISTINCT CASE
+                          WHEN a.created_at >= DATE_TRUNC('month', CURRENT_DATE)
+                              AND a.created_at < DATE_TRUNC('month', CURRENT_DATE) + INTERVAL '1 month'
+                              THEN a.id END)  as applications_received,
+
+       COUNT(DISTINCT CASE
+                          WHEN r.status = 'accepted'
+                              AND r.created_at >= DATE_TRUNC('month', CURRENT_DATE)
+                              AND r.created_at < DATE_TRUNC('month', CURRENT_DATE) + INTERVAL '1 month'
+                              THEN r.id END) as accepted_reports
+
+FROM offer o
+         LEFT JOIN application a ON o.id = a.offer_id
+         LEFT JOIN report r ON a.id = r.application_id;
@@ -0,0 +1,3 @@
+-- Версия оффера для оптимистичной блокировки: каждое изменение увеличивает ее на единицу
+ALTER TABLE offer
+    ADD COLUMN IF NOT EXISTS version INT NOT NULL DEFAULT 1;
@@ -0,0 +1,6 @@
+-- Сколько победителей выбирается в розыгрыше: отель может выделить несколько номеров на одни даты
+ALTER TABLE offer
+    ADD COLUMN IF NOT EXISTS winners_count INT NOT NULL DEFAULT 1;
+
+ALTER TABLE offer
+    ADD CONSTRAINT chk_offer_winners_count CHECK (winners_count > 0);
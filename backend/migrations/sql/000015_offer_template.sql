@@ -0,0 +1,29 @@
+-- Шаблоны повторяющихся офферов: по ним планировщик заранее создает конкретные офферы
+CREATE TABLE IF NOT EXISTS offer_template
+(
+    id                  UUID         NOT NULL PRIMARY KEY,
+    hotel_id            UUID         NOT NULL REFERENCES hotel (id),
+    room_id             UUID         NOT NULL REFERENCES room (id),
+    task                TEXT         NOT NULL,
+    participants_limit  INT          NOT NULL CHECK (participants_limit > 0),
+    winners_count       INT          NOT NULL DEFAULT 1 CHECK (winners_count > 0),
+    period              VARCHAR(16)  NOT NULL CHECK (period IN ('weekly', 'monthly')),
+    starts_at           TIMESTAMP WITH TIME ZONE NOT NULL,
+    check_in_after_days INT          NOT NULL CHECK (check_in_after_days > 0),
+    nights              INT          NOT NULL CHECK (nights > 0),
+    application_days    INT          NOT NULL CHECK (application_days > 0),
+    -- materialized_count - сколько повторений уже пройдено, от него считается следующая публикация
+    materialized_count  INT          NOT NULL DEFAULT 0,
+    next_publish_at     TIMESTAMP WITH TIME ZONE NOT NULL,
+    active              BOOLEAN      NOT NULL DEFAULT TRUE,
+    created_at          TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_offer_template_next_publish ON offer_template (next_publish_at) WHERE active;
+
+-- Офферы из шаблона создаются черновиками и публикуются планировщиком в publish_at
+ALTER TABLE offer
+    ADD COLUMN IF NOT EXISTS template_id UUID REFERENCES offer_template (id),
+    ADD COLUMN IF NOT EXISTS publish_at  TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_offer_scheduled_publish ON offer (publish_at) WHERE status = 'draft';
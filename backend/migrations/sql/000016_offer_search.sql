@@ -0,0 +1,4 @@
+-- Полнотекстовый поиск по заданию оффера и фильтры поиска по датам
+CREATE INDEX IF NOT EXISTS idx_offer_task_fts ON offer USING GIN (to_tsvector('russian', COALESCE(task, '')));
+
+CREATE INDEX IF NOT EXISTS idx_offer_check_in ON offer (check_in_at);
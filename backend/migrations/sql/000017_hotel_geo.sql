@@ -0,0 +1,15 @@
+-- Координаты и адрес отеля для поиска офферов по расстоянию и карты.
+-- PostGIS не используем: расстояние считается формулой гаверсинуса
+ALTER TABLE hotel
+    ADD COLUMN IF NOT EXISTS address   TEXT,
+    ADD COLUMN IF NOT EXISTS latitude  DOUBLE PRECISION,
+    ADD COLUMN IF NOT EXISTS longitude DOUBLE PRECISION;
+
+ALTER TABLE hotel
+    ADD CONSTRAINT chk_hotel_coordinates CHECK (
+        (latitude IS NULL AND longitude IS NULL) OR
+        (latitude BETWEEN -90 AND 90 AND longitude BETWEEN -180 AND 180)
+    );
+
+-- Для отсечения по ограничивающему прямоугольнику перед точным расчетом расстояния
+CREATE INDEX IF NOT EXISTS idx_hotel_coordinates ON hotel (latitude, longitude) WHERE latitude IS NOT NULL;
@@ -0,0 +1,4 @@
+-- Лист ожидания: заявки сверх participants_limit получают статус __app_waitlisted
+-- и переводятся в участники в порядке подачи, когда освобождается место
+CREATE INDEX IF NOT EXISTS idx_application_waitlist ON application (offer_id, created_at, id)
+    WHERE status = '__app_waitlisted';
@@ -0,0 +1,27 @@
+-- Чек-лист задания оффера: пункты с типом ответа и признаком обязательности
+CREATE TABLE IF NOT EXISTS offer_checklist_item
+(
+    id       UUID        NOT NULL PRIMARY KEY,
+    offer_id UUID        NOT NULL REFERENCES offer (id) ON DELETE CASCADE,
+    position INT         NOT NULL,
+    type     VARCHAR(16) NOT NULL CHECK (type IN ('yes_no', 'score', 'text', 'photo')),
+    title    TEXT        NOT NULL,
+    required BOOLEAN     NOT NULL DEFAULT FALSE,
+
+    CONSTRAINT uq_offer_checklist_item UNIQUE (offer_id, position)
+);
+
+-- Ответ отчета на пункт чек-листа. Для пунктов-фото ответ - фотографии с item_id
+CREATE TABLE IF NOT EXISTS report_answer
+(
+    report_id UUID NOT NULL REFERENCES report (id) ON DELETE CASCADE,
+    item_id   UUID NOT NULL REFERENCES offer_checklist_item (id) ON DELETE CASCADE,
+    checked   BOOLEAN,
+    score     SMALLINT CHECK (score BETWEEN 1 AND 5),
+    text      TEXT,
+
+    PRIMARY KEY (report_id, item_id)
+);
+
+ALTER TABLE photo
+    ADD COLUMN IF NOT EXISTS item_id UUID REFERENCES offer_checklist_item (id) ON DELETE SET NULL;
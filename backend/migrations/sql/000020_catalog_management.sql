@@ -0,0 +1,38 @@
+-- Мягкое удаление локаций, отелей и номеров: офферы и отчеты продолжают ссылаться на удаленные записи
+ALTER TABLE location
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+
+ALTER TABLE hotel
+    ADD COLUMN IF NOT EXISTS deleted_at  TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN IF NOT EXISTS stars       SMALLINT CHECK (stars BETWEEN 1 AND 5),
+    ADD COLUMN IF NOT EXISTS description TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS phone       TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS email       TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS website     TEXT NOT NULL DEFAULT '';
+
+-- Номер без отеля - общий тип номера из каталога, его можно использовать в любом отеле
+ALTER TABLE room
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN IF NOT EXISTS hotel_id   UUID REFERENCES hotel (id);
+
+-- Имена уникальны только среди неудаленных записей, у номеров - в пределах отеля
+ALTER TABLE location DROP CONSTRAINT IF EXISTS location_name_key;
+ALTER TABLE hotel DROP CONSTRAINT IF EXISTS hotel_name_key;
+ALTER TABLE room DROP CONSTRAINT IF EXISTS room_name_key;
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_location_name ON location (name) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_hotel_name ON hotel (name) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_room_name ON room (name) WHERE deleted_at IS NULL AND hotel_id IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_hotel_room_name ON room (hotel_id, name) WHERE deleted_at IS NULL AND hotel_id IS NOT NULL;
+
+-- Обложки отеля в S3, position задает порядок показа
+CREATE TABLE IF NOT EXISTS hotel_image
+(
+    id         UUID NOT NULL PRIMARY KEY,
+    hotel_id   UUID NOT NULL REFERENCES hotel (id),
+    s3_link    TEXT NOT NULL UNIQUE,
+    position   INT  NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_hotel_image_hotel ON hotel_image (hotel_id, position);
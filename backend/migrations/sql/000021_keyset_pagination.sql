@@ -0,0 +1,9 @@
+-- Заявки и отчеты пользователя листаются курсором по (created_at, id), поэтому created_at не может быть пустым
+UPDATE application SET created_at = NOW() WHERE created_at IS NULL;
+ALTER TABLE application ALTER COLUMN created_at SET NOT NULL;
+
+UPDATE report SET created_at = NOW() WHERE created_at IS NULL;
+ALTER TABLE report ALTER COLUMN created_at SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_application_user_created ON application (user_id, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_report_created ON report (created_at DESC, id DESC);
@@ -0,0 +1,37 @@
+-- Аудит розыгрышей: по seed, версии алгоритма и кандидатам в том же порядке розыгрыш повторяется с тем же исходом
+CREATE TABLE IF NOT EXISTS draw
+(
+    id                UUID                     NOT NULL PRIMARY KEY,
+    offer_id          UUID                     NOT NULL REFERENCES offer (id),
+    algorithm_version INT                      NOT NULL,
+    seed              BIGINT                   NOT NULL,
+    winners_count     INT                      NOT NULL,
+    created_at        TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_draw_offer ON draw (offer_id, created_at);
+
+-- Кандидат с рейтингом и весом на момент розыгрыша. winner_order - номер победителя, NULL у проигравших
+CREATE TABLE IF NOT EXISTS draw_candidate
+(
+    draw_id        UUID             NOT NULL REFERENCES draw (id) ON DELETE CASCADE,
+    position       INT              NOT NULL,
+    application_id UUID             NOT NULL REFERENCES application (id),
+    user_id        UUID             NOT NULL REFERENCES "user" (id),
+    rating         INT              NOT NULL,
+    weight         DOUBLE PRECISION NOT NULL,
+    winner_order   INT,
+
+    PRIMARY KEY (draw_id, position)
+);
+
+INSERT INTO permission (name, description)
+VALUES ('draw:audit', 'Просмотр и повтор розыгрышей')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO role_permission (role_id, permission_id)
+SELECT r.id, p.id
+FROM role r
+         INNER JOIN permission p ON p.name = 'draw:audit'
+WHERE r.name IN ('admin', 'moderator', 'support')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,2 @@
+-- Воркер берет истекшие офферы к розыгрышу через FOR UPDATE SKIP LOCKED
+CREATE INDEX IF NOT EXISTS idx_offer_draw_ready ON offer (expiration_at, id) WHERE status IN ('published', 'drawing');
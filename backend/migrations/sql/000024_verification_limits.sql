@@ -0,0 +1,4 @@
+-- Отправки кода и неудачные попытки считаются за окно и не сбрасываются повторной отправкой
+ALTER TABLE email_verification
+    ADD COLUMN IF NOT EXISTS sends INT NOT NULL DEFAULT 1,
+    ADD COLUMN IF NOT EXISTS window_started_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW();
@@ -0,0 +1,5 @@
+-- Один промокод на отчет, пока он не отозван: погашенный код тоже блокирует повторную выдачу.
+-- Перевыпуск сначала отзывает старый код, поэтому новый индекс ему не мешает
+DROP INDEX IF EXISTS uq_promocode_report_issued;
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_promocode_report_active ON promocode (report_id) WHERE status <> 'revoked';
@@ -0,0 +1,37 @@
+package pkg
+
+import (
+	"math/rand"
+	"time"
+)
+
+// ExponentialBackoff возвращает base * 2^attempt, но не больше max.
+// attempt начинается с нуля.
+func ExponentialBackoff(base, max time.Duration, attempt int) time.Duration {
+	if attempt < 0 {
+		attempt = 0
+	}
+
+	d := base
+	for i := 0; i < attempt; i++ {
+		if d >= max/2 {
+			return max
+		}
+		d *= 2
+	}
+
+	if d > max {
+		return max
+	}
+
+	return d
+}
+
+// FullJitter возвращает случайную задержку из [0, d), чтобы повторы разных клиентов не совпадали по времени
+func FullJitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+
+	return time.Duration(rand.Int63n(int64(d)))
+}
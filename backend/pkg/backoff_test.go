@@ -0,0 +1,30 @@
+package pkg
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExponentialBackoff(t *testing.T) {
+	base, max := time.Minute, time.Hour
+
+	require.Equal(t, time.Minute, ExponentialBackoff(base, max, -1))
+	require.Equal(t, time.Minute, ExponentialBackoff(base, max, 0))
+	require.Equal(t, 2*time.Minute, ExponentialBackoff(base, max, 1))
+	require.Equal(t, 32*time.Minute, ExponentialBackoff(base, max, 5))
+	require.Equal(t, time.Hour, ExponentialBackoff(base, max, 6))
+	// без переполнения на больших значениях
+	require.Equal(t, time.Hour, ExponentialBackoff(base, max, 1000))
+}
+
+func TestFullJitter(t *testing.T) {
+	require.Zero(t, FullJitter(0))
+
+	for i := 0; i < 100; i++ {
+		d := FullJitter(time.Second)
+		require.GreaterOrEqual(t, d, time.Duration(0))
+		require.Less(t, d, time.Second)
+	}
+}
@@ -0,0 +1,81 @@
+package pkg
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// CircuitBreaker перестает пропускать запросы после threshold ошибок подряд.
+// Через openTimeout пропускает один пробный запрос: успех закрывает цепь, ошибка снова открывает.
+type CircuitBreaker struct {
+	mu          sync.Mutex
+	threshold   int
+	openTimeout time.Duration
+	state       circuitState
+	failures    int
+	openedAt    time.Time
+	now         func() time.Time
+}
+
+func NewCircuitBreaker(threshold int, openTimeout time.Duration) *CircuitBreaker {
+	if threshold < 1 {
+		threshold = 1
+	}
+
+	return &CircuitBreaker{
+		threshold:   threshold,
+		openTimeout: openTimeout,
+		now:         time.Now,
+	}
+}
+
+// Allow возвращает ErrCircuitOpen, если запрос выполнять не нужно
+func (b *CircuitBreaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case circuitOpen:
+		if b.now().Sub(b.openedAt) < b.openTimeout {
+			return ErrCircuitOpen
+		}
+		b.state = circuitHalfOpen
+		return nil
+	case circuitHalfOpen:
+		// пробный запрос уже выполняется
+		return ErrCircuitOpen
+	default:
+		return nil
+	}
+}
+
+func (b *CircuitBreaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.state = circuitClosed
+	b.failures = 0
+}
+
+func (b *CircuitBreaker) Failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+
+	if b.state == circuitHalfOpen || b.failures >= b.threshold {
+		b.state = circuitOpen
+		b.openedAt = b.now()
+	}
+}
@@ -0,0 +1,44 @@
+package pkg
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Now()
+	b := NewCircuitBreaker(2, time.Minute)
+	b.now = func() time.Time { return now }
+
+	require.NoError(t, b.Allow())
+	b.Failure()
+	require.NoError(t, b.Allow(), "одной ошибки недостаточно")
+	b.Failure()
+	require.ErrorIs(t, b.Allow(), ErrCircuitOpen)
+
+	// после таймаута пропускается ровно один пробный запрос
+	now = now.Add(time.Minute)
+	require.NoError(t, b.Allow())
+	require.ErrorIs(t, b.Allow(), ErrCircuitOpen)
+
+	// неудачная проба сразу открывает цепь заново
+	b.Failure()
+	require.ErrorIs(t, b.Allow(), ErrCircuitOpen)
+
+	now = now.Add(time.Minute)
+	require.NoError(t, b.Allow())
+	b.Success()
+	require.NoError(t, b.Allow())
+	require.NoError(t, b.Allow())
+}
+
+func TestCircuitBreakerSuccessResetsFailures(t *testing.T) {
+	b := NewCircuitBreaker(2, time.Minute)
+
+	b.Failure()
+	b.Success()
+	b.Failure()
+	require.NoError(t, b.Allow())
+}
@@ -0,0 +1,17 @@
+package pkg
+
+import "time"
+
+// AddMonths сдвигает t на months месяцев. В отличие от time.AddDate день не переносится
+// в следующий месяц, а прижимается к последнему дню целевого: 31 января + 1 месяц = 28 (29) февраля
+func AddMonths(t time.Time, months int) time.Time {
+	year, month, day := t.Date()
+	hour, min, sec := t.Clock()
+
+	first := time.Date(year, month+time.Month(months), 1, hour, min, sec, t.Nanosecond(), t.Location())
+	if last := first.AddDate(0, 1, -1).Day(); day > last {
+		day = last
+	}
+
+	return first.AddDate(0, 0, day-1)
+}
@@ -0,0 +1,28 @@
+package pkg
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddMonths(t *testing.T) {
+	start := time.Date(2025, time.January, 31, 10, 30, 0, 0, time.UTC)
+
+	// каждый месяц ровно по одному разу, короткие прижимаются к последнему дню
+	require.Equal(t, start, AddMonths(start, 0))
+	require.Equal(t, time.Date(2025, time.February, 28, 10, 30, 0, 0, time.UTC), AddMonths(start, 1))
+	require.Equal(t, time.Date(2025, time.March, 31, 10, 30, 0, 0, time.UTC), AddMonths(start, 2))
+	require.Equal(t, time.Date(2025, time.April, 30, 10, 30, 0, 0, time.UTC), AddMonths(start, 3))
+	require.Equal(t, time.Date(2025, time.May, 31, 10, 30, 0, 0, time.UTC), AddMonths(start, 4))
+	require.Equal(t, time.Date(2026, time.February, 28, 10, 30, 0, 0, time.UTC), AddMonths(start, 13))
+
+	// високосный год и сдвиг назад
+	require.Equal(t, time.Date(2024, time.February, 29, 10, 30, 0, 0, time.UTC), AddMonths(start, -11))
+	require.Equal(t, time.Date(2024, time.December, 31, 10, 30, 0, 0, time.UTC), AddMonths(start, -1))
+
+	// дни до 28 не меняются
+	mid := time.Date(2025, time.January, 15, 0, 0, 0, 0, time.UTC)
+	require.Equal(t, time.Date(2025, time.February, 15, 0, 0, 0, 0, time.UTC), AddMonths(mid, 1))
+}
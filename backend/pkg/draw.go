@@ -0,0 +1,129 @@
+package pkg
+
+import (
+	crand "crypto/rand"
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/rand"
+
+	"github.com/google/uuid"
+	model "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/user"
+)
+
+// DrawAlgorithmVersion меняется вместе с формулой весов или порядком выбора,
+// чтобы сохраненный розыгрыш не воспроизводили другим алгоритмом
+const DrawAlgorithmVersion = 1
+
+var ErrUnknownDrawAlgorithm = errors.New("unknown draw algorithm version")
+
+var (
+	alpha = 0.0149
+	gamma = 0.17628
+)
+
+type DrawCandidate struct {
+	UserID uuid.UUID
+	Rating int
+	Weight float64
+}
+
+// Draw - все, что нужно, чтобы повторить розыгрыш: кандидаты в порядке участия, их веса и seed
+type Draw struct {
+	Algorithm  int
+	Seed       int64
+	Candidates []DrawCandidate
+	// Winners в порядке выбора
+	Winners []uuid.UUID
+}
+
+// NewDrawSeed - seed из криптографического генератора, чтобы его нельзя было предсказать заранее.
+// crypto/rand.Read не возвращает ошибок, при сбое источника программа падает
+func NewDrawSeed() int64 {
+	var buf [8]byte
+	_, _ = crand.Read(buf[:])
+	return int64(binary.LittleEndian.Uint64(buf[:]))
+}
+
+// DrawByRating выбирает до n разных пользователей с шансом по рейтингу.
+// При тех же пользователях в том же порядке и том же seed результат тот же
+func DrawByRating(users []model.User, n int, seed int64) Draw {
+	candidates := make([]DrawCandidate, len(users))
+	for i, user := range users {
+		candidates[i] = DrawCandidate{
+			UserID: user.ID,
+			Rating: user.Rating,
+			Weight: transformRatingToContribution(user.Rating, alpha, gamma),
+		}
+	}
+
+	return Draw{
+		Algorithm:  DrawAlgorithmVersion,
+		Seed:       seed,
+		Candidates: candidates,
+		Winners:    drawWinners(candidates, n, seed),
+	}
+}
+
+// ReplayDraw заново считает веса по сохраненным рейтингам и повторяет выбор с сохраненным seed
+func ReplayDraw(draw Draw, n int) (Draw, error) {
+	if draw.Algorithm != DrawAlgorithmVersion {
+		return Draw{}, ErrUnknownDrawAlgorithm
+	}
+
+	users := make([]model.User, len(draw.Candidates))
+	for i, candidate := range draw.Candidates {
+		users[i] = model.User{ID: candidate.UserID, Rating: candidate.Rating}
+	}
+	return DrawByRating(users, n, draw.Seed), nil
+}
+
+func drawWinners(candidates []DrawCandidate, n int, seed int64) []uuid.UUID {
+	rnd := rand.New(rand.NewSource(seed))
+
+	remaining := make([]DrawCandidate, len(candidates))
+	copy(remaining, candidates)
+
+	if n > len(remaining) {
+		n = len(remaining)
+	}
+
+	winners := make([]uuid.UUID, 0, n)
+	for len(winners) < n {
+		weights := make([]float64, len(remaining))
+		for i, candidate := range remaining {
+			weights[i] = candidate.Weight
+		}
+
+		idx := chooseIndex(weights, rnd.Float64())
+		winners = append(winners, remaining[idx].UserID)
+		remaining = append(remaining[:idx], remaining[idx+1:]...)
+	}
+
+	return winners
+}
+
+// chooseIndex выбирает индекс с вероятностью, пропорциональной весу. x - случайное число из [0, 1)
+func chooseIndex(weights []float64, x float64) int {
+	sum := 0.0
+	for _, w := range weights {
+		sum += w
+	}
+
+	target := x * sum
+	for i, w := range weights {
+		target -= w
+		if target < 0 {
+			return i
+		}
+	}
+	// на случай ошибки округления
+	return len(weights) - 1
+}
+
+func transformRatingToContribution(rating int, alpha, gamma float64) float64 {
+	// f(r): в диапазон [10,100)
+	f := 100 - 90*math.Exp(-alpha*float64(rating))
+	// g(r): степень
+	return math.Pow(f, gamma)
+}
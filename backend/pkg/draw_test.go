@@ -0,0 +1,120 @@
+package pkg
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	model "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/user"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDrawByRatingIsReproducible(t *testing.T) {
+	users := make([]model.User, 0, 10)
+	for i := 0; i < 10; i++ {
+		users = append(users, model.User{ID: uuid.New(), Rating: i * 30})
+	}
+
+	draw := DrawByRating(users, 3, 42)
+	require.Equal(t, DrawAlgorithmVersion, draw.Algorithm)
+	require.Len(t, draw.Candidates, len(users))
+	require.Len(t, draw.Winners, 3)
+
+	for i, candidate := range draw.Candidates {
+		require.Equal(t, users[i].ID, candidate.UserID)
+		require.Equal(t, transformRatingToContribution(users[i].Rating, alpha, gamma), candidate.Weight)
+	}
+
+	// тот же seed - те же победители в том же порядке
+	require.Equal(t, draw.Winners, DrawByRating(users, 3, 42).Winners)
+
+	replay, err := ReplayDraw(draw, 3)
+	require.NoError(t, err)
+	require.Equal(t, draw, replay)
+}
+
+func TestDrawByRatingDependsOnSeed(t *testing.T) {
+	users := make([]model.User, 0, 20)
+	for i := 0; i < 20; i++ {
+		users = append(users, model.User{ID: uuid.New(), Rating: 100})
+	}
+
+	// при равных весах победитель определяется только seed
+	outcomes := make(map[uuid.UUID]bool)
+	for seed := int64(0); seed < 20; seed++ {
+		outcomes[DrawByRating(users, 1, seed).Winners[0]] = true
+	}
+	require.Greater(t, len(outcomes), 1)
+}
+
+func TestReplayDrawUnknownAlgorithm(t *testing.T) {
+	_, err := ReplayDraw(Draw{Algorithm: DrawAlgorithmVersion + 1}, 1)
+	require.ErrorIs(t, err, ErrUnknownDrawAlgorithm)
+}
+
+func TestDrawByRatingWinnersAreDistinct(t *testing.T) {
+	users := make([]model.User, 0, 5)
+	for i := 0; i < 5; i++ {
+		users = append(users, model.User{ID: uuid.New(), Rating: i * 50})
+	}
+
+	winners := DrawByRating(users, 3, NewDrawSeed()).Winners
+	require.Len(t, winners, 3)
+
+	seen := make(map[uuid.UUID]bool, len(winners))
+	for _, id := range winners {
+		require.False(t, seen[id], "победитель не должен повторяться")
+		seen[id] = true
+	}
+
+	// мест больше, чем участников - побеждают все
+	require.ElementsMatch(t, []uuid.UUID{users[0].ID, users[1].ID, users[2].ID, users[3].ID, users[4].ID},
+		DrawByRating(users, 10, NewDrawSeed()).Winners)
+
+	require.Empty(t, DrawByRating(nil, 2, NewDrawSeed()).Winners)
+}
+
+func TestContributionFromRating(t *testing.T) {
+
+	var (
+		alphas = []float64{0.0149}
+		gammas = []float64{0.17628}
+	)
+
+	bigUserRating := 10_000
+	normalUserRating := 100
+	smallUserRating := 0
+	smallUser2Rating := 10
+
+	for _, alpha = range alphas {
+		for _, gamma = range gammas {
+			contributionOfBigUser := transformRatingToContribution(bigUserRating, alpha, gamma)
+			contributionOfNormalUserRating := transformRatingToContribution(normalUserRating, alpha, gamma)
+			contributionOfSmallUserRating := transformRatingToContribution(smallUserRating, alpha, gamma)
+			contributionOfSmallUser2Rating := transformRatingToContribution(smallUser2Rating, alpha, gamma)
+			fmt.Printf("With alpha = %+v, gamma= %+v\n", alpha, gamma)
+			fmt.Println("Contribution of big user:    ", contributionOfBigUser)
+			fmt.Println("Contribution of normal user: ", contributionOfNormalUserRating)
+			fmt.Println("Contribution of small user:  ", contributionOfSmallUserRating)
+			fmt.Println("Contribution of small user2: ", contributionOfSmallUser2Rating)
+			fmt.Println("small if small vs normal user: ",
+				contributionOfSmallUserRating/(contributionOfNormalUserRating+contributionOfSmallUserRating))
+			fmt.Println("normal if normal vs big user: ",
+				contributionOfNormalUserRating/(contributionOfNormalUserRating+contributionOfBigUser))
+			fmt.Println("small if small vs small2 user: ",
+				contributionOfSmallUserRating/(contributionOfSmallUserRating+contributionOfSmallUser2Rating))
+			fmt.Println()
+		}
+	}
+
+	//Result
+	//With alpha = 0.0149, gamma= 0.17628
+	//Contribution of big user:     2.251956514544276
+	//Contribution of normal user:  2.1637390507268726
+	//Contribution of small user:   1.5006520298004717
+	//Contribution of small user2:  1.7995965185560954
+	//small if small vs normal user:  0.40952289120420854
+	//normal if normal vs big user:  0.4900109209847683
+	//small if small vs small2 user:  0.4547087917204767
+
+}
@@ -1,11 +1,107 @@
 package pkg
 
 import (
+	"crypto/rand"
 	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// Параметры argon2id для новых хешей. При их изменении старые хеши
+// пересчитываются при следующем успешном входе (см. VerifyPassword).
+const (
+	argonTime    uint32 = 1
+	argonMemory  uint32 = 64 * 1024
+	argonThreads uint8  = 4
+	argonKeyLen  uint32 = 32
+	argonSaltLen        = 16
+
+	argonPrefix = "$argon2id$"
 )
 
+var b64 = base64.RawStdEncoding
+
+// HashPassword возвращает хеш в PHC-формате:
+// $argon2id$v=19$m=65536,t=1,p=4$<salt>$<hash>
 func HashPassword(password string) string {
-	hash := sha256.Sum256([]byte(password))
-	return fmt.Sprintf("%x", hash)
+	salt := make([]byte, argonSaltLen)
+	// crypto/rand.Read не возвращает ошибок начиная с go 1.24
+	_, _ = rand.Read(salt)
+
+	key := argon2.IDKey([]byte(password), salt, argonTime, argonMemory, argonThreads, argonKeyLen)
+
+	return fmt.Sprintf(
+		"%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		argonPrefix, argon2.Version, argonMemory, argonTime, argonThreads,
+		b64.EncodeToString(salt), b64.EncodeToString(key),
+	)
+}
+
+// VerifyPassword сравнивает пароль с сохраненным хешем за постоянное время.
+// needsRehash = true, если хеш устаревшего формата (sha256) или с устаревшими параметрами,
+// и его стоит перезаписать результатом HashPassword.
+func VerifyPassword(password, encoded string) (ok bool, needsRehash bool) {
+	if !strings.HasPrefix(encoded, argonPrefix) {
+		return verifyLegacySHA256(password, encoded), true
+	}
+
+	var (
+		version      int
+		memory, time uint32
+		threads      uint8
+	)
+
+	parts := strings.Split(encoded, "$")
+	// "", "argon2id", "v=19", "m=...,t=...,p=...", salt, hash
+	if len(parts) != 6 {
+		return false, false
+	}
+
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return false, false
+	}
+
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &memory, &time, &threads); err != nil {
+		return false, false
+	}
+
+	salt, err := b64.DecodeString(parts[4])
+	if err != nil {
+		return false, false
+	}
+
+	key, err := b64.DecodeString(parts[5])
+	if err != nil {
+		return false, false
+	}
+
+	actual := argon2.IDKey([]byte(password), salt, time, memory, threads, uint32(len(key)))
+
+	if subtle.ConstantTimeCompare(actual, key) != 1 {
+		return false, false
+	}
+
+	needsRehash = memory != argonMemory ||
+		time != argonTime ||
+		threads != argonThreads ||
+		uint32(len(key)) != argonKeyLen
+
+	return true, needsRehash
+}
+
+// verifyLegacySHA256 проверяет хеши, созданные до перехода на argon2id
+func verifyLegacySHA256(password, encoded string) bool {
+	stored, err := hex.DecodeString(encoded)
+	if err != nil || len(stored) != sha256.Size {
+		return false
+	}
+
+	actual := sha256.Sum256([]byte(password))
+
+	return subtle.ConstantTimeCompare(actual[:], stored) == 1
 }
@@ -0,0 +1,59 @@
+package pkg
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/argon2"
+)
+
+func TestHashPasswordArgon(t *testing.T) {
+	hash := HashPassword("Root12345!")
+
+	require.True(t, strings.HasPrefix(hash, "$argon2id$v=19$"))
+	require.NotEqual(t, hash, HashPassword("Root12345!"), "соль должна быть своя у каждого хеша")
+
+	ok, needsRehash := VerifyPassword("Root12345!", hash)
+	require.True(t, ok)
+	require.False(t, needsRehash)
+
+	ok, _ = VerifyPassword("Root12345?", hash)
+	require.False(t, ok)
+}
+
+func TestVerifyPasswordLegacySHA256(t *testing.T) {
+	// хеш root из тестовых данных
+	legacy := "ee1a7dc746c6024bd64fe2e2245e6566fbff2d27f617284a002ec1202734d3c7"
+
+	ok, needsRehash := VerifyPassword("Root12345!", legacy)
+	require.True(t, ok)
+	require.True(t, needsRehash)
+
+	ok, _ = VerifyPassword("Root12345?", legacy)
+	require.False(t, ok)
+}
+
+func TestVerifyPasswordOutdatedParams(t *testing.T) {
+	salt := []byte("0123456789abcdef")
+	key := argon2.IDKey([]byte("Root12345!"), salt, 2, 32*1024, 2, 32)
+	outdated := fmt.Sprintf("$argon2id$v=19$m=%d,t=%d,p=%d$%s$%s",
+		32*1024, 2, 2, b64.EncodeToString(salt), b64.EncodeToString(key))
+
+	ok, needsRehash := VerifyPassword("Root12345!", outdated)
+	require.True(t, ok)
+	require.True(t, needsRehash)
+}
+
+func TestVerifyPasswordMalformed(t *testing.T) {
+	for _, encoded := range []string{
+		"",
+		"$argon2id$",
+		"$argon2id$v=19$m=65536,t=1,p=4$bad salt$bad hash",
+		"not-a-hex-string",
+	} {
+		ok, _ := VerifyPassword("Root12345!", encoded)
+		require.False(t, ok, encoded)
+	}
+}
@@ -0,0 +1,162 @@
+package pkg
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	ErrNoJWTKeys         = errors.New("no jwt keys configured")
+	ErrJWTSigningKey     = errors.New("jwt signing key not found or has no private part")
+	ErrUnknownJWTKey     = errors.New("unknown jwt key id")
+	ErrUnexpectedJWTAlg  = errors.New("unexpected jwt signing method")
+	ErrDuplicateJWTKeyID = errors.New("duplicate jwt key id")
+	ErrUnsupportedJWTKey = errors.New("unsupported jwt key type, only Ed25519 is supported")
+)
+
+var jwtSigningMethod = jwt.SigningMethodEdDSA
+
+// JWTKey - Ed25519 ключ с идентификатором kid. У ключей, оставленных только для проверки
+// выданных ранее токенов, приватной части нет.
+type JWTKey struct {
+	ID      string
+	Private ed25519.PrivateKey
+	Public  ed25519.PublicKey
+}
+
+// ParseJWTPrivateKey разбирает PEM с приватным Ed25519 ключом в формате PKCS#8
+func ParseJWTPrivateKey(id string, pemData []byte) (JWTKey, error) {
+	key, err := jwt.ParseEdPrivateKeyFromPEM(pemData)
+	if err != nil {
+		return JWTKey{}, fmt.Errorf("failed to parse private key %s: %w", id, err)
+	}
+
+	private, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return JWTKey{}, ErrUnsupportedJWTKey
+	}
+
+	return JWTKey{
+		ID:      id,
+		Private: private,
+		Public:  private.Public().(ed25519.PublicKey),
+	}, nil
+}
+
+// ParseJWTPublicKey разбирает PEM с публичным Ed25519 ключом в формате PKIX
+func ParseJWTPublicKey(id string, pemData []byte) (JWTKey, error) {
+	key, err := jwt.ParseEdPublicKeyFromPEM(pemData)
+	if err != nil {
+		return JWTKey{}, fmt.Errorf("failed to parse public key %s: %w", id, err)
+	}
+
+	public, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return JWTKey{}, ErrUnsupportedJWTKey
+	}
+
+	return JWTKey{
+		ID:     id,
+		Public: public,
+	}, nil
+}
+
+// JWTKeySet подписывает токены одним ключом и проверяет любым из известных.
+// Для ротации новый ключ делают подписывающим, а старый оставляют в наборе, пока не истекут его токены.
+type JWTKeySet struct {
+	signing JWTKey
+	keys    map[string]JWTKey
+	order   []string
+}
+
+func NewJWTKeySet(signingKeyID string, keys ...JWTKey) (*JWTKeySet, error) {
+	if len(keys) == 0 {
+		return nil, ErrNoJWTKeys
+	}
+
+	set := &JWTKeySet{
+		keys: make(map[string]JWTKey, len(keys)),
+	}
+
+	for _, key := range keys {
+		if _, ok := set.keys[key.ID]; ok {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateJWTKeyID, key.ID)
+		}
+
+		set.keys[key.ID] = key
+		set.order = append(set.order, key.ID)
+	}
+
+	signing, ok := set.keys[signingKeyID]
+	if !ok || signing.Private == nil {
+		return nil, fmt.Errorf("%w: %s", ErrJWTSigningKey, signingKeyID)
+	}
+
+	set.signing = signing
+
+	return set, nil
+}
+
+// Sign подписывает claims текущим ключом и проставляет его kid в заголовок
+func (s *JWTKeySet) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(jwtSigningMethod, claims)
+	token.Header["kid"] = s.signing.ID
+
+	return token.SignedString(s.signing.Private)
+}
+
+// Keyfunc выбирает ключ проверки по kid из заголовка токена
+func (s *JWTKeySet) Keyfunc(token *jwt.Token) (interface{}, error) {
+	if token.Method.Alg() != jwtSigningMethod.Alg() {
+		return nil, ErrUnexpectedJWTAlg
+	}
+
+	kid, _ := token.Header["kid"].(string)
+
+	key, ok := s.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownJWTKey, kid)
+	}
+
+	return key.Public, nil
+}
+
+// ParserOptions ограничивает допустимые алгоритмы, чтобы токен нельзя было подсунуть с alg=none или HS256
+func (s *JWTKeySet) ParserOptions() []jwt.ParserOption {
+	return []jwt.ParserOption{jwt.WithValidMethods([]string{jwtSigningMethod.Alg()})}
+}
+
+type JWK struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS возвращает публичные части всех ключей в формате RFC 7517 / RFC 8037
+func (s *JWTKeySet) JWKS() JWKS {
+	jwks := JWKS{Keys: make([]JWK, 0, len(s.order))}
+
+	for _, id := range s.order {
+		jwks.Keys = append(jwks.Keys, JWK{
+			Kty: "OKP",
+			Crv: "Ed25519",
+			X:   base64.RawURLEncoding.EncodeToString(s.keys[id].Public),
+			Kid: id,
+			Use: "sig",
+			Alg: jwtSigningMethod.Alg(),
+		})
+	}
+
+	return jwks
+}
@@ -0,0 +1,89 @@
+package pkg
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestJWTKey(t *testing.T, id string) JWTKey {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	return JWTKey{ID: id, Private: private, Public: public}
+}
+
+func parseWithKeySet(s *JWTKeySet, token string) (*jwt.RegisteredClaims, error) {
+	claims := &jwt.RegisteredClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, s.Keyfunc, s.ParserOptions()...)
+	return claims, err
+}
+
+func TestJWTKeySetRotation(t *testing.T) {
+	oldKey, newKey := newTestJWTKey(t, "2025-01"), newTestJWTKey(t, "2025-06")
+
+	oldSet, err := NewJWTKeySet(oldKey.ID, oldKey)
+	require.NoError(t, err)
+
+	oldToken, err := oldSet.Sign(jwt.RegisteredClaims{Subject: "old"})
+	require.NoError(t, err)
+
+	// после ротации старый ключ остается только для проверки
+	verifyOnly := JWTKey{ID: oldKey.ID, Public: oldKey.Public}
+	newSet, err := NewJWTKeySet(newKey.ID, newKey, verifyOnly)
+	require.NoError(t, err)
+
+	newToken, err := newSet.Sign(jwt.RegisteredClaims{Subject: "new"})
+	require.NoError(t, err)
+
+	claims, err := parseWithKeySet(newSet, oldToken)
+	require.NoError(t, err)
+	require.Equal(t, "old", claims.Subject)
+
+	claims, err = parseWithKeySet(newSet, newToken)
+	require.NoError(t, err)
+	require.Equal(t, "new", claims.Subject)
+
+	// старый набор не знает новый kid
+	_, err = parseWithKeySet(oldSet, newToken)
+	require.ErrorIs(t, err, ErrUnknownJWTKey)
+
+	jwks := newSet.JWKS()
+	require.Len(t, jwks.Keys, 2)
+	require.Equal(t, "2025-06", jwks.Keys[0].Kid)
+	require.Equal(t, "OKP", jwks.Keys[0].Kty)
+	require.Equal(t, "EdDSA", jwks.Keys[0].Alg)
+}
+
+func TestJWTKeySetRejectsHMAC(t *testing.T) {
+	key := newTestJWTKey(t, "k1")
+	set, err := NewJWTKeySet(key.ID, key)
+	require.NoError(t, err)
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{Subject: "x"})
+	token.Header["kid"] = key.ID
+	signed, err := token.SignedString([]byte(key.Public))
+	require.NoError(t, err)
+
+	_, err = parseWithKeySet(set, signed)
+	require.Error(t, err)
+}
+
+func TestNewJWTKeySetValidation(t *testing.T) {
+	key := newTestJWTKey(t, "k1")
+
+	_, err := NewJWTKeySet("k1")
+	require.ErrorIs(t, err, ErrNoJWTKeys)
+
+	_, err = NewJWTKeySet("k2", key)
+	require.ErrorIs(t, err, ErrJWTSigningKey)
+
+	_, err = NewJWTKeySet("k1", JWTKey{ID: "k1", Public: key.Public})
+	require.ErrorIs(t, err, ErrJWTSigningKey)
+
+	_, err = NewJWTKeySet("k1", key, key)
+	require.ErrorIs(t, err, ErrDuplicateJWTKeyID)
+}
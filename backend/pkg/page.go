@@ -0,0 +1,100 @@
+package pkg
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+)
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+var (
+	ErrInvalidPageSize = fmt.Errorf("page size must be in [1, %d]", MaxPageSize)
+	ErrInvalidPageNum  = errors.New("page number is too large")
+	ErrInvalidCursor   = errors.New("invalid cursor")
+)
+
+// PageRequest - страница по номеру или по курсору. Курсор задан (даже пустой) - выдача без подсчета страниц
+type PageRequest struct {
+	Size   uint64
+	Num    uint64
+	Cursor Opt[string]
+}
+
+func (p PageRequest) Validate() error {
+	if p.Size == 0 || p.Size > MaxPageSize {
+		return ErrInvalidPageSize
+	}
+	// Offset уходит в OFFSET запроса, который в Postgres не больше MaxInt64
+	if p.Num > math.MaxInt64/p.Size {
+		return ErrInvalidPageNum
+	}
+	return nil
+}
+
+func (p PageRequest) Offset() uint64 {
+	return p.Num * p.Size
+}
+
+func (p PageRequest) IsCursor() bool {
+	return p.Cursor.IsExists()
+}
+
+// Page - в режиме номеров заполнено PagesCount, в режиме курсора - NextCursor. Пустой NextCursor - страница последняя
+type Page[T any] struct {
+	Items      []T
+	PagesCount int
+	NextCursor string
+}
+
+// PagesCount - число страниц по числу записей, при нулевом размере страницы страниц нет
+func PagesCount(total int, size uint64) int {
+	if total <= 0 || size == 0 {
+		return 0
+	}
+	return int((uint64(total) + size - 1) / size)
+}
+
+func NumberPage[T any](items []T, total int, size uint64) Page[T] {
+	return Page[T]{Items: items, PagesCount: PagesCount(total, size)}
+}
+
+// CursorPage ожидает на одну запись больше размера страницы: лишняя запись отрезается
+// и означает, что есть следующая страница, курсор строится по последней оставшейся
+func CursorPage[T, C any](items []T, size uint64, cursor func(T) C) (Page[T], error) {
+	if uint64(len(items)) <= size {
+		return Page[T]{Items: items}, nil
+	}
+
+	items = items[:size]
+	next, err := EncodeCursor(cursor(items[len(items)-1]))
+	if err != nil {
+		return Page[T]{}, err
+	}
+	return Page[T]{Items: items, NextCursor: next}, nil
+}
+
+// EncodeCursor - курсор непрозрачен для клиента: JSON в base64 без паддинга, чтобы его можно было передать в URL
+func EncodeCursor(value any) (string, error) {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode cursor: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+func DecodeCursor(cursor string, value any) error {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return ErrInvalidCursor
+	}
+	if err := json.Unmarshal(raw, value); err != nil {
+		return ErrInvalidCursor
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package pkg
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPagesCount(t *testing.T) {
+	require.Equal(t, 0, PagesCount(0, 10))
+	require.Equal(t, 1, PagesCount(1, 10))
+	require.Equal(t, 1, PagesCount(10, 10))
+	require.Equal(t, 2, PagesCount(11, 10))
+	// нулевой размер страницы не должен приводить к делению на ноль
+	require.Equal(t, 0, PagesCount(5, 0))
+}
+
+func TestPageRequestValidate(t *testing.T) {
+	require.ErrorIs(t, PageRequest{Size: 0}.Validate(), ErrInvalidPageSize)
+	require.ErrorIs(t, PageRequest{Size: MaxPageSize + 1}.Validate(), ErrInvalidPageSize)
+	require.NoError(t, PageRequest{Size: MaxPageSize}.Validate())
+	require.NoError(t, PageRequest{Size: 10, Num: math.MaxInt64 / 10}.Validate())
+	require.ErrorIs(t, PageRequest{Size: 10, Num: math.MaxInt64/10 + 1}.Validate(), ErrInvalidPageNum)
+	require.ErrorIs(t, PageRequest{Size: 1, Num: math.MaxUint64}.Validate(), ErrInvalidPageNum)
+
+	page := PageRequest{Size: 10, Num: 3}
+	require.Equal(t, uint64(30), page.Offset())
+	require.False(t, page.IsCursor())
+
+	page.Cursor = NewWithValue("")
+	require.True(t, page.IsCursor())
+}
+
+type testCursor struct {
+	At time.Time `json:"at"`
+	ID int       `json:"id"`
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	in := testCursor{At: time.Date(2025, 6, 1, 12, 30, 0, 123456000, time.UTC), ID: 42}
+
+	encoded, err := EncodeCursor(in)
+	require.NoError(t, err)
+	require.NotContains(t, encoded, "=")
+
+	var out testCursor
+	require.NoError(t, DecodeCursor(encoded, &out))
+	require.True(t, in.At.Equal(out.At))
+	require.Equal(t, in.ID, out.ID)
+
+	require.ErrorIs(t, DecodeCursor("not a cursor!", &out), ErrInvalidCursor)
+	require.ErrorIs(t, DecodeCursor("bm90IGpzb24", &out), ErrInvalidCursor)
+}
+
+func TestCursorPage(t *testing.T) {
+	cursor := func(v int) int { return v }
+
+	page, err := CursorPage([]int{1, 2, 3}, 3, cursor)
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 2, 3}, page.Items)
+	require.Empty(t, page.NextCursor)
+
+	page, err = CursorPage([]int{1, 2, 3, 4}, 3, cursor)
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 2, 3}, page.Items)
+
+	var last int
+	require.NoError(t, DecodeCursor(page.NextCursor, &last))
+	require.Equal(t, 3, last)
+}
@@ -0,0 +1,95 @@
+package pkg
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// TTLCache - LRU-кэш ограниченного размера. Просроченные записи не удаляются сразу:
+// их можно отдать как устаревшие, если свежее значение получить не удалось
+type TTLCache[K comparable, V any] struct {
+	mu      sync.Mutex
+	size    int
+	ttl     time.Duration
+	entries map[K]*list.Element
+	order   *list.List
+	now     func() time.Time
+}
+
+type ttlCacheEntry[K comparable, V any] struct {
+	key       K
+	value     V
+	expiresAt time.Time
+}
+
+func NewTTLCache[K comparable, V any](size int, ttl time.Duration) *TTLCache[K, V] {
+	if size < 1 {
+		size = 1
+	}
+
+	return &TTLCache[K, V]{
+		size:    size,
+		ttl:     ttl,
+		entries: make(map[K]*list.Element, size),
+		order:   list.New(),
+		now:     time.Now,
+	}
+}
+
+// Get возвращает значение и признак того, что оно еще не просрочено
+func (c *TTLCache[K, V]) Get(key K) (value V, fresh bool, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		return value, false, false
+	}
+
+	c.order.MoveToFront(el)
+	entry := el.Value.(*ttlCacheEntry[K, V])
+
+	return entry.value, c.now().Before(entry.expiresAt), true
+}
+
+// Set добавляет значение и вытесняет самое давно использованное, если кэш заполнен
+func (c *TTLCache[K, V]) Set(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expiresAt := c.now().Add(c.ttl)
+
+	if el, ok := c.entries[key]; ok {
+		entry := el.Value.(*ttlCacheEntry[K, V])
+		entry.value = value
+		entry.expiresAt = expiresAt
+		c.order.MoveToFront(el)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&ttlCacheEntry[K, V]{key: key, value: value, expiresAt: expiresAt})
+
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*ttlCacheEntry[K, V]).key)
+	}
+}
+
+func (c *TTLCache[K, V]) Delete(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[key]; ok {
+		c.order.Remove(el)
+		delete(c.entries, key)
+	}
+}
+
+func (c *TTLCache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
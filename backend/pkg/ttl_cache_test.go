@@ -0,0 +1,59 @@
+package pkg
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTTLCacheExpiration(t *testing.T) {
+	now := time.Now()
+	c := NewTTLCache[string, int](10, time.Minute)
+	c.now = func() time.Time { return now }
+
+	_, _, ok := c.Get("a")
+	require.False(t, ok)
+
+	c.Set("a", 1)
+	v, fresh, ok := c.Get("a")
+	require.True(t, ok)
+	require.True(t, fresh)
+	require.Equal(t, 1, v)
+
+	// просроченная запись остается доступной как устаревшая
+	now = now.Add(time.Minute)
+	v, fresh, ok = c.Get("a")
+	require.True(t, ok)
+	require.False(t, fresh)
+	require.Equal(t, 1, v)
+
+	c.Set("a", 2)
+	v, fresh, _ = c.Get("a")
+	require.True(t, fresh)
+	require.Equal(t, 2, v)
+
+	c.Delete("a")
+	_, _, ok = c.Get("a")
+	require.False(t, ok)
+}
+
+func TestTTLCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	c := NewTTLCache[string, int](2, time.Minute)
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Get("a")
+	c.Set("c", 3)
+
+	require.Equal(t, 2, c.Len())
+
+	_, _, ok := c.Get("b")
+	require.False(t, ok, "b использовался давнее всех")
+
+	_, _, ok = c.Get("a")
+	require.True(t, ok)
+
+	_, _, ok = c.Get("c")
+	require.True(t, ok)
+}
@@ -0,0 +1,219 @@
+package pkg
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidXLSX = errors.New("invalid xlsx file")
+
+// xlsxMaxPartSize - ограничение на распакованный размер одной части книги, чтобы zip-бомба не съела память
+const xlsxMaxPartSize = 64 << 20
+
+type xlsxWorkbook struct {
+	Sheets []struct {
+		RelID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type xlsxRelationships struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+type xlsxSharedStrings struct {
+	Items []xlsxRichText `xml:"si"`
+}
+
+// xlsxRichText - строка может быть целиком в <t> или разбита на куски с разным форматированием в <r><t>
+type xlsxRichText struct {
+	Text string `xml:"t"`
+	Runs []struct {
+		Text string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t xlsxRichText) String() string {
+	if len(t.Runs) == 0 {
+		return t.Text
+	}
+
+	var b strings.Builder
+	b.WriteString(t.Text)
+	for _, run := range t.Runs {
+		b.WriteString(run.Text)
+	}
+	return b.String()
+}
+
+type xlsxSheet struct {
+	Rows []struct {
+		Index int `xml:"r,attr"`
+		Cells []struct {
+			Ref    string       `xml:"r,attr"`
+			Type   string       `xml:"t,attr"`
+			Value  string       `xml:"v"`
+			Inline xlsxRichText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// ReadXLSX возвращает строки первого листа книги как текст. Пустые строки листа сохраняются,
+// чтобы номер строки совпадал с тем, что видит пользователь в Excel.
+// Числа и даты отдаются как есть: дата - это число дней, см. ExcelSerialToTime
+func ReadXLSX(r io.ReaderAt, size int64) ([][]string, error) {
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidXLSX, err)
+	}
+
+	sheetPath, err := firstSheetPath(archive)
+	if err != nil {
+		return nil, err
+	}
+
+	// В книге без текстовых ячеек sharedStrings может не быть
+	var shared xlsxSharedStrings
+	if err := readXLSXPart(archive, "xl/sharedStrings.xml", &shared); err != nil && !errors.Is(err, errXLSXPartNotFound) {
+		return nil, err
+	}
+
+	var sheet xlsxSheet
+	if err := readXLSXPart(archive, sheetPath, &sheet); err != nil {
+		return nil, err
+	}
+
+	var rows [][]string
+	for _, row := range sheet.Rows {
+		index := row.Index
+		if index == 0 {
+			index = len(rows) + 1
+		}
+		for len(rows) < index-1 {
+			rows = append(rows, nil)
+		}
+
+		var values []string
+		for i, cell := range row.Cells {
+			column := i
+			if cell.Ref != "" {
+				column, err = xlsxColumnIndex(cell.Ref)
+				if err != nil {
+					return nil, err
+				}
+			}
+			for len(values) < column {
+				values = append(values, "")
+			}
+
+			value := cell.Value
+			switch cell.Type {
+			case "s":
+				idx, err := strconv.Atoi(cell.Value)
+				if err != nil || idx < 0 || idx >= len(shared.Items) {
+					return nil, fmt.Errorf("%w: bad shared string in %s", ErrInvalidXLSX, cell.Ref)
+				}
+				value = shared.Items[idx].String()
+			case "inlineStr":
+				value = cell.Inline.String()
+			}
+
+			values = append(values, value)
+		}
+
+		rows = append(rows, values)
+	}
+
+	return rows, nil
+}
+
+var errXLSXPartNotFound = errors.New("xlsx part not found")
+
+func readXLSXPart(archive *zip.Reader, name string, v any) error {
+	for _, file := range archive.File {
+		if file.Name != name {
+			continue
+		}
+
+		rc, err := file.Open()
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidXLSX, err)
+		}
+		defer rc.Close()
+
+		if err := xml.NewDecoder(io.LimitReader(rc, xlsxMaxPartSize)).Decode(v); err != nil {
+			return fmt.Errorf("%w: %s: %v", ErrInvalidXLSX, name, err)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("%w: %w: %s", ErrInvalidXLSX, errXLSXPartNotFound, name)
+}
+
+// firstSheetPath находит файл первого листа по workbook.xml. Если книга собрана без связей, берем sheet1.xml
+func firstSheetPath(archive *zip.Reader) (string, error) {
+	const fallback = "xl/worksheets/sheet1.xml"
+
+	var workbook xlsxWorkbook
+	if err := readXLSXPart(archive, "xl/workbook.xml", &workbook); err != nil {
+		return "", err
+	}
+
+	if len(workbook.Sheets) == 0 {
+		return "", fmt.Errorf("%w: workbook has no sheets", ErrInvalidXLSX)
+	}
+
+	var rels xlsxRelationships
+	if err := readXLSXPart(archive, "xl/_rels/workbook.xml.rels", &rels); err != nil {
+		return fallback, nil
+	}
+
+	for _, rel := range rels.Relationships {
+		if rel.ID != workbook.Sheets[0].RelID {
+			continue
+		}
+		if strings.HasPrefix(rel.Target, "/") {
+			return strings.TrimPrefix(rel.Target, "/"), nil
+		}
+		return path.Join("xl", rel.Target), nil
+	}
+
+	return fallback, nil
+}
+
+// xlsxColumnIndex переводит ссылку вида "AB12" в номер колонки с нуля
+func xlsxColumnIndex(ref string) (int, error) {
+	column := 0
+	letters := 0
+	for _, ch := range ref {
+		if ch < 'A' || ch > 'Z' {
+			break
+		}
+		column = column*26 + int(ch-'A'+1)
+		letters++
+	}
+
+	if letters == 0 {
+		return 0, fmt.Errorf("%w: bad cell reference %q", ErrInvalidXLSX, ref)
+	}
+	return column - 1, nil
+}
+
+// excelEpoch - день ноль в Excel с учетом ошибки с 29 февраля 1900 года
+var excelEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+// ExcelSerialToTime переводит дату Excel (дни с дробной частью времени) в UTC
+func ExcelSerialToTime(serial float64) time.Time {
+	days := int(serial)
+	seconds := int((serial-float64(days))*24*60*60 + 0.5)
+	return excelEpoch.AddDate(0, 0, days).Add(time.Duration(seconds) * time.Second)
+}
@@ -0,0 +1,99 @@
+package pkg
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func buildXLSX(t *testing.T, parts map[string]string) *bytes.Reader {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, content := range parts {
+		f, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+
+	return bytes.NewReader(buf.Bytes())
+}
+
+const testWorkbook = `<?xml version="1.0" encoding="UTF-8"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"
+          xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+  <sheets><sheet name="Offers" sheetId="1" r:id="rId2"/></sheets>
+</workbook>`
+
+const testRels = `<?xml version="1.0" encoding="UTF-8"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+  <Relationship Id="rId1" Type="styles" Target="styles.xml"/>
+  <Relationship Id="rId2" Type="worksheet" Target="worksheets/offers.xml"/>
+</Relationships>`
+
+const testSharedStrings = `<?xml version="1.0" encoding="UTF-8"?>
+<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+  <si><t>hotel</t></si>
+  <si><t>limit</t></si>
+  <si><r><t>Moscow </t></r><r><t>Grand</t></r></si>
+</sst>`
+
+const testSheet = `<?xml version="1.0" encoding="UTF-8"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+  <sheetData>
+    <row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c></row>
+    <row r="3"><c r="A3" t="s"><v>2</v></c><c r="C3"><v>45658</v></c></row>
+    <row r="4"><c r="B4" t="inlineStr"><is><t>inline</t></is></c></row>
+  </sheetData>
+</worksheet>`
+
+func TestReadXLSX(t *testing.T) {
+	file := buildXLSX(t, map[string]string{
+		"xl/workbook.xml":            testWorkbook,
+		"xl/_rels/workbook.xml.rels": testRels,
+		"xl/sharedStrings.xml":       testSharedStrings,
+		"xl/worksheets/offers.xml":   testSheet,
+	})
+
+	rows, err := ReadXLSX(file, file.Size())
+	require.NoError(t, err)
+	require.Equal(t, [][]string{
+		{"hotel", "limit"},
+		nil,
+		{"Moscow Grand", "", "45658"},
+		{"", "inline"},
+	}, rows)
+}
+
+func TestReadXLSXFallbackSheet(t *testing.T) {
+	file := buildXLSX(t, map[string]string{
+		"xl/workbook.xml":          testWorkbook,
+		"xl/worksheets/sheet1.xml": `<worksheet><sheetData><row><c><v>1</v></c><c><v>2</v></c></row></sheetData></worksheet>`,
+	})
+
+	rows, err := ReadXLSX(file, file.Size())
+	require.NoError(t, err)
+	require.Equal(t, [][]string{{"1", "2"}}, rows)
+}
+
+func TestReadXLSXInvalid(t *testing.T) {
+	file := bytes.NewReader([]byte("type,name\nhotel,Grand\n"))
+
+	_, err := ReadXLSX(file, file.Size())
+	require.ErrorIs(t, err, ErrInvalidXLSX)
+
+	file = buildXLSX(t, map[string]string{"xl/workbook.xml": testWorkbook})
+	_, err = ReadXLSX(file, file.Size())
+	require.ErrorIs(t, err, ErrInvalidXLSX)
+}
+
+func TestExcelSerialToTime(t *testing.T) {
+	require.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), ExcelSerialToTime(45658))
+	require.Equal(t, time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC), ExcelSerialToTime(45658.5))
+}
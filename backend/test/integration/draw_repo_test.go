@@ -0,0 +1,254 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"testing"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	drawRepo "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/draw"
+	offerRepo "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/offer"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/application"
+	drawModel "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/draw"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/offer"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/pkg"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/pkg/testhelper"
+	"github.com/stretchr/testify/suite"
+)
+
+type DrawRepoSuite struct {
+	suite.Suite
+	db  *sqlx.DB
+	ctx context.Context
+
+	offerIDs []uuid.UUID
+	userIDs  []uuid.UUID
+}
+
+func TestDrawRepo(t *testing.T) {
+	suite.Run(t, &DrawRepoSuite{})
+}
+
+func (suite *DrawRepoSuite) SetupSuite() {
+	suite.ctx = context.Background()
+	suite.db = testhelper.NewPostgreSqlx(suite.T())
+}
+
+func (suite *DrawRepoSuite) TearDownTest() {
+	if len(suite.offerIDs) > 0 {
+		applications := sq.Select("id").From("application").Where(sq.Eq{"offer_id": suite.offerIDs})
+		for _, sql := range []sq.Sqlizer{
+			sq.Delete("draw").Where(sq.Eq{"offer_id": suite.offerIDs}),
+			sq.Delete("report").Where(sq.Expr("application_id IN (?)", applications)),
+			sq.Delete("application").Where(sq.Eq{"offer_id": suite.offerIDs}),
+			sq.Delete("offer").Where(sq.Eq{"id": suite.offerIDs}),
+		} {
+			suite.exec(suite.ctx, sql)
+		}
+	}
+	if len(suite.userIDs) > 0 {
+		suite.exec(suite.ctx, sq.Delete(`"user"`).Where(sq.Eq{"id": suite.userIDs}))
+	}
+
+	suite.offerIDs, suite.userIDs = nil, nil
+}
+
+func (suite *DrawRepoSuite) TestDrawNextConcurrentClaims() {
+	// Arrange
+	ctx, cancel := context.WithTimeout(suite.ctx, time.Minute)
+	defer cancel()
+
+	// Раньше любых других офферов в базе, чтобы обе реплики взяли именно их
+	first := suite.createOffer(ctx, time.Date(1999, 1, 1, 0, 0, 0, 0, time.UTC), offer.StatusPublished)
+	second := suite.createOffer(ctx, time.Date(1999, 1, 2, 0, 0, 0, 0, time.UTC), offer.StatusPublished)
+	firstApp := suite.createApplication(ctx, first, suite.createUser(ctx), application.APPLICATION_CREATED)
+	secondApp := suite.createApplication(ctx, second, suite.createUser(ctx), application.APPLICATION_CREATED)
+
+	// Оба розыгрыша ждут друг друга внутри своих транзакций: если бы второй ждал блокировку первого,
+	// они бы не встретились и тест упал бы по таймауту
+	var arrived sync.WaitGroup
+	arrived.Add(2)
+	met := make(chan struct{})
+	go func() {
+		arrived.Wait()
+		close(met)
+	}()
+
+	drawer := func(offerID uuid.UUID, winnersCount int, candidates []drawModel.Candidate) (drawModel.Draw, error) {
+		arrived.Done()
+		select {
+		case <-met:
+		case <-ctx.Done():
+			return drawModel.Draw{}, ctx.Err()
+		}
+		return firstWins(offerID, winnersCount, candidates), nil
+	}
+
+	repo := drawRepo.NewRepo(suite.db)
+
+	// Act
+	claimed := make([]uuid.UUID, 2)
+	errs := make([]error, 2)
+	var done sync.WaitGroup
+	for i := range claimed {
+		done.Add(1)
+		go func(i int) {
+			defer done.Done()
+			var ok bool
+			claimed[i], ok, errs[i] = repo.DrawNext(ctx, nil, drawer)
+			if errs[i] == nil && !ok {
+				errs[i] = errors.New("no offer claimed")
+			}
+		}(i)
+	}
+	done.Wait()
+
+	// Assert
+	suite.Require().NoError(errs[0])
+	suite.Require().NoError(errs[1])
+	suite.Require().ElementsMatch([]uuid.UUID{first, second}, claimed)
+
+	for offerID, applicationID := range map[uuid.UUID]uuid.UUID{first: firstApp, second: secondApp} {
+		suite.Require().Equal(offer.StatusAwarded, suite.offerStatus(ctx, offerID))
+		suite.Require().Equal(application.APPLICATION_ACCEPTED, suite.applicationStatus(ctx, applicationID))
+		suite.Require().Equal(1, suite.count(ctx, "report", sq.Eq{"application_id": applicationID}))
+		suite.Require().Equal(1, suite.count(ctx, "draw", sq.Eq{"offer_id": offerID}))
+	}
+}
+
+func (suite *DrawRepoSuite) TestDrawNextFinalizesStuckDraw() {
+	// Arrange
+	ctx, cancel := context.WithTimeout(suite.ctx, time.Minute)
+	defer cancel()
+
+	// Старый воркер сохранил розыгрыш и отчет победителю, но упал, не закончив оффер
+	offerID := suite.createOffer(ctx, time.Date(1999, 1, 1, 0, 0, 0, 0, time.UTC), offer.StatusDrawing)
+	loserUser, winnerUser := suite.createUser(ctx), suite.createUser(ctx)
+	loserApp := suite.createApplication(ctx, offerID, loserUser, application.APPLICATION_CREATED)
+	winnerApp := suite.createApplication(ctx, offerID, winnerUser, application.APPLICATION_CREATED)
+
+	drawID := uuid.New()
+	suite.exec(ctx, sq.Insert("draw").
+		Columns("id", "offer_id", "algorithm_version", "seed", "winners_count").
+		Values(drawID, offerID, pkg.DrawAlgorithmVersion, 42, 1))
+	suite.exec(ctx, sq.Insert("draw_candidate").
+		Columns("draw_id", "position", "application_id", "user_id", "rating", "weight", "winner_order").
+		Values(drawID, 0, loserApp, loserUser, 5, 0.5, nil).
+		Values(drawID, 1, winnerApp, winnerUser, 5, 0.5, 1))
+	suite.exec(ctx, sq.Insert("report").
+		Columns("id", "application_id", "expiration_at", "status", "text").
+		Values(uuid.New(), winnerApp, time.Now(), "created", ""))
+
+	drawer := func(uuid.UUID, int, []drawModel.Candidate) (drawModel.Draw, error) {
+		return drawModel.Draw{}, errors.New("stuck offer must not be drawn again")
+	}
+
+	// Act
+	claimed, ok, err := drawRepo.NewRepo(suite.db).DrawNext(ctx, nil, drawer)
+
+	// Assert
+	suite.Require().NoError(err)
+	suite.Require().True(ok)
+	suite.Require().Equal(offerID, claimed)
+
+	suite.Require().Equal(offer.StatusAwarded, suite.offerStatus(ctx, offerID))
+	suite.Require().Equal(application.APPLICATION_ACCEPTED, suite.applicationStatus(ctx, winnerApp))
+	suite.Require().Equal(application.APPLICATION_CREATED, suite.applicationStatus(ctx, loserApp))
+	suite.Require().Equal(1, suite.count(ctx, "report", sq.Eq{"application_id": winnerApp}))
+	suite.Require().Equal(0, suite.count(ctx, "report", sq.Eq{"application_id": loserApp}))
+	suite.Require().Equal(1, suite.count(ctx, "draw", sq.Eq{"offer_id": offerID}))
+}
+
+// firstWins - розыгрыш, в котором побеждает первый кандидат
+func firstWins(offerID uuid.UUID, winnersCount int, candidates []drawModel.Candidate) drawModel.Draw {
+	winnerOrder := 1
+	candidates[0].WinnerOrder = &winnerOrder
+	return drawModel.Draw{
+		ID:           uuid.New(),
+		OfferID:      offerID,
+		Algorithm:    pkg.DrawAlgorithmVersion,
+		Seed:         1,
+		WinnersCount: winnersCount,
+		Candidates:   candidates,
+	}
+}
+
+func (suite *DrawRepoSuite) createOffer(ctx context.Context, expirationAt time.Time, status offer.Status) uuid.UUID {
+	id := uuid.New()
+	err := offerRepo.New(suite.db, log.Default()).Create(ctx, id, offer.Create{
+		Task:              "Проверить завтрак",
+		RoomID:            uuid.MustParse("359d2a75-0237-4ce7-9e8f-adbc61357aa2"),
+		HotelID:           uuid.MustParse("f47ac10b-58cc-4372-a567-0e02b2c3d479"), // Moscow Grand Hotel
+		LocalID:           uuid.MustParse("f47ac10b-58cc-4372-a567-0e02b2c3d479"), // Москва
+		CheckIn:           time.Now().Add(24 * time.Hour),
+		CheckOut:          time.Now().Add(48 * time.Hour),
+		ExpirationAT:      expirationAt,
+		ParticipantsLimit: 10,
+		WinnersCount:      1,
+		Status:            status,
+	})
+	suite.Require().NoError(err)
+
+	suite.offerIDs = append(suite.offerIDs, id)
+	return id
+}
+
+func (suite *DrawRepoSuite) createUser(ctx context.Context) uuid.UUID {
+	id := uuid.New()
+	suite.exec(ctx, sq.Insert(`"user"`).
+		Columns("id", "ostrovok_login", "password_hash", "rating").
+		Values(id, "draw-test-"+id.String(), "hash", 5))
+
+	suite.userIDs = append(suite.userIDs, id)
+	return id
+}
+
+func (suite *DrawRepoSuite) createApplication(
+	ctx context.Context,
+	offerID, userID uuid.UUID,
+	status application.ApplicationStatus,
+) uuid.UUID {
+	id := uuid.New()
+	suite.exec(ctx, sq.Insert("application").
+		Columns("id", "user_id", "offer_id", "status").
+		Values(id, userID, offerID, status))
+	return id
+}
+
+func (suite *DrawRepoSuite) offerStatus(ctx context.Context, id uuid.UUID) offer.Status {
+	var status offer.Status
+	suite.Require().NoError(suite.db.GetContext(ctx, &status, `SELECT status FROM offer WHERE id = $1`, id))
+	return status
+}
+
+func (suite *DrawRepoSuite) applicationStatus(ctx context.Context, id uuid.UUID) application.ApplicationStatus {
+	var status application.ApplicationStatus
+	suite.Require().NoError(suite.db.GetContext(ctx, &status, `SELECT status FROM application WHERE id = $1`, id))
+	return status
+}
+
+func (suite *DrawRepoSuite) count(ctx context.Context, table string, where sq.Eq) int {
+	query, args, err := sq.Select("COUNT(*)").From(table).Where(where).PlaceholderFormat(sq.Dollar).ToSql()
+	suite.Require().NoError(err)
+
+	var count int
+	suite.Require().NoError(suite.db.GetContext(ctx, &count, query, args...))
+	return count
+}
+
+func (suite *DrawRepoSuite) exec(ctx context.Context, sql sq.Sqlizer) {
+	query, args, err := sql.ToSql()
+	suite.Require().NoError(err)
+	query, err = sq.Dollar.ReplacePlaceholders(query)
+	suite.Require().NoError(err)
+
+	_, err = suite.db.ExecContext(ctx, query, args...)
+	suite.Require().NoError(err, query)
+}
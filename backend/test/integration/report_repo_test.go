@@ -16,6 +16,7 @@ import (
 	reportRepo "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/client/postgres/report"
 	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/offer"
 	model "github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/internal/model/report"
+	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/pkg"
 	"github.com/ostrovok-hackathon-2025/afrikanskie-petushki/backend/pkg/testhelper"
 	"github.com/stretchr/testify/suite"
 )
@@ -37,7 +38,7 @@ func (suite *RepoSuite) SetupSuite() {
 
 func (suite *RepoSuite) SetupTest() {
 	for _, query := range []string{
-		"truncate photo, report;",
+		"truncate report_answer, photo, report;",
 	} {
 		_, err := suite.db.ExecContext(suite.ctx, query)
 		suite.Require().NoError(err, query)
@@ -46,7 +47,7 @@ func (suite *RepoSuite) SetupTest() {
 
 func (suite *RepoSuite) TearDownTest() {
 	for _, query := range []string{
-		"truncate photo, report;",
+		"truncate report_answer, photo, report;",
 	} {
 		_, err := suite.db.ExecContext(suite.ctx, query)
 		suite.Require().NoError(err, query)
@@ -105,7 +106,7 @@ func (suite *RepoSuite) TestCreate() {
 
 	getByIDRes, getByIDOk, getByIDErr := repo.GetByID(ctx, report.ID)
 	getImagesByReportIDRes, getImagesByReportIDErr := repo.GetImagesByReportID(ctx, report.ID)
-	getByUserIDRes, getByUserIDErr := repo.GetByUserID(ctx, report.UserID, 10, 0)
+	getByUserIDRes, getByUserIDErr := repo.GetByUserID(ctx, report.UserID, 10, 0, pkg.NewEmpty[model.Cursor]())
 	getRes, getErr := repo.Get(ctx, 10, 0)
 	countRes, countErr := repo.Count(ctx)
 
@@ -122,6 +123,8 @@ func (suite *RepoSuite) TestCreate() {
 
 	suite.Require().NoError(getByUserIDErr)
 	suite.Require().Len(getByUserIDRes, 1)
+	suite.Require().False(getByUserIDRes[0].CreatedAt.IsZero())
+	getByUserIDRes[0].CreatedAt = time.Time{}
 	suite.Require().EqualValues(report, getByUserIDRes[0])
 
 	suite.Require().NoError(getErr)